@@ -17,6 +17,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
 	"github.com/jesseduffield/lazygit/pkg/common"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/env"
@@ -264,7 +265,25 @@ func (app *App) Rebase() error {
 	app.Log.Info("args: ", os.Args)
 
 	if strings.HasSuffix(os.Args[1], "git-rebase-todo") {
-		if err := ioutil.WriteFile(os.Args[1], []byte(os.Getenv("LAZYGIT_REBASE_TODO")), 0644); err != nil {
+		content := os.Getenv("LAZYGIT_REBASE_TODO")
+
+		changes := os.Getenv("LAZYGIT_REBASE_TODO_CHANGES")
+		swap := os.Getenv("LAZYGIT_REBASE_TODO_SWAP")
+		if changes != "" || swap != "" {
+			// git has generated the todo itself (e.g. because we passed
+			// --rebase-merges) so we amend it rather than overwriting it
+			generated, err := ioutil.ReadFile(os.Args[1])
+			if err != nil {
+				return err
+			}
+
+			content, err = todo.ApplyChanges(string(generated), changes, strings.Fields(swap))
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := ioutil.WriteFile(os.Args[1], []byte(content), 0644); err != nil {
 			return err
 		}
 
@@ -84,6 +84,7 @@ func localisedTitle(mApp *app.App, str string) string {
 		"remotes":        tr.RemotesTitle,
 		"reflogCommits":  tr.ReflogCommitsTitle,
 		"tags":           tr.TagsTitle,
+		"worktrees":      tr.WorktreesTitle,
 		"commitFiles":    tr.CommitFilesTitle,
 		"commitMessage":  tr.CommitMessageTitle,
 		"commits":        tr.CommitsTitle,
@@ -93,6 +94,8 @@ func localisedTitle(mApp *app.App, str string) string {
 		"main":           tr.MainTitle,
 		"patchBuilding":  tr.PatchBuildingTitle,
 		"merging":        tr.MergingTitle,
+		"conflictEdit":   tr.ConflictEditTitle,
+		"blame":          tr.BlameTitle,
 		"normal":         tr.NormalTitle,
 		"staging":        tr.StagingTitle,
 		"menu":           tr.MenuTitle,
@@ -37,11 +37,14 @@ type GitCommand struct {
 	Tag         *git_commands.TagCommands
 	WorkingTree *git_commands.WorkingTreeCommands
 	Bisect      *git_commands.BisectCommands
+	Worktree    *git_commands.WorktreeCommands
+	Rerere      *git_commands.RerereCommands
 
 	Loaders Loaders
 }
 
 type Loaders struct {
+	Blame         *loaders.BlameLoader
 	Branches      *loaders.BranchLoader
 	CommitFiles   *loaders.CommitFileLoader
 	Commits       *loaders.CommitLoader
@@ -50,6 +53,7 @@ type Loaders struct {
 	Remotes       *loaders.RemoteLoader
 	Stash         *loaders.StashLoader
 	Tags          *loaders.TagLoader
+	Worktrees     *loaders.WorktreeLoader
 }
 
 func NewGitCommand(
@@ -98,7 +102,7 @@ func NewGitCommandAux(
 	gitCommon := git_commands.NewGitCommon(cmn, cmd, osCommand, dotGitDir, repo, configCommands)
 
 	statusCommands := git_commands.NewStatusCommands(gitCommon)
-	fileLoader := loaders.NewFileLoader(cmn, cmd, configCommands)
+	fileLoader := loaders.NewFileLoader(cmn, cmd, dotGitDir, configCommands)
 	flowCommands := git_commands.NewFlowCommands(gitCommon)
 	remoteCommands := git_commands.NewRemoteCommands(gitCommon)
 	branchCommands := git_commands.NewBranchCommands(gitCommon)
@@ -115,6 +119,8 @@ func NewGitCommandAux(
 	patchManager := patch.NewPatchManager(cmn.Log, workingTreeCommands.ApplyPatch, workingTreeCommands.ShowFileDiff)
 	patchCommands := git_commands.NewPatchCommands(gitCommon, rebaseCommands, commitCommands, statusCommands, stashCommands, patchManager)
 	bisectCommands := git_commands.NewBisectCommands(gitCommon)
+	worktreeCommands := git_commands.NewWorktreeCommands(gitCommon)
+	rerereCommands := git_commands.NewRerereCommands(gitCommon)
 
 	return &GitCommand{
 		Branch:      branchCommands,
@@ -133,7 +139,10 @@ func NewGitCommandAux(
 		Tag:         tagCommands,
 		Bisect:      bisectCommands,
 		WorkingTree: workingTreeCommands,
+		Worktree:    worktreeCommands,
+		Rerere:      rerereCommands,
 		Loaders: Loaders{
+			Blame:         loaders.NewBlameLoader(cmn, cmd),
 			Branches:      loaders.NewBranchLoader(cmn, branchCommands.GetRawBranches, branchCommands.CurrentBranchName, configCommands),
 			CommitFiles:   loaders.NewCommitFileLoader(cmn, cmd),
 			Commits:       loaders.NewCommitLoader(cmn, cmd, dotGitDir, branchCommands.CurrentBranchName, statusCommands.RebaseMode),
@@ -142,6 +151,7 @@ func NewGitCommandAux(
 			Remotes:       loaders.NewRemoteLoader(cmn, cmd, repo.Remotes),
 			Stash:         loaders.NewStashLoader(cmn, cmd),
 			Tags:          loaders.NewTagLoader(cmn, cmd),
+			Worktrees:     loaders.NewWorktreeLoader(cmn, cmd, os.Getwd),
 		},
 	}
 }
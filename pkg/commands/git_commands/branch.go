@@ -117,6 +117,51 @@ func (self *BranchCommands) GetUpstreamDifferenceCount(branchName string) (strin
 	return self.GetCommitDifferences(branchName, branchName+"@{u}")
 }
 
+// GetBranchBases returns the base branches that have been set for individual
+// branches via e.g. `git config branch.my-branch.base origin/main`, keyed by
+// branch name. We don't go through the cached git config given that bases can
+// be set at any time.
+func (self *BranchCommands) GetBranchBases() map[string]string {
+	bases := map[string]string{}
+
+	// git exits with an error when nothing matches
+	output, err := self.cmd.New(`git config --local --get-regexp "^branch[.].*[.]base$"`).DontLog().RunWithOutput()
+	if err != nil {
+		return bases
+	}
+
+	for _, line := range utils.SplitLines(output) {
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		branchName := strings.TrimSuffix(strings.TrimPrefix(parts[0], "branch."), ".base")
+		bases[branchName] = strings.TrimSpace(parts[1])
+	}
+
+	return bases
+}
+
+// GetBaseDifferenceCount returns how many commits the branch is ahead and behind
+// the given base branch
+func (self *BranchCommands) GetBaseDifferenceCount(branchName string, base string) (string, string) {
+	// the left count is the commits only on the base, the right only on the branch
+	output, err := self.cmd.New(
+		fmt.Sprintf("git rev-list --left-right --count %s", self.cmd.Quote(base+"..."+branchName)),
+	).DontLog().RunWithOutput()
+	if err != nil {
+		return "?", "?"
+	}
+
+	counts := strings.Fields(output)
+	if len(counts) != 2 {
+		return "?", "?"
+	}
+
+	return counts[1], counts[0]
+}
+
 // GetCommitDifferences checks how many pushables/pullables there are for the
 // current branch
 func (self *BranchCommands) GetCommitDifferences(from, to string) (string, string) {
@@ -141,8 +186,19 @@ func (self *BranchCommands) Rename(oldName string, newName string) error {
 	return self.cmd.New(fmt.Sprintf("git branch --move %s %s", self.cmd.Quote(oldName), self.cmd.Quote(newName))).Run()
 }
 
+// MergedInto returns the names of the local branches whose commits are all
+// reachable from the given ref
+func (self *BranchCommands) MergedInto(ref string) ([]string, error) {
+	output, err := self.cmd.New(fmt.Sprintf("git branch --merged %s --format=%%(refname:short)", self.cmd.Quote(ref))).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SplitLines(output), nil
+}
+
 func (self *BranchCommands) GetRawBranches() (string, error) {
-	return self.cmd.New(`git for-each-ref --sort=-committerdate --format="%(HEAD)|%(refname:short)|%(upstream:short)|%(upstream:track)" refs/heads`).DontLog().RunWithOutput()
+	return self.cmd.New(`git for-each-ref --sort=-committerdate --format="%(HEAD)|%(refname:short)|%(upstream:short)|%(upstream:track)|%(committerdate:unix)" refs/heads`).DontLog().RunWithOutput()
 }
 
 type MergeOpts struct {
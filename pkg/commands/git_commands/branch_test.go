@@ -98,6 +98,17 @@ func TestBranchDeleteBranch(t *testing.T) {
 	}
 }
 
+func TestBranchMergedInto(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		Expect(`git branch --merged "master" --format=%(refname:short)`, "master\nfeature/a\nbugfix/b\n", nil)
+	instance := buildBranchCommands(commonDeps{runner: runner})
+
+	names, err := instance.MergedInto("master")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"master", "feature/a", "bugfix/b"}, names)
+	runner.CheckForMissingCalls()
+}
+
 func TestBranchMerge(t *testing.T) {
 	runner := oscommands.NewFakeRunner(t).
 		Expect(`git merge --no-edit "test"`, "", nil)
@@ -223,3 +234,30 @@ func TestBranchCurrentBranchName(t *testing.T) {
 		})
 	}
 }
+
+func TestBranchGetBranchBases(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"config", "--local", "--get-regexp", "^branch[.].*[.]base$"}, "branch.feature/a.base origin/main\nbranch.release.base origin/release-1.0\n", nil).
+		// git exits with an error when there are no matches
+		ExpectGitArgs([]string{"config", "--local", "--get-regexp", "^branch[.].*[.]base$"}, "", errors.New("exit status 1"))
+	instance := buildBranchCommands(commonDeps{runner: runner})
+
+	assert.EqualValues(t, map[string]string{
+		"feature/a": "origin/main",
+		"release":   "origin/release-1.0",
+	}, instance.GetBranchBases())
+	// bases can be set at any time so we don't cache them
+	assert.EqualValues(t, map[string]string{}, instance.GetBranchBases())
+	runner.CheckForMissingCalls()
+}
+
+func TestBranchGetBaseDifferenceCount(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rev-list", "--left-right", "--count", "origin/main...feature/a"}, "1\t3\n", nil)
+	instance := buildBranchCommands(commonDeps{runner: runner})
+
+	ahead, behind := instance.GetBaseDifferenceCount("feature/a", "origin/main")
+	assert.EqualValues(t, "3", ahead)
+	assert.EqualValues(t, "1", behind)
+	runner.CheckForMissingCalls()
+}
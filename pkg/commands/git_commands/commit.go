@@ -2,9 +2,14 @@ package git_commands
 
 import (
 	"fmt"
+	"io/ioutil"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 type CommitCommands struct {
@@ -48,6 +53,64 @@ func (self *CommitCommands) CommitCmdObj(message string) oscommands.ICmdObj {
 	return self.cmd.New(fmt.Sprintf("git commit%s%s%s", noVerifyFlag, self.signoffFlag(), lineArgs))
 }
 
+// LintMessage checks the message against the rules configured in
+// git.commit.lint, returning a description of each broken rule. An empty
+// message breaks no rules
+func (self *CommitCommands) LintMessage(message string) []string {
+	rules := self.UserConfig.Git.Commit.Lint
+	message = strings.TrimRight(message, "\n")
+	warnings := []string{}
+	if message == "" {
+		return warnings
+	}
+
+	lines := strings.Split(message, "\n")
+	subject := lines[0]
+
+	if rules.MaxSubjectLength > 0 && utf8.RuneCountInString(subject) > rules.MaxSubjectLength {
+		warnings = append(warnings, utils.ResolvePlaceholderString(
+			self.Tr.LintSubjectTooLong,
+			map[string]string{"max": strconv.Itoa(rules.MaxSubjectLength)},
+		))
+	}
+
+	if rules.BlankSecondLine && len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		warnings = append(warnings, self.Tr.LintSecondLineNotBlank)
+	}
+
+	if rules.MaxBodyLineLength > 0 {
+		for i, line := range lines[1:] {
+			if utf8.RuneCountInString(line) > rules.MaxBodyLineLength {
+				warnings = append(warnings, utils.ResolvePlaceholderString(
+					self.Tr.LintBodyLineTooLong,
+					map[string]string{"line": strconv.Itoa(i + 2), "max": strconv.Itoa(rules.MaxBodyLineLength)},
+				))
+			}
+		}
+	}
+
+	if rules.ConventionalCommits && !isConventionalSubject(subject) {
+		warnings = append(warnings, self.Tr.LintNotConventionalCommit)
+	}
+
+	return warnings
+}
+
+// ValidateMessage returns an error if the message breaks any lint rules and
+// we've been configured to block on errors
+func (self *CommitCommands) ValidateMessage(message string) error {
+	if !self.UserConfig.Git.Commit.Lint.BlockOnError {
+		return nil
+	}
+
+	warnings := self.LintMessage(message)
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	return errors.New(self.Tr.CommitMessageLintFailed + "\n\n" + strings.Join(warnings, "\n"))
+}
+
 // runs git commit without the -m argument meaning it will invoke the user's editor
 func (self *CommitCommands) CommitEditorCmdObj() oscommands.ICmdObj {
 	return self.cmd.New(fmt.Sprintf("git commit%s", self.signoffFlag()))
@@ -61,6 +124,43 @@ func (self *CommitCommands) signoffFlag() string {
 	}
 }
 
+// GetCommitTemplate returns the content of the configured commit template, with
+// comments stripped, or an empty string if there is none
+func (self *CommitCommands) GetCommitTemplate() (string, error) {
+	path := self.config.GetCommitTemplatePath()
+	if path == "" {
+		return "", nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return CleanCommitTemplate(string(content)), nil
+}
+
+// GetAuthors returns the authors of the commits reachable from HEAD, most
+// prolific first, in the form used by trailers e.g. 'Jesse <jesse@example.com>'
+func (self *CommitCommands) GetAuthors() ([]string, error) {
+	output, err := self.cmd.New("git shortlog -sne HEAD").DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	authors := []string{}
+	for _, line := range utils.SplitLines(output) {
+		// each line is like '    12\tJesse <jesse@example.com>'
+		fields := strings.SplitN(strings.TrimSpace(line), "\t", 2)
+		if len(fields) < 2 {
+			continue
+		}
+		authors = append(authors, fields[1])
+	}
+
+	return authors, nil
+}
+
 // Get the subject of the HEAD commit
 func (self *CommitCommands) GetHeadCommitMessage() (string, error) {
 	message, err := self.cmd.New("git log -1 --pretty=%s").DontLog().RunWithOutput()
@@ -110,6 +210,22 @@ func (self *CommitCommands) ShowCmdObj(sha string, filterPath string) oscommands
 	return self.cmd.New(cmdStr).DontLog()
 }
 
+// ShowLineRangeCmdObj shows the changes to the given line range (e.g.
+// '10,20:path/to/file') in the commit at the given index of `git log -L`'s
+// results. We can't just pass the commit's sha because git needs to walk
+// history from refName in order to know where the lines were in that commit.
+func (self *CommitCommands) ShowLineRangeCmdObj(refName string, lineRange string, idx int) oscommands.ICmdObj {
+	cmdStr := fmt.Sprintf(
+		"git log %s --%s --color=%s --skip=%d -n 1 -L %s",
+		self.cmd.Quote(refName),
+		self.UserConfig.Git.Log.Order,
+		self.UserConfig.Git.Paging.ColorArg,
+		idx,
+		self.cmd.Quote(lineRange),
+	)
+	return self.cmd.New(cmdStr).DontLog()
+}
+
 // Revert reverts the selected commit by sha
 func (self *CommitCommands) Revert(sha string) error {
 	return self.cmd.New(fmt.Sprintf("git revert %s", sha)).Run()
@@ -123,3 +239,8 @@ func (self *CommitCommands) RevertMerge(sha string, parentNumber int) error {
 func (self *CommitCommands) CreateFixupCommit(sha string) error {
 	return self.cmd.New(fmt.Sprintf("git commit --fixup=%s", sha)).Run()
 }
+
+// IsAncestorOfHead tells us whether the given commit is reachable from HEAD
+func (self *CommitCommands) IsAncestorOfHead(sha string) bool {
+	return self.cmd.New(fmt.Sprintf("git merge-base --is-ancestor %s HEAD", self.cmd.Quote(sha))).DontLog().Run() == nil
+}
@@ -0,0 +1,82 @@
+package git_commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// These functions manipulate a commit message as typed into the commit message
+// panel, before we ever pass it to git
+
+var trailerRegex = regexp.MustCompile(`^[A-Za-z0-9-]+: .+$`)
+
+// matches e.g. 'feat: ', 'fix(parser): ' or 'refactor!: ' at the start of the
+// subject line
+var conventionalPrefixRegex = regexp.MustCompile(`^[a-z]+(\([^)]*\))?!?: `)
+
+// AddTrailer adds a trailer like 'Signed-off-by: Jesse <jesse@example.com>' to
+// the end of the message. If the last paragraph of the message already consists
+// of trailers, the new trailer joins them, otherwise it starts a new paragraph.
+func AddTrailer(message string, key string, value string) string {
+	trailer := fmt.Sprintf("%s: %s", key, value)
+
+	message = strings.TrimRight(message, " \n")
+	for _, line := range strings.Split(message, "\n") {
+		if line == trailer {
+			return message
+		}
+	}
+
+	paragraphs := strings.Split(message, "\n\n")
+	if len(paragraphs) > 1 && isTrailerParagraph(paragraphs[len(paragraphs)-1]) {
+		return message + "\n" + trailer
+	}
+
+	return message + "\n\n" + trailer
+}
+
+func isTrailerParagraph(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		if !trailerRegex.MatchString(line) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetConventionalCommitType sets the conventional commit type (and optional
+// scope) of the message's subject line, replacing any existing one e.g.
+// 'fix: typo' becomes 'docs(readme): typo'
+func SetConventionalCommitType(message string, commitType string, scope string) string {
+	prefix := commitType
+	if scope != "" {
+		prefix += "(" + scope + ")"
+	}
+	prefix += ": "
+
+	lines := strings.SplitN(message, "\n", 2)
+	lines[0] = prefix + conventionalPrefixRegex.ReplaceAllString(lines[0], "")
+
+	return strings.Join(lines, "\n")
+}
+
+func isConventionalSubject(subject string) bool {
+	prefix := conventionalPrefixRegex.FindString(subject)
+	return prefix != "" && strings.TrimSpace(subject[len(prefix):]) != ""
+}
+
+// CleanCommitTemplate strips the comment lines from the content of a commit
+// template, as git would when committing
+func CleanCommitTemplate(content string) string {
+	lines := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,99 @@
+package git_commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddTrailer(t *testing.T) {
+	type scenario struct {
+		testName string
+		message  string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "empty message",
+			message:  "",
+			expected: "\n\nCo-authored-by: Jesse <jesse@example.com>",
+		},
+		{
+			testName: "subject only",
+			message:  "Fix bug\n",
+			expected: "Fix bug\n\nCo-authored-by: Jesse <jesse@example.com>",
+		},
+		{
+			testName: "subject that looks like a trailer",
+			message:  "fix: bug",
+			expected: "fix: bug\n\nCo-authored-by: Jesse <jesse@example.com>",
+		},
+		{
+			testName: "existing trailers",
+			message:  "Fix bug\n\nSome detail\n\nSigned-off-by: Me <me@example.com>",
+			expected: "Fix bug\n\nSome detail\n\nSigned-off-by: Me <me@example.com>\nCo-authored-by: Jesse <jesse@example.com>",
+		},
+		{
+			testName: "body that isn't trailers",
+			message:  "Fix bug\n\nSome detail: about the bug\nmore detail",
+			expected: "Fix bug\n\nSome detail: about the bug\nmore detail\n\nCo-authored-by: Jesse <jesse@example.com>",
+		},
+		{
+			testName: "trailer already present",
+			message:  "Fix bug\n\nCo-authored-by: Jesse <jesse@example.com>",
+			expected: "Fix bug\n\nCo-authored-by: Jesse <jesse@example.com>",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			assert.Equal(t, s.expected, AddTrailer(s.message, "Co-authored-by", "Jesse <jesse@example.com>"))
+		})
+	}
+}
+
+func TestSetConventionalCommitType(t *testing.T) {
+	type scenario struct {
+		testName   string
+		message    string
+		commitType string
+		scope      string
+		expected   string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:   "empty message",
+			message:    "",
+			commitType: "feat",
+			expected:   "feat: ",
+		},
+		{
+			testName:   "with scope",
+			message:    "add thing\n\nbody",
+			commitType: "feat",
+			scope:      "gui",
+			expected:   "feat(gui): add thing\n\nbody",
+		},
+		{
+			testName:   "replaces existing type",
+			message:    "fix(parser)!: handle empty input",
+			commitType: "refactor",
+			expected:   "refactor: handle empty input",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			assert.Equal(t, s.expected, SetConventionalCommitType(s.message, s.commitType, s.scope))
+		})
+	}
+}
+
+func TestCleanCommitTemplate(t *testing.T) {
+	content := "\n# Why is this change needed?\nTicket: \n\n# Lines starting with '#' are ignored\n"
+	assert.Equal(t, "\nTicket:", CleanCommitTemplate(content))
+}
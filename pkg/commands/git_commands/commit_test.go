@@ -1,8 +1,12 @@
 package git_commands
 
 import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/stretchr/testify/assert"
@@ -161,3 +165,131 @@ func TestCommitShowCmdObj(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitShowLineRangeCmdObj(t *testing.T) {
+	instance := buildCommitCommands(commonDeps{})
+
+	cmdStr := instance.ShowLineRangeCmdObj("HEAD", "10,20:file.txt", 3).ToString()
+	assert.Equal(t, `git log "HEAD" --topo-order --color=always --skip=3 -n 1 -L "10,20:file.txt"`, cmdStr)
+}
+
+func TestCommitGetAuthors(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"shortlog", "-sne", "HEAD"}, "    12\tJesse Duffield <jesse@example.com>\n     3\tMark <mark@example.com>\n", nil)
+	instance := buildCommitCommands(commonDeps{runner: runner})
+
+	authors, err := instance.GetAuthors()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Jesse Duffield <jesse@example.com>", "Mark <mark@example.com>"}, authors)
+	runner.CheckForMissingCalls()
+}
+
+func TestCommitGetCommitTemplate(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "template.txt")
+	err := ioutil.WriteFile(templatePath, []byte("\n# explain why\nTicket: \n"), 0644)
+	assert.NoError(t, err)
+
+	instance := buildCommitCommands(commonDeps{
+		gitConfig: git_config.NewFakeGitConfig(map[string]string{"commit.template": templatePath}),
+	})
+
+	template, err := instance.GetCommitTemplate()
+	assert.NoError(t, err)
+	assert.Equal(t, "\nTicket:", template)
+
+	instance = buildCommitCommands(commonDeps{})
+	template, err = instance.GetCommitTemplate()
+	assert.NoError(t, err)
+	assert.Equal(t, "", template)
+}
+
+func TestCommitLintMessage(t *testing.T) {
+	type scenario struct {
+		testName string
+		rules    config.CommitLintConfig
+		message  string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "no rules",
+			message:  "a subject that goes on and on and on and on and on and on and on and on\nnot blank",
+			expected: []string{},
+		},
+		{
+			testName: "empty message",
+			rules:    config.CommitLintConfig{MaxSubjectLength: 5, ConventionalCommits: true},
+			message:  "",
+			expected: []string{},
+		},
+		{
+			testName: "all rules pass",
+			rules: config.CommitLintConfig{
+				MaxSubjectLength:    50,
+				BlankSecondLine:     true,
+				MaxBodyLineLength:   20,
+				ConventionalCommits: true,
+			},
+			message:  "feat(gui): add lint\n\nshort body line\n",
+			expected: []string{},
+		},
+		{
+			testName: "all rules fail",
+			rules: config.CommitLintConfig{
+				MaxSubjectLength:    10,
+				BlankSecondLine:     true,
+				MaxBodyLineLength:   10,
+				ConventionalCommits: true,
+			},
+			message: "Add commit message linting\nbody\n\nthis line is too long",
+			expected: []string{
+				"subject is longer than 10 characters",
+				"second line should be blank",
+				"line 4 is longer than 10 characters",
+				"subject should be like 'type(scope): description'",
+			},
+		},
+		{
+			testName: "conventional commit without description",
+			rules:    config.CommitLintConfig{ConventionalCommits: true},
+			message:  "feat: ",
+			expected: []string{"subject should be like 'type(scope): description'"},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			userConfig := config.GetDefaultConfig()
+			userConfig.Git.Commit.Lint = s.rules
+			instance := buildCommitCommands(commonDeps{userConfig: userConfig})
+
+			assert.Equal(t, s.expected, instance.LintMessage(s.message))
+		})
+	}
+}
+
+func TestCommitValidateMessage(t *testing.T) {
+	userConfig := config.GetDefaultConfig()
+	userConfig.Git.Commit.Lint = config.CommitLintConfig{MaxSubjectLength: 5}
+	instance := buildCommitCommands(commonDeps{userConfig: userConfig})
+
+	// only warnings unless we block on errors
+	assert.NoError(t, instance.ValidateMessage("too long"))
+
+	userConfig.Git.Commit.Lint.BlockOnError = true
+	assert.Error(t, instance.ValidateMessage("too long"))
+	assert.NoError(t, instance.ValidateMessage("short"))
+}
+
+func TestCommitIsAncestorOfHead(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"merge-base", "--is-ancestor", "abc123", "HEAD"}, "", nil).
+		ExpectGitArgs([]string{"merge-base", "--is-ancestor", "def456", "HEAD"}, "", errors.New("exit status 1"))
+	instance := buildCommitCommands(commonDeps{runner: runner})
+
+	assert.True(t, instance.IsAncestorOfHead("abc123"))
+	assert.False(t, instance.IsAncestorOfHead("def456"))
+	runner.CheckForMissingCalls()
+}
@@ -1,7 +1,9 @@
 package git_commands
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -68,13 +70,38 @@ func (self *ConfigCommands) UsingGpg() bool {
 	return self.gitConfig.GetBool("commit.gpgsign")
 }
 
+// GetUserIdentity returns the configured user in the form used by trailers
+// e.g. 'Jesse Duffield <jesse@example.com>'
+func (self *ConfigCommands) GetUserIdentity() string {
+	return fmt.Sprintf("%s <%s>", self.gitConfig.Get("user.name"), self.gitConfig.Get("user.email"))
+}
+
+// GetCommitTemplatePath returns the path of the file configured as the commit
+// template, if any, expanding a leading '~/' as git does
+func (self *ConfigCommands) GetCommitTemplatePath() string {
+	path := self.gitConfig.Get("commit.template")
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	return path
+}
+
 func (self *ConfigCommands) GetCoreEditor() string {
 	return self.gitConfig.Get("core.editor")
 }
 
 // GetRemoteURL returns current repo remote url
 func (self *ConfigCommands) GetRemoteURL() string {
-	return self.gitConfig.Get("remote.origin.url")
+	return self.GetRemoteURLFor("origin")
+}
+
+// GetRemoteURLFor returns the URL of the given remote, or an empty string if
+// there's no such remote
+func (self *ConfigCommands) GetRemoteURLFor(remoteName string) string {
+	return self.gitConfig.Get(fmt.Sprintf("remote.%s.url", remoteName))
 }
 
 func (self *ConfigCommands) GetShowUntrackedFiles() string {
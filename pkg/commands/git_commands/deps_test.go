@@ -6,6 +6,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
 	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/common"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -87,7 +88,7 @@ func buildRepo() *gogit.Repository {
 }
 
 func buildFileLoader(gitCommon *GitCommon) *loaders.FileLoader {
-	return loaders.NewFileLoader(gitCommon.Common, gitCommon.cmd, gitCommon.config)
+	return loaders.NewFileLoader(gitCommon.Common, gitCommon.cmd, gitCommon.dotGitDir, gitCommon.config)
 }
 
 func buildSubmoduleCommands(deps commonDeps) *SubmoduleCommands {
@@ -131,6 +132,12 @@ func buildSyncCommands(deps commonDeps) *SyncCommands {
 	return NewSyncCommands(gitCommon)
 }
 
+func buildTagCommands(deps commonDeps) *TagCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewTagCommands(gitCommon)
+}
+
 func buildFileCommands(deps commonDeps) *FileCommands {
 	gitCommon := buildGitCommon(deps)
 
@@ -142,3 +149,30 @@ func buildBranchCommands(deps commonDeps) *BranchCommands {
 
 	return NewBranchCommands(gitCommon)
 }
+
+func buildWorktreeCommands(deps commonDeps) *WorktreeCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewWorktreeCommands(gitCommon)
+}
+
+func buildRerereCommands(deps commonDeps) *RerereCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewRerereCommands(gitCommon)
+}
+
+func buildPatchCommands(deps commonDeps, patchManager *patch.PatchManager) *PatchCommands {
+	gitCommon := buildGitCommon(deps)
+	rebaseCommands := buildRebaseCommands(deps)
+	commitCommands := buildCommitCommands(deps)
+	statusCommands := NewStatusCommands(gitCommon)
+	stashCommands := buildStashCommands(deps)
+
+	return NewPatchCommands(gitCommon, rebaseCommands, commitCommands, statusCommands, stashCommands, patchManager)
+}
+
+func buildStatusCommands(deps commonDeps) *StatusCommands {
+	gitCommon := buildGitCommon(deps)
+	return NewStatusCommands(gitCommon)
+}
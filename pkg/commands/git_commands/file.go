@@ -1,6 +1,7 @@
 package git_commands
 
 import (
+	"fmt"
 	"io/ioutil"
 	"strconv"
 
@@ -27,6 +28,18 @@ func (self *FileCommands) Cat(fileName string) (string, error) {
 	return string(buf), nil
 }
 
+// the index stages of a conflicted file
+const (
+	STAGE_BASE   = 1
+	STAGE_OURS   = 2
+	STAGE_THEIRS = 3
+)
+
+// ShowStage obtains the content of a conflicted file at the given index stage
+func (self *FileCommands) ShowStage(fileName string, stage int) (string, error) {
+	return self.cmd.New("git show " + self.cmd.Quote(fmt.Sprintf(":%d:%s", stage, fileName))).DontLog().RunWithOutput()
+}
+
 func (self *FileCommands) GetEditCmdStr(filename string, lineNumber int) (string, error) {
 	editor := self.UserConfig.OS.EditCommand
 
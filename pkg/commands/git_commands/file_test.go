@@ -161,3 +161,14 @@ func TestEditFileCmdStr(t *testing.T) {
 		s.runner.CheckForMissingCalls()
 	}
 }
+
+func TestShowStage(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		Expect(`git show ":3:dir/my file.go"`, "theirs\n", nil)
+	instance := buildFileCommands(commonDeps{runner: runner})
+
+	content, err := instance.ShowStage("dir/my file.go", STAGE_THEIRS)
+	assert.NoError(t, err)
+	assert.Equal(t, "theirs\n", content)
+	runner.CheckForMissingCalls()
+}
@@ -1,11 +1,19 @@
 package git_commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
 	"github.com/jesseduffield/lazygit/pkg/commands/types/enums"
 )
 
@@ -105,16 +113,16 @@ func (self *PatchCommands) MovePatchToSelectedCommit(commits []*models.Commit, s
 	}
 
 	baseIndex := sourceCommitIdx + 1
-	todo := ""
-	for i, commit := range commits[0:baseIndex] {
-		a := "pick"
+	todos := make([]todo.Todo, 0, baseIndex)
+	for i := baseIndex - 1; i >= 0; i-- {
+		action := todo.Pick
 		if i == sourceCommitIdx || i == destinationCommitIdx {
-			a = "edit"
+			action = todo.Edit
 		}
-		todo = a + " " + commit.Sha + " " + commit.Name + "\n" + todo
+		todos = append(todos, commitTodo(action, commits[i]))
 	}
 
-	err := self.rebase.PrepareInteractiveRebaseCommand(commits[baseIndex].Sha, todo, true).Run()
+	err := self.rebase.PrepareInteractiveRebaseCommand(commits[baseIndex].Sha, todo.Write(todos), true).Run()
 	if err != nil {
 		return err
 	}
@@ -254,3 +262,134 @@ func (self *PatchCommands) PullPatchIntoNewCommit(commits []*models.Commit, comm
 	self.PatchManager.Reset()
 	return self.rebase.ContinueRebase()
 }
+
+// ExportPatch writes the current patch to the given path, in a format that can
+// be applied with `git apply`
+func (self *PatchCommands) ExportPatch(path string) error {
+	return self.os.CreateFileWithContent(path, self.PatchManager.RenderAggregatedPatchColored(true))
+}
+
+// savedPatchesDir returns where we save patches. We use the common git dir so
+// that a patch saved in one worktree can be opened from any other
+func (self *PatchCommands) savedPatchesDir() (string, error) {
+	commonGitDir, err := self.status.CommonGitDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(commonGitDir, "lazygit", "patches"), nil
+}
+
+func (self *PatchCommands) savedPatchPath(name string) (string, error) {
+	dir, err := self.savedPatchesDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// SavePatch saves the current patch under the given name, replacing any patch
+// already saved with that name
+func (self *PatchCommands) SavePatch(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return errors.New(self.Tr.InvalidPatchName)
+	}
+
+	state := self.PatchManager.State()
+	isStash := isStashRef(state.To)
+	// a stash ref like stash@{1} points at a different entry as soon as another
+	// is pushed or dropped, so we save the commits the patch is between instead
+	for _, ref := range []*string{&state.From, &state.To} {
+		if !isStashRef(*ref) {
+			continue
+		}
+
+		sha, err := self.cmd.New("git rev-parse " + self.cmd.Quote(*ref)).DontLog().RunWithOutput()
+		if err != nil {
+			return err
+		}
+		*ref = strings.TrimSpace(sha)
+	}
+
+	savedPatch := &patch.SavedPatch{
+		Name:          name,
+		UnixTimestamp: time.Now().Unix(),
+		Patch:         self.PatchManager.RenderAggregatedPatchColored(true),
+		State:         state,
+		Stash:         isStash,
+	}
+
+	content, err := json.MarshalIndent(savedPatch, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path, err := self.savedPatchPath(name)
+	if err != nil {
+		return err
+	}
+
+	return self.os.CreateFileWithContent(path, string(content))
+}
+
+func isStashRef(ref string) bool {
+	return strings.HasPrefix(ref, "stash@{")
+}
+
+// GetSavedPatches returns the patches saved for this repo, most recent first
+func (self *PatchCommands) GetSavedPatches() ([]*patch.SavedPatch, error) {
+	dir, err := self.savedPatchesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*patch.SavedPatch{}, nil
+		}
+		return nil, err
+	}
+
+	savedPatches := []*patch.SavedPatch{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		savedPatch := &patch.SavedPatch{}
+		if err := json.Unmarshal(content, savedPatch); err != nil {
+			// we don't want one bad file to stop the user from getting at the rest
+			self.Log.Errorf("could not parse saved patch %s: %v", file.Name(), err)
+			continue
+		}
+
+		if savedPatch.State == nil {
+			self.Log.Errorf("saved patch %s has no state", file.Name())
+			continue
+		}
+
+		savedPatches = append(savedPatches, savedPatch)
+	}
+
+	sort.SliceStable(savedPatches, func(i, j int) bool {
+		return savedPatches[i].UnixTimestamp > savedPatches[j].UnixTimestamp
+	})
+
+	return savedPatches, nil
+}
+
+func (self *PatchCommands) DeleteSavedPatch(name string) error {
+	path, err := self.savedPatchPath(name)
+	if err != nil {
+		return err
+	}
+
+	return self.os.Remove(path)
+}
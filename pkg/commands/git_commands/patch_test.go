@@ -0,0 +1,139 @@
+package git_commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const patchTestDiff = `diff --git a/file.txt b/file.txt
+index dcd3485..1ba5540 100644
+--- a/file.txt
++++ b/file.txt
+@@ -1,2 +1,2 @@
+ apple
+-orange
++grape
+`
+
+func buildPatchCommandsWithPatch(t *testing.T, dotGitDir string) *PatchCommands {
+	return buildPatchCommandsWithPatchFrom(t, commonDeps{dotGitDir: dotGitDir}, "abc123")
+}
+
+func buildPatchCommandsWithPatchFrom(t *testing.T, deps commonDeps, to string) *PatchCommands {
+	loadFileDiff := func(from string, to string, reverse bool, filename string, plain bool) (string, error) {
+		return patchTestDiff, nil
+	}
+	patchManager := patch.NewPatchManager(utils.NewDummyLog(), nil, loadFileDiff)
+	patchManager.Start(to+"^", to, false, true)
+	assert.NoError(t, patchManager.AddFileWhole("file.txt"))
+
+	return buildPatchCommands(deps, patchManager)
+}
+
+func TestPatchExportPatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-patch")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	instance := buildPatchCommandsWithPatch(t, dir)
+	path := filepath.Join(dir, "exported", "my.patch")
+	assert.NoError(t, instance.ExportPatch(path))
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, patchTestDiff+"\n", string(content))
+}
+
+func expectCommonGitDir(runner *oscommands.FakeCmdObjRunner, commonGitDir string, times int) *oscommands.FakeCmdObjRunner {
+	for i := 0; i < times; i++ {
+		runner.Expect("git rev-parse --git-common-dir", commonGitDir+"\n", nil)
+	}
+
+	return runner
+}
+
+func TestPatchSavedPatches(t *testing.T) {
+	commonGitDir, err := ioutil.TempDir("", "lazygit-patch")
+	assert.NoError(t, err)
+	defer os.RemoveAll(commonGitDir)
+
+	runner := expectCommonGitDir(oscommands.NewFakeRunner(t), commonGitDir, 5)
+	instance := buildPatchCommandsWithPatchFrom(t, commonDeps{dotGitDir: commonGitDir, runner: runner}, "abc123")
+
+	savedPatches, err := instance.GetSavedPatches()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*patch.SavedPatch{}, savedPatches)
+
+	for _, name := range []string{"", "a/b", ".hidden"} {
+		assert.Error(t, instance.SavePatch(name), name)
+	}
+
+	assert.NoError(t, instance.SavePatch("fruit"))
+	// a file we can't parse, or one without any patch state, shouldn't stop us
+	// loading the others
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(commonGitDir, "lazygit", "patches", "broken.json"), []byte("{"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(commonGitDir, "lazygit", "patches", "stateless.json"), []byte(`{"Name": "stateless"}`), 0644))
+
+	savedPatches, err = instance.GetSavedPatches()
+	assert.NoError(t, err)
+	assert.Len(t, savedPatches, 1)
+	assert.Equal(t, "fruit", savedPatches[0].Name)
+	assert.Equal(t, patchTestDiff+"\n", savedPatches[0].Patch)
+	assert.EqualValues(t, instance.PatchManager.State(), savedPatches[0].State)
+
+	assert.NoError(t, instance.DeleteSavedPatch("fruit"))
+	savedPatches, err = instance.GetSavedPatches()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*patch.SavedPatch{}, savedPatches)
+	runner.CheckForMissingCalls()
+}
+
+func TestPatchSavePatchInLinkedWorktree(t *testing.T) {
+	commonGitDir, err := ioutil.TempDir("", "lazygit-patch")
+	assert.NoError(t, err)
+	defer os.RemoveAll(commonGitDir)
+
+	// a linked worktree has its own git dir inside the common one
+	dotGitDir := filepath.Join(commonGitDir, "worktrees", "other")
+	runner := expectCommonGitDir(oscommands.NewFakeRunner(t), commonGitDir, 1)
+	instance := buildPatchCommandsWithPatchFrom(t, commonDeps{dotGitDir: dotGitDir, runner: runner}, "abc123")
+
+	assert.NoError(t, instance.SavePatch("shared"))
+	runner.CheckForMissingCalls()
+
+	_, err = os.Stat(filepath.Join(commonGitDir, "lazygit", "patches", "shared.json"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dotGitDir, "lazygit"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestPatchSavePatchFromStash(t *testing.T) {
+	commonGitDir, err := ioutil.TempDir("", "lazygit-patch")
+	assert.NoError(t, err)
+	defer os.RemoveAll(commonGitDir)
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rev-parse", "stash@{1}^"}, "aaa111\n", nil).
+		ExpectGitArgs([]string{"rev-parse", "stash@{1}"}, "bbb222\n", nil)
+	expectCommonGitDir(runner, commonGitDir, 2)
+	instance := buildPatchCommandsWithPatchFrom(t, commonDeps{dotGitDir: commonGitDir, runner: runner}, "stash@{1}")
+
+	assert.NoError(t, instance.SavePatch("stashed"))
+
+	savedPatches, err := instance.GetSavedPatches()
+	assert.NoError(t, err)
+	assert.Len(t, savedPatches, 1)
+	assert.True(t, savedPatches[0].Stash)
+	assert.Equal(t, "aaa111", savedPatches[0].State.From)
+	assert.Equal(t, "bbb222", savedPatches[0].State.To)
+	// the patch we're building is left alone
+	assert.Equal(t, "stash@{1}", instance.PatchManager.To)
+	runner.CheckForMissingCalls()
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 type RebaseCommands struct {
@@ -32,6 +34,10 @@ func NewRebaseCommands(
 }
 
 func (self *RebaseCommands) RewordCommit(commits []*models.Commit, index int, message string) error {
+	if err := self.commit.ValidateMessage(message); err != nil {
+		return err
+	}
+
 	if index == 0 {
 		// we've selected the top commit so no rebase is required
 		return self.commit.RewordLastCommit(message)
@@ -52,37 +58,82 @@ func (self *RebaseCommands) RewordCommit(commits []*models.Commit, index int, me
 }
 
 func (self *RebaseCommands) RewordCommitInEditor(commits []*models.Commit, index int) (oscommands.ICmdObj, error) {
-	todo, sha, err := self.GenerateGenericRebaseTodo(commits, index, "reword")
+	todoStr, sha, err := self.GenerateGenericRebaseTodo(commits, index, todo.Reword)
 	if err != nil {
 		return nil, err
 	}
 
-	return self.PrepareInteractiveRebaseCommand(sha, todo, false), nil
+	return self.PrepareInteractiveRebaseCommand(sha, todoStr, false), nil
 }
 
 func (self *RebaseCommands) MoveCommitDown(commits []*models.Commit, index int) error {
-	// we must ensure that we have at least two commits after the selected one
-	if len(commits) <= index+2 {
+	return self.MoveCommitsDown(commits, index, index)
+}
+
+// MoveCommitsDown moves the commits from startIdx to endIdx (inclusive) below
+// the commit that follows them
+func (self *RebaseCommands) MoveCommitsDown(commits []*models.Commit, startIdx int, endIdx int) error {
+	// we must ensure that we have at least two commits after the selected ones
+	if len(commits) <= endIdx+2 {
 		// assuming they aren't picking the bottom commit
 		return errors.New(self.Tr.NoRoom)
 	}
 
-	todo := ""
-	orderedCommits := append(commits[0:index], commits[index+1], commits[index])
-	for _, commit := range orderedCommits {
-		todo = "pick " + commit.Sha + " " + commit.Name + "\n" + todo
+	orderedCommits := make([]*models.Commit, 0, endIdx+2)
+	orderedCommits = append(orderedCommits, commits[0:startIdx]...)
+	orderedCommits = append(orderedCommits, commits[endIdx+1])
+	orderedCommits = append(orderedCommits, commits[startIdx:endIdx+1]...)
+
+	return self.PrepareInteractiveRebaseCommand(commits[endIdx+2].Sha, pickTodo(orderedCommits), true).Run()
+}
+
+// MoveCommitsUp moves the commits from startIdx to endIdx (inclusive) above
+// the commit that precedes them
+func (self *RebaseCommands) MoveCommitsUp(commits []*models.Commit, startIdx int, endIdx int) error {
+	if startIdx == 0 {
+		return errors.New(self.Tr.NoRoom)
+	}
+
+	if len(commits) <= endIdx+1 {
+		return errors.New(self.Tr.CannotRebaseOntoFirstCommit)
+	}
+
+	orderedCommits := make([]*models.Commit, 0, endIdx+1)
+	orderedCommits = append(orderedCommits, commits[0:startIdx-1]...)
+	orderedCommits = append(orderedCommits, commits[startIdx:endIdx+1]...)
+	orderedCommits = append(orderedCommits, commits[startIdx-1])
+
+	return self.PrepareInteractiveRebaseCommand(commits[endIdx+1].Sha, pickTodo(orderedCommits), true).Run()
+}
+
+// pickTodo returns a todo which picks the given commits, which are expected to
+// be ordered from newest to oldest
+func pickTodo(commits []*models.Commit) string {
+	todos := make([]todo.Todo, 0, len(commits))
+	for i := len(commits) - 1; i >= 0; i-- {
+		todos = append(todos, commitTodo(todo.Pick, commits[i]))
 	}
 
-	return self.PrepareInteractiveRebaseCommand(commits[index+2].Sha, todo, true).Run()
+	return todo.Write(todos)
 }
 
-func (self *RebaseCommands) InteractiveRebase(commits []*models.Commit, index int, action string) error {
-	todo, sha, err := self.GenerateGenericRebaseTodo(commits, index, action)
+func commitTodo(command todo.TodoCommand, commit *models.Commit) todo.Todo {
+	return todo.Todo{Command: command, Commit: commit.Sha, Msg: commit.Name}
+}
+
+func (self *RebaseCommands) InteractiveRebase(commits []*models.Commit, index int, action todo.TodoCommand) error {
+	return self.InteractiveRebaseRange(commits, index, index, action)
+}
+
+// InteractiveRebaseRange applies the given action to each commit from startIdx
+// to endIdx (inclusive)
+func (self *RebaseCommands) InteractiveRebaseRange(commits []*models.Commit, startIdx int, endIdx int, action todo.TodoCommand) error {
+	todoStr, sha, err := self.GenerateGenericRebaseTodoForRange(commits, startIdx, endIdx, action)
 	if err != nil {
 		return err
 	}
 
-	return self.PrepareInteractiveRebaseCommand(sha, todo, true).Run()
+	return self.PrepareInteractiveRebaseCommand(sha, todoStr, true).Run()
 }
 
 // PrepareInteractiveRebaseCommand returns the cmd for an interactive rebase
@@ -124,14 +175,132 @@ func (self *RebaseCommands) PrepareInteractiveRebaseCommand(baseSha string, todo
 	return cmdObj
 }
 
-func (self *RebaseCommands) GenerateGenericRebaseTodo(commits []*models.Commit, actionIndex int, action string) (string, string, error) {
-	baseIndex := actionIndex + 1
+// InteractiveRebaseMergesRange is like InteractiveRebaseRange except that it
+// passes --rebase-merges so that merge commits are preserved. Because git
+// generates the todo itself in this case (complete with label/reset/merge
+// lines) we only tell the lazygit client which commits to change.
+func (self *RebaseCommands) InteractiveRebaseMergesRange(commits []*models.Commit, startIdx int, endIdx int, action todo.TodoCommand) error {
+	if len(commits) <= endIdx+1 {
+		return errors.New(self.Tr.CannotRebaseOntoFirstCommit)
+	}
+
+	// git gives merge commits 'merge' lines in the todo rather than 'pick' lines,
+	// so there'd be nothing for us to change
+	for _, commit := range commits[startIdx : endIdx+1] {
+		if commit.IsMerge() {
+			return errors.New(self.Tr.CannotChangeMergeCommitsInRange)
+		}
+	}
+
+	baseSha := commits[endIdx].Sha + "^"
+	if action == todo.Squash || action == todo.Fixup {
+		if len(commits) <= endIdx+2 {
+			return errors.New(self.Tr.CannotSquashOntoSecondCommit)
+		}
+
+		// we go via the commit we're squashing onto rather than using '~2', which
+		// would follow the wrong parent if that commit is a merge
+		baseSha = commits[endIdx+1].Sha + "^"
+	}
+
+	changes := make([]todo.Todo, 0, endIdx-startIdx+1)
+	for i := endIdx; i >= startIdx; i-- {
+		changes = append(changes, commitTodo(action, commits[i]))
+	}
+
+	return self.PrepareInteractiveRebaseMergesCommand(baseSha, changes, nil).Run()
+}
+
+// MoveCommitsDownRebasingMerges is like MoveCommitsDown except that it passes
+// --rebase-merges so that merge commits are preserved
+func (self *RebaseCommands) MoveCommitsDownRebasingMerges(commits []*models.Commit, startIdx int, endIdx int) error {
+	if len(commits) <= endIdx+2 {
+		return errors.New(self.Tr.NoRoom)
+	}
+
+	other := commits[endIdx+1]
+	swap := append([]string{other.Sha}, rangeShas(commits, startIdx, endIdx)...)
+
+	return self.PrepareInteractiveRebaseMergesCommand(other.Sha+"^", nil, swap).Run()
+}
+
+// MoveCommitsUpRebasingMerges is like MoveCommitsUp except that it passes
+// --rebase-merges so that merge commits are preserved
+func (self *RebaseCommands) MoveCommitsUpRebasingMerges(commits []*models.Commit, startIdx int, endIdx int) error {
+	if startIdx == 0 {
+		return errors.New(self.Tr.NoRoom)
+	}
+
+	if len(commits) <= endIdx+1 {
+		return errors.New(self.Tr.CannotRebaseOntoFirstCommit)
+	}
+
+	other := commits[startIdx-1]
+	swap := append([]string{other.Sha}, rangeShas(commits, startIdx, endIdx)...)
+
+	return self.PrepareInteractiveRebaseMergesCommand(commits[endIdx].Sha+"^", nil, swap).Run()
+}
+
+func rangeShas(commits []*models.Commit, startIdx int, endIdx int) []string {
+	shas := make([]string, 0, endIdx-startIdx+1)
+	for _, commit := range commits[startIdx : endIdx+1] {
+		shas = append(shas, commit.Sha)
+	}
+
+	return shas
+}
+
+// PrepareInteractiveRebaseMergesCommand returns the cmd for an interactive
+// rebase with --rebase-merges. Rather than passing a whole todo, we pass the
+// changes to apply to the todo that git generates: the commands to set for
+// given commits, and the shas of a commit to swap with an adjacent block of
+// commits (see todo.ApplyChanges)
+func (self *RebaseCommands) PrepareInteractiveRebaseMergesCommand(baseSha string, changes []todo.Todo, swap []string) oscommands.ICmdObj {
+	ex := oscommands.GetLazygitPath()
+
+	debug := "FALSE"
+	if self.Debug {
+		debug = "TRUE"
+	}
+
+	cmdStr := fmt.Sprintf("git rebase --interactive --autostash --keep-empty --rebase-merges %s", baseSha)
+	self.Log.WithField("command", cmdStr).Info("RunCommand")
+
+	cmdObj := self.cmd.New(cmdStr)
+
+	changesStr := todo.Write(changes)
+	swapStr := strings.Join(swap, " ")
+	self.os.LogCommand(fmt.Sprintf("Changing TODO file for interactive rebase: \n\n%s%s", changesStr, swapStr), false)
+
+	cmdObj.AddEnvVars(
+		"LAZYGIT_CLIENT_COMMAND=INTERACTIVE_REBASE",
+		"LAZYGIT_REBASE_TODO_CHANGES="+changesStr,
+		"LAZYGIT_REBASE_TODO_SWAP="+swapStr,
+		"DEBUG="+debug,
+		"LANG=en_US.UTF-8",   // Force using EN as language
+		"LC_ALL=en_US.UTF-8", // Force using EN as language
+		"GIT_SEQUENCE_EDITOR="+ex,
+		"GIT_EDITOR="+ex,
+	)
+
+	return cmdObj
+}
+
+func (self *RebaseCommands) GenerateGenericRebaseTodo(commits []*models.Commit, actionIndex int, action todo.TodoCommand) (string, string, error) {
+	return self.GenerateGenericRebaseTodoForRange(commits, actionIndex, actionIndex, action)
+}
+
+// GenerateGenericRebaseTodoForRange returns a todo which applies the given
+// action to the commits from startIdx to endIdx (inclusive), along with the sha
+// to rebase onto
+func (self *RebaseCommands) GenerateGenericRebaseTodoForRange(commits []*models.Commit, startIdx int, endIdx int, action todo.TodoCommand) (string, string, error) {
+	baseIndex := endIdx + 1
 
 	if len(commits) <= baseIndex {
 		return "", "", errors.New(self.Tr.CannotRebaseOntoFirstCommit)
 	}
 
-	if action == "squash" || action == "fixup" {
+	if action == todo.Squash || action == todo.Fixup {
 		baseIndex++
 
 		if len(commits) <= baseIndex {
@@ -139,23 +308,24 @@ func (self *RebaseCommands) GenerateGenericRebaseTodo(commits []*models.Commit,
 		}
 	}
 
-	todo := ""
-	for i, commit := range commits[0:baseIndex] {
-		var commitAction string
-		if i == actionIndex {
+	todos := make([]todo.Todo, 0, baseIndex)
+	for i := baseIndex - 1; i >= 0; i-- {
+		commit := commits[i]
+		var commitAction todo.TodoCommand
+		if i >= startIdx && i <= endIdx {
 			commitAction = action
 		} else if commit.IsMerge() {
 			// your typical interactive rebase will actually drop merge commits by default. Damn git CLI, you scary!
 			// doing this means we don't need to worry about rebasing over merges which always causes problems.
 			// you typically shouldn't be doing rebases that pass over merge commits anyway.
-			commitAction = "drop"
+			commitAction = todo.Drop
 		} else {
-			commitAction = "pick"
+			commitAction = todo.Pick
 		}
-		todo = commitAction + " " + commit.Sha + " " + commit.Name + "\n" + todo
+		todos = append(todos, commitTodo(commitAction, commit))
 	}
 
-	return todo, commits[baseIndex].Sha, nil
+	return todo.Write(todos), commits[baseIndex].Sha, nil
 }
 
 // AmendTo amends the given commit with whatever files are staged
@@ -168,54 +338,138 @@ func (self *RebaseCommands) AmendTo(sha string) error {
 }
 
 // EditRebaseTodo sets the action at a given index in the git-rebase-todo file
-func (self *RebaseCommands) EditRebaseTodo(index int, action string) error {
-	fileName := filepath.Join(self.dotGitDir, "rebase-merge/git-rebase-todo")
-	bytes, err := ioutil.ReadFile(fileName)
+func (self *RebaseCommands) EditRebaseTodo(index int, action todo.TodoCommand) error {
+	todos, err := self.readTodos()
 	if err != nil {
 		return err
 	}
 
-	content := strings.Split(string(bytes), "\n")
-	commitCount := self.getTodoCommitCount(content)
+	todoIdx, err := self.todoIndex(todos, index)
+	if err != nil {
+		return err
+	}
 
-	// we have the most recent commit at the bottom whereas the todo file has
-	// it at the bottom, so we need to subtract our index from the commit count
-	contentIndex := commitCount - 1 - index
-	splitLine := strings.Split(content[contentIndex], " ")
-	content[contentIndex] = action + " " + strings.Join(splitLine[1:], " ")
-	result := strings.Join(content, "\n")
+	current := todos[todoIdx]
+	if !current.Command.IsCommit() {
+		// exec and break lines have no commit to speak of, but we allow them to
+		// be dropped
+		if action == todo.Drop && (current.Command == todo.Exec || current.Command == todo.Break) {
+			todos = append(todos[:todoIdx], todos[todoIdx+1:]...)
+			return self.writeTodos(todos)
+		}
 
-	return ioutil.WriteFile(fileName, []byte(result), 0644)
-}
+		return errors.New(utils.ResolvePlaceholderString(
+			self.Tr.CannotChangeTodoCommand,
+			map[string]string{"command": current.Command.String()},
+		))
+	}
 
-func (self *RebaseCommands) getTodoCommitCount(content []string) int {
-	// count lines that are not blank and are not comments
-	commitCount := 0
-	for _, line := range content {
-		if line != "" && !strings.HasPrefix(line, "#") {
-			commitCount++
-		}
+	todos[todoIdx].Command = action
+	if action != todo.Fixup {
+		// the -C/-c flags only make sense for a fixup
+		todos[todoIdx].Flag = ""
 	}
-	return commitCount
+
+	return self.writeTodos(todos)
 }
 
 // MoveTodoDown moves a rebase todo item down by one position
 func (self *RebaseCommands) MoveTodoDown(index int) error {
-	fileName := filepath.Join(self.dotGitDir, "rebase-merge/git-rebase-todo")
-	bytes, err := ioutil.ReadFile(fileName)
+	todos, err := self.readTodos()
+	if err != nil {
+		return err
+	}
+
+	todoIdx, err := self.todoIndex(todos, index)
+	if err != nil {
+		return err
+	}
+
+	// the todo file is ordered from oldest to newest, so moving down in our
+	// list means moving up in the file
+	otherIdx := todoIdx - 1
+	for otherIdx >= 0 && !isVisibleTodo(todos[otherIdx]) {
+		otherIdx--
+	}
+	if otherIdx < 0 {
+		return errors.New(self.Tr.NoRoom)
+	}
+
+	todos[todoIdx], todos[otherIdx] = todos[otherIdx], todos[todoIdx]
+
+	return self.writeTodos(todos)
+}
+
+// InsertTodo inserts a todo above the one at the given index, meaning it will
+// be run after it. If the index is the number of todos, it will be inserted
+// below all the others, meaning it will be run next.
+func (self *RebaseCommands) InsertTodo(index int, newTodo todo.Todo) error {
+	todos, err := self.readTodos()
 	if err != nil {
 		return err
 	}
 
-	content := strings.Split(string(bytes), "\n")
-	commitCount := self.getTodoCommitCount(content)
-	contentIndex := commitCount - 1 - index
+	insertIdx := 0
+	if index < visibleTodoCount(todos) {
+		todoIdx, err := self.todoIndex(todos, index)
+		if err != nil {
+			return err
+		}
+		insertIdx = todoIdx + 1
+	}
+
+	todos = append(todos[:insertIdx], append([]todo.Todo{newTodo}, todos[insertIdx:]...)...)
 
-	rearrangedContent := append(content[0:contentIndex-1], content[contentIndex], content[contentIndex-1])
-	rearrangedContent = append(rearrangedContent, content[contentIndex+1:]...)
-	result := strings.Join(rearrangedContent, "\n")
+	return self.writeTodos(todos)
+}
+
+func (self *RebaseCommands) todoFilePath() string {
+	return filepath.Join(self.dotGitDir, "rebase-merge/git-rebase-todo")
+}
+
+func (self *RebaseCommands) readTodos() ([]todo.Todo, error) {
+	bytes, err := ioutil.ReadFile(self.todoFilePath())
+	if err != nil {
+		return nil, err
+	}
 
-	return ioutil.WriteFile(fileName, []byte(result), 0644)
+	return todo.Parse(string(bytes))
+}
+
+func (self *RebaseCommands) writeTodos(todos []todo.Todo) error {
+	return ioutil.WriteFile(self.todoFilePath(), []byte(todo.Write(todos)), 0644)
+}
+
+// todoIndex maps an index in our commits list, which has the most recent commit
+// at the top, to an index in the todo file, which has it at the bottom
+func (self *RebaseCommands) todoIndex(todos []todo.Todo, index int) (int, error) {
+	visibleIdx := visibleTodoCount(todos) - 1 - index
+	for i, t := range todos {
+		if !isVisibleTodo(t) {
+			continue
+		}
+		if visibleIdx == 0 {
+			return i, nil
+		}
+		visibleIdx--
+	}
+
+	return 0, errors.New("index outside of range of rebase todos")
+}
+
+// isVisibleTodo tells us whether the todo is shown in the commits list
+func isVisibleTodo(t todo.Todo) bool {
+	return t.Command != todo.Comment && t.Command != todo.Noop
+}
+
+func visibleTodoCount(todos []todo.Todo) int {
+	count := 0
+	for _, t := range todos {
+		if isVisibleTodo(t) {
+			count++
+		}
+	}
+	return count
 }
 
 // SquashAllAboveFixupCommits squashes all fixup! commits above the given one
@@ -244,12 +498,12 @@ func (self *RebaseCommands) BeginInteractiveRebaseForCommit(commits []*models.Co
 		return errors.New(self.Tr.DisabledForGPG)
 	}
 
-	todo, sha, err := self.GenerateGenericRebaseTodo(commits, commitIndex, "edit")
+	todoStr, sha, err := self.GenerateGenericRebaseTodo(commits, commitIndex, todo.Edit)
 	if err != nil {
 		return err
 	}
 
-	return self.PrepareInteractiveRebaseCommand(sha, todo, true).Run()
+	return self.PrepareInteractiveRebaseCommand(sha, todoStr, true).Run()
 }
 
 // RebaseBranch interactive rebases onto a branch
@@ -336,10 +590,5 @@ func (self *RebaseCommands) DiscardOldFileChanges(commits []*models.Commit, comm
 
 // CherryPickCommits begins an interactive rebase with the given shas being cherry picked onto HEAD
 func (self *RebaseCommands) CherryPickCommits(commits []*models.Commit) error {
-	todo := ""
-	for _, commit := range commits {
-		todo = "pick " + commit.Sha + " " + commit.Name + "\n" + todo
-	}
-
-	return self.PrepareInteractiveRebaseCommand("HEAD", todo, false).Run()
+	return self.PrepareInteractiveRebaseCommand("HEAD", pickTodo(commits), false).Run()
 }
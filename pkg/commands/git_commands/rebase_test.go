@@ -1,6 +1,9 @@
 package git_commands
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -8,6 +11,8 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
+	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -149,3 +154,349 @@ func TestRebaseDiscardOldFileChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestRebaseGenerateGenericRebaseTodoForRange(t *testing.T) {
+	commits := []*models.Commit{
+		{Name: "commit1", Sha: "111"},
+		{Name: "commit2", Sha: "222"},
+		{Name: "commit3", Sha: "333"},
+		{Name: "commit4", Sha: "444"},
+		{Name: "commit5", Sha: "555"},
+	}
+
+	type scenario struct {
+		testName        string
+		startIdx        int
+		endIdx          int
+		action          todo.TodoCommand
+		expectedTodo    string
+		expectedBaseSha string
+		expectedErr     bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:        "single commit",
+			startIdx:        1,
+			endIdx:          1,
+			action:          todo.Drop,
+			expectedTodo:    "drop 222 commit2\npick 111 commit1\n",
+			expectedBaseSha: "333",
+		},
+		{
+			testName:        "range of commits",
+			startIdx:        0,
+			endIdx:          2,
+			action:          todo.Drop,
+			expectedTodo:    "drop 333 commit3\ndrop 222 commit2\ndrop 111 commit1\n",
+			expectedBaseSha: "444",
+		},
+		{
+			testName:        "squashing a range rebases onto the commit below the one we squash into",
+			startIdx:        1,
+			endIdx:          2,
+			action:          todo.Squash,
+			expectedTodo:    "pick 444 commit4\nsquash 333 commit3\nsquash 222 commit2\npick 111 commit1\n",
+			expectedBaseSha: "555",
+		},
+		{
+			testName:    "cannot squash onto the first commit",
+			startIdx:    2,
+			endIdx:      3,
+			action:      todo.Fixup,
+			expectedErr: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			instance := buildRebaseCommands(commonDeps{})
+
+			todoStr, baseSha, err := instance.GenerateGenericRebaseTodoForRange(commits, s.startIdx, s.endIdx, s.action)
+			if s.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedTodo, todoStr)
+			assert.Equal(t, s.expectedBaseSha, baseSha)
+		})
+	}
+}
+
+func TestRebaseMoveCommits(t *testing.T) {
+	commits := []*models.Commit{
+		{Name: "commit1", Sha: "111"},
+		{Name: "commit2", Sha: "222"},
+		{Name: "commit3", Sha: "333"},
+		{Name: "commit4", Sha: "444"},
+		{Name: "commit5", Sha: "555"},
+	}
+
+	type scenario struct {
+		testName     string
+		move         func(*RebaseCommands) error
+		expectedCmd  string
+		expectedTodo string
+		expectedErr  bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "move range down",
+			move: func(instance *RebaseCommands) error {
+				return instance.MoveCommitsDown(commits, 1, 2)
+			},
+			expectedCmd:  "git rebase --interactive --autostash --keep-empty 555",
+			expectedTodo: "pick 333 commit3\npick 222 commit2\npick 444 commit4\npick 111 commit1\n",
+		},
+		{
+			testName: "move range up",
+			move: func(instance *RebaseCommands) error {
+				return instance.MoveCommitsUp(commits, 1, 2)
+			},
+			expectedCmd:  "git rebase --interactive --autostash --keep-empty 444",
+			expectedTodo: "pick 111 commit1\npick 333 commit3\npick 222 commit2\n",
+		},
+		{
+			testName: "no room to move down",
+			move: func(instance *RebaseCommands) error {
+				return instance.MoveCommitsDown(commits, 2, 3)
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "no room to move up",
+			move: func(instance *RebaseCommands) error {
+				return instance.MoveCommitsUp(commits, 0, 1)
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t)
+			if !s.expectedErr {
+				runner = runner.ExpectFunc(func(cmdObj oscommands.ICmdObj) (string, error) {
+					assert.Equal(t, s.expectedCmd, cmdObj.ToString())
+					assert.Contains(t, cmdObj.GetEnvVars(), "LAZYGIT_REBASE_TODO="+s.expectedTodo)
+					return "", nil
+				})
+			}
+			instance := buildRebaseCommands(commonDeps{runner: runner})
+
+			err := s.move(instance)
+			if s.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestRebaseRebasingMerges(t *testing.T) {
+	commits := []*models.Commit{
+		{Name: "commit1", Sha: "111"},
+		{Name: "commit2", Sha: "222"},
+		{Name: "merge", Sha: "333", Parents: []string{"444", "aaa"}},
+		{Name: "commit4", Sha: "444"},
+		{Name: "commit5", Sha: "555"},
+	}
+
+	type scenario struct {
+		testName        string
+		run             func(*RebaseCommands) error
+		expectedCmd     string
+		expectedChanges string
+		expectedSwap    string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "drop range",
+			run: func(instance *RebaseCommands) error {
+				return instance.InteractiveRebaseMergesRange(commits, 0, 1, todo.Drop)
+			},
+			expectedCmd:     "git rebase --interactive --autostash --keep-empty --rebase-merges 222^",
+			expectedChanges: "drop 222 commit2\ndrop 111 commit1\n",
+		},
+		{
+			testName: "squash",
+			run: func(instance *RebaseCommands) error {
+				return instance.InteractiveRebaseMergesRange(commits, 0, 0, todo.Squash)
+			},
+			expectedCmd:     "git rebase --interactive --autostash --keep-empty --rebase-merges 222^",
+			expectedChanges: "squash 111 commit1\n",
+		},
+		{
+			// '222~2' would take the merge's first parent rather than the merge
+			testName: "squash onto a merge commit",
+			run: func(instance *RebaseCommands) error {
+				return instance.InteractiveRebaseMergesRange(commits, 1, 1, todo.Fixup)
+			},
+			expectedCmd:     "git rebase --interactive --autostash --keep-empty --rebase-merges 333^",
+			expectedChanges: "fixup 222 commit2\n",
+		},
+		{
+			testName: "move range down",
+			run: func(instance *RebaseCommands) error {
+				return instance.MoveCommitsDownRebasingMerges(commits, 0, 1)
+			},
+			expectedCmd:  "git rebase --interactive --autostash --keep-empty --rebase-merges 333^",
+			expectedSwap: "333 111 222",
+		},
+		{
+			testName: "move up",
+			run: func(instance *RebaseCommands) error {
+				return instance.MoveCommitsUpRebasingMerges(commits, 1, 1)
+			},
+			expectedCmd:  "git rebase --interactive --autostash --keep-empty --rebase-merges 222^",
+			expectedSwap: "111 222",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).
+				ExpectFunc(func(cmdObj oscommands.ICmdObj) (string, error) {
+					assert.Equal(t, s.expectedCmd, cmdObj.ToString())
+					assert.Contains(t, cmdObj.GetEnvVars(), "LAZYGIT_REBASE_TODO_CHANGES="+s.expectedChanges)
+					assert.Contains(t, cmdObj.GetEnvVars(), "LAZYGIT_REBASE_TODO_SWAP="+s.expectedSwap)
+					return "", nil
+				})
+			instance := buildRebaseCommands(commonDeps{runner: runner})
+
+			assert.NoError(t, s.run(instance))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestRebaseRebasingMergesRangeWithMergeCommit(t *testing.T) {
+	commits := []*models.Commit{
+		{Name: "commit1", Sha: "111"},
+		{Name: "merge", Sha: "222", Parents: []string{"333", "aaa"}},
+		{Name: "commit3", Sha: "333"},
+		{Name: "commit4", Sha: "444"},
+	}
+
+	for _, action := range []todo.TodoCommand{todo.Drop, todo.Squash} {
+		runner := oscommands.NewFakeRunner(t)
+		instance := buildRebaseCommands(commonDeps{runner: runner})
+
+		err := instance.InteractiveRebaseMergesRange(commits, 0, 1, action)
+		assert.EqualError(t, err, instance.Tr.CannotChangeMergeCommitsInRange)
+		runner.CheckForMissingCalls()
+	}
+}
+
+func TestRebaseEditTodoFile(t *testing.T) {
+	content := "pick 111 commit1\n" +
+		"exec make test\n" +
+		"pick 222 commit2\n" +
+		"\n" +
+		"# Rebase 000..222 onto 000 (3 commands)\n"
+
+	type scenario struct {
+		testName        string
+		edit            func(*RebaseCommands) error
+		expectedContent string
+		expectedErr     bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "change the action of a commit",
+			edit: func(instance *RebaseCommands) error {
+				return instance.EditRebaseTodo(0, todo.Drop)
+			},
+			expectedContent: "pick 111 commit1\nexec make test\ndrop 222 commit2\n# Rebase 000..222 onto 000 (3 commands)\n",
+		},
+		{
+			testName: "drop an exec line",
+			edit: func(instance *RebaseCommands) error {
+				return instance.EditRebaseTodo(1, todo.Drop)
+			},
+			expectedContent: "pick 111 commit1\npick 222 commit2\n# Rebase 000..222 onto 000 (3 commands)\n",
+		},
+		{
+			testName: "cannot squash an exec line",
+			edit: func(instance *RebaseCommands) error {
+				return instance.EditRebaseTodo(1, todo.Squash)
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "move a todo down",
+			edit: func(instance *RebaseCommands) error {
+				return instance.MoveTodoDown(0)
+			},
+			expectedContent: "pick 111 commit1\npick 222 commit2\nexec make test\n# Rebase 000..222 onto 000 (3 commands)\n",
+		},
+		{
+			testName: "cannot move the bottom todo down",
+			edit: func(instance *RebaseCommands) error {
+				return instance.MoveTodoDown(2)
+			},
+			expectedErr: true,
+		},
+		{
+			testName: "insert a todo above a commit",
+			edit: func(instance *RebaseCommands) error {
+				return instance.InsertTodo(2, todo.Todo{Command: todo.Break})
+			},
+			expectedContent: "pick 111 commit1\nbreak\nexec make test\npick 222 commit2\n# Rebase 000..222 onto 000 (3 commands)\n",
+		},
+		{
+			testName: "insert a todo to be run next",
+			edit: func(instance *RebaseCommands) error {
+				return instance.InsertTodo(3, todo.Todo{Command: todo.Exec, ExecCommand: "go test ./..."})
+			},
+			expectedContent: "exec go test ./...\npick 111 commit1\nexec make test\npick 222 commit2\n# Rebase 000..222 onto 000 (3 commands)\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			dotGitDir, err := ioutil.TempDir("", "lazygit-rebase-todo")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dotGitDir)
+
+			todoPath := filepath.Join(dotGitDir, "rebase-merge/git-rebase-todo")
+			assert.NoError(t, os.MkdirAll(filepath.Dir(todoPath), 0755))
+			assert.NoError(t, ioutil.WriteFile(todoPath, []byte(content), 0644))
+
+			instance := buildRebaseCommands(commonDeps{dotGitDir: dotGitDir})
+
+			err = s.edit(instance)
+			if s.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			result, err := ioutil.ReadFile(todoPath)
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedContent, string(result))
+		})
+	}
+}
+
+func TestRebaseRewordCommitBlockedByLint(t *testing.T) {
+	userConfig := config.GetDefaultConfig()
+	userConfig.Git.Commit.Lint = config.CommitLintConfig{ConventionalCommits: true, BlockOnError: true}
+	runner := oscommands.NewFakeRunner(t)
+	instance := buildRebaseCommands(commonDeps{runner: runner, userConfig: userConfig})
+
+	err := instance.RewordCommit([]*models.Commit{{Name: "commit", Sha: "123"}}, 0, "not conventional")
+	assert.Error(t, err)
+	runner.CheckForMissingCalls()
+}
@@ -0,0 +1,137 @@
+package git_commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// RerereCommands deal with the conflict resolutions recorded by `git rerere`
+type RerereCommands struct {
+	*GitCommon
+}
+
+func NewRerereCommands(gitCommon *GitCommon) *RerereCommands {
+	return &RerereCommands{
+		GitCommon: gitCommon,
+	}
+}
+
+// Forget drops the recorded resolution for the given conflicted file and
+// restores the conflict markers, so that the file can be resolved again
+func (self *RerereCommands) Forget(fileName string) error {
+	if err := self.cmd.New("git rerere forget -- " + self.cmd.Quote(fileName)).Run(); err != nil {
+		return err
+	}
+
+	return self.cmd.New("git checkout -m -- " + self.cmd.Quote(fileName)).Run()
+}
+
+// DiffCmdObj shows how the conflicts rerere is currently tracking have been
+// resolved so far, i.e. what it will record once they're resolved
+func (self *RerereCommands) DiffCmdObj() oscommands.ICmdObj {
+	return self.cmd.New("git rerere diff").DontLog()
+}
+
+// cacheDir returns the path of the rerere cache. We ask git for it because in a
+// linked worktree the cache is shared with the main worktree, unlike MERGE_RR.
+func (self *RerereCommands) cacheDir() (string, error) {
+	output, err := self.cmd.New("git rev-parse --git-path rr-cache").DontLog().RunWithOutput()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// GetEntries returns the entries in the rerere cache, most recent first
+func (self *RerereCommands) GetEntries() ([]*models.RerereEntry, error) {
+	cacheDir, err := self.cacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs, err := ioutil.ReadDir(cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*models.RerereEntry{}, nil
+		}
+		return nil, err
+	}
+
+	entries := []*models.RerereEntry{}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		entry := &models.RerereEntry{Hash: dir.Name(), UnixTimestamp: dir.ModTime().Unix()}
+		entryDir := filepath.Join(cacheDir, dir.Name())
+
+		if postimage, err := os.Stat(filepath.Join(entryDir, "postimage")); err == nil {
+			entry.Resolved = true
+			entry.UnixTimestamp = postimage.ModTime().Unix()
+		}
+
+		if preimage, err := ioutil.ReadFile(filepath.Join(entryDir, "preimage")); err == nil {
+			entry.Preview = conflictPreview(string(preimage))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].UnixTimestamp > entries[j].UnixTimestamp
+	})
+
+	return entries, nil
+}
+
+// conflictPreview returns the first line of the first side of the first conflict
+// in a preimage
+func conflictPreview(preimage string) string {
+	lines := utils.SplitLines(preimage)
+	for i, line := range lines {
+		if strings.HasPrefix(line, "<<<<<<<") && i+1 < len(lines) {
+			return strings.TrimSpace(lines[i+1])
+		}
+	}
+
+	return ""
+}
+
+// ShowEntryCmdObj shows how the conflict in the given entry was resolved
+func (self *RerereCommands) ShowEntryCmdObj(hash string) (oscommands.ICmdObj, error) {
+	cacheDir, err := self.cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	entryDir := filepath.Join(cacheDir, hash)
+
+	return self.cmd.New(
+		fmt.Sprintf(
+			"git diff --no-index --no-ext-diff --color=%s -- %s %s",
+			self.UserConfig.Git.Paging.ColorArg,
+			self.cmd.Quote(filepath.Join(entryDir, "preimage")),
+			self.cmd.Quote(filepath.Join(entryDir, "postimage")),
+		),
+	).DontLog(), nil
+}
+
+// DeleteEntry removes an entry from the rerere cache, so that its resolution
+// won't be reused
+func (self *RerereCommands) DeleteEntry(hash string) error {
+	cacheDir, err := self.cacheDir()
+	if err != nil {
+		return err
+	}
+
+	return self.os.Remove(filepath.Join(cacheDir, hash))
+}
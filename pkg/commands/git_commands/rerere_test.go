@@ -0,0 +1,99 @@
+package git_commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRerereForget(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		Expect(`git rerere forget -- "test.txt"`, "", nil).
+		Expect(`git checkout -m -- "test.txt"`, "", nil)
+	instance := buildRerereCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Forget("test.txt"))
+	runner.CheckForMissingCalls()
+}
+
+func TestRerereGetEntries(t *testing.T) {
+	dotGitDir, err := ioutil.TempDir("", "lazygit-rerere")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dotGitDir)
+
+	writeEntry := func(hash string, files map[string]string, modTime time.Time) {
+		dir := filepath.Join(dotGitDir, "rr-cache", hash)
+		assert.NoError(t, os.MkdirAll(dir, 0755))
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+			assert.NoError(t, os.Chtimes(path, modTime, modTime))
+		}
+		assert.NoError(t, os.Chtimes(dir, modTime, modTime))
+	}
+
+	older := time.Unix(1600000000, 0)
+	newer := time.Unix(1700000000, 0)
+	writeEntry("aaaa", map[string]string{
+		"preimage":  "before\n<<<<<<<\nfoo\n=======\nbar\n>>>>>>>\n",
+		"postimage": "before\nfoobar\n",
+	}, older)
+	writeEntry("bbbb", map[string]string{
+		"preimage": "<<<<<<<\n  baz\n=======\n>>>>>>>\n",
+	}, newer)
+
+	runner := oscommands.NewFakeRunner(t).
+		Expect("git rev-parse --git-path rr-cache", filepath.Join(dotGitDir, "rr-cache")+"\n", nil)
+	instance := buildRerereCommands(commonDeps{runner: runner})
+	entries, err := instance.GetEntries()
+	assert.NoError(t, err)
+	runner.CheckForMissingCalls()
+	assert.EqualValues(t, []*models.RerereEntry{
+		{Hash: "bbbb", UnixTimestamp: newer.Unix(), Resolved: false, Preview: "baz"},
+		{Hash: "aaaa", UnixTimestamp: older.Unix(), Resolved: true, Preview: "foo"},
+	}, entries)
+}
+
+func TestRerereGetEntriesWithoutCache(t *testing.T) {
+	dotGitDir, err := ioutil.TempDir("", "lazygit-rerere")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dotGitDir)
+
+	runner := oscommands.NewFakeRunner(t).
+		Expect("git rev-parse --git-path rr-cache", filepath.Join(dotGitDir, "rr-cache")+"\n", nil)
+	instance := buildRerereCommands(commonDeps{runner: runner})
+	entries, err := instance.GetEntries()
+	assert.NoError(t, err)
+	runner.CheckForMissingCalls()
+	assert.EqualValues(t, []*models.RerereEntry{}, entries)
+}
+
+func TestRerereDeleteEntryInLinkedWorktree(t *testing.T) {
+	// in a linked worktree the cache lives in the common git dir rather than
+	// the worktree's own git dir
+	commonGitDir, err := ioutil.TempDir("", "lazygit-rerere")
+	assert.NoError(t, err)
+	defer os.RemoveAll(commonGitDir)
+
+	entryDir := filepath.Join(commonGitDir, "rr-cache", "aaaa")
+	assert.NoError(t, os.MkdirAll(entryDir, 0755))
+
+	runner := oscommands.NewFakeRunner(t).
+		Expect("git rev-parse --git-path rr-cache", filepath.Join(commonGitDir, "rr-cache")+"\n", nil)
+	instance := buildRerereCommands(commonDeps{
+		runner:    runner,
+		dotGitDir: filepath.Join(commonGitDir, "worktrees", "feature"),
+	})
+
+	assert.NoError(t, instance.DeleteEntry("aaaa"))
+	runner.CheckForMissingCalls()
+
+	_, err = os.Stat(entryDir)
+	assert.True(t, os.IsNotExist(err))
+}
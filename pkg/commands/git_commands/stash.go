@@ -2,6 +2,8 @@ package git_commands
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
@@ -43,6 +45,120 @@ func (self *StashCommands) Save(message string) error {
 	return self.cmd.New("git stash save " + self.cmd.Quote(message)).Run()
 }
 
+// SaveIncludingUntracked stashes all changes, including untracked files
+func (self *StashCommands) SaveIncludingUntracked(message string) error {
+	return self.cmd.New("git stash save --include-untracked " + self.cmd.Quote(message)).Run()
+}
+
+// SavePaths stashes only the changes to the given paths
+func (self *StashCommands) SavePaths(message string, paths []string, includeUntracked bool) error {
+	cmdStr := "git stash push"
+	if includeUntracked {
+		cmdStr += " --include-untracked"
+	}
+	cmdStr += " -m " + self.cmd.Quote(message) + " --"
+	for _, path := range paths {
+		cmdStr += " " + self.cmd.Quote(path)
+	}
+
+	return self.cmd.New(cmdStr).Run()
+}
+
+// SavePatch stashes just the changes in the given patch, which must apply on top
+// of the index, and removes them from the working tree. The index and all other
+// changes are left alone. We build the stash commits ourselves: the index commit
+// is the index as it is now, and the working tree commit is the index with the
+// patch applied, which we get by applying the patch to a temporary index.
+func (self *StashCommands) SavePatch(message string, patch string) error {
+	if message == "" {
+		message = "WIP (partial stash)"
+	}
+
+	patchPath, err := self.workingTree.SaveTemporaryPatch(patch)
+	if err != nil {
+		return err
+	}
+
+	indexTree, err := self.cmd.New("git write-tree").RunWithOutput()
+	if err != nil {
+		return err
+	}
+	indexTree = strings.TrimSpace(indexTree)
+
+	tempIndexPath := patchPath + ".index"
+	defer func() { _ = os.Remove(tempIndexPath) }()
+	tempIndexEnv := "GIT_INDEX_FILE=" + tempIndexPath
+
+	if err := self.cmd.New("git read-tree " + indexTree).AddEnvVars(tempIndexEnv).Run(); err != nil {
+		return err
+	}
+
+	if err := self.workingTree.ApplyPatchFileCmdObj(patchPath, "cached").AddEnvVars(tempIndexEnv).Run(); err != nil {
+		return err
+	}
+
+	workingTreeTree, err := self.cmd.New("git write-tree").AddEnvVars(tempIndexEnv).RunWithOutput()
+	if err != nil {
+		return err
+	}
+	workingTreeTree = strings.TrimSpace(workingTreeTree)
+
+	indexCommit, err := self.cmd.New(
+		fmt.Sprintf("git commit-tree %s -p HEAD -m %s", indexTree, self.cmd.Quote("index on "+message)),
+	).RunWithOutput()
+	if err != nil {
+		return err
+	}
+	indexCommit = strings.TrimSpace(indexCommit)
+
+	stashCommit, err := self.cmd.New(
+		fmt.Sprintf("git commit-tree %s -p HEAD -p %s -m %s", workingTreeTree, indexCommit, self.cmd.Quote(message)),
+	).RunWithOutput()
+	if err != nil {
+		return err
+	}
+
+	if err := self.Store(strings.TrimSpace(stashCommit), message); err != nil {
+		return err
+	}
+
+	return self.workingTree.ApplyPatchFileCmdObj(patchPath, "reverse").Run()
+}
+
+func (self *StashCommands) Sha(index int) (string, error) {
+	sha, err := self.cmd.New(fmt.Sprintf("git rev-parse stash@{%d}", index)).DontLog().RunWithOutput()
+	return strings.TrimSpace(sha), err
+}
+
+// Store adds the given stash commit to the stash list with the given message
+func (self *StashCommands) Store(sha string, message string) error {
+	return self.cmd.New(fmt.Sprintf("git stash store -m %s %s", self.cmd.Quote(message), sha)).Run()
+}
+
+// Rename changes the message of a stash entry. Git has no command for this so
+// we store the entry's commit again and then drop the original, meaning the
+// entry ends up at the top of the stash list. We store first so that if that
+// fails we haven't lost the entry
+func (self *StashCommands) Rename(index int, message string) error {
+	sha, err := self.Sha(index)
+	if err != nil {
+		return err
+	}
+
+	if err := self.Store(sha, message); err != nil {
+		return err
+	}
+
+	// storing pushed the original entry down by one
+	return self.Drop(index + 1)
+}
+
+// CreateBranch checks out a new branch at the commit the stash entry was based
+// on, applies the entry and drops it if it applied cleanly
+func (self *StashCommands) CreateBranch(index int, branchName string) error {
+	return self.cmd.New(fmt.Sprintf("git stash branch %s stash@{%d}", self.cmd.Quote(branchName), index)).Run()
+}
+
 func (self *StashCommands) ShowStashEntryCmdObj(index int) oscommands.ICmdObj {
 	cmdStr := fmt.Sprintf("git stash show -p --stat --color=%s --unified=%d stash@{%d}", self.UserConfig.Git.Paging.ColorArg, self.UserConfig.Git.DiffContextSize, index)
 
@@ -1,6 +1,9 @@
 package git_commands
 
 import (
+	"io/ioutil"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
@@ -44,6 +47,118 @@ func TestStashSave(t *testing.T) {
 	runner.CheckForMissingCalls()
 }
 
+func TestStashSaveIncludingUntracked(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"stash", "save", "--include-untracked", "A stash message"}, "", nil)
+	instance := buildStashCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.SaveIncludingUntracked("A stash message"))
+	runner.CheckForMissingCalls()
+}
+
+func TestStashSavePaths(t *testing.T) {
+	type scenario struct {
+		testName         string
+		paths            []string
+		includeUntracked bool
+		expected         []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "Tracked paths",
+			paths:    []string{"file1", "dir/file2"},
+			expected: []string{"stash", "push", "-m", "A stash message", "--", "file1", "dir/file2"},
+		},
+		{
+			testName:         "Including untracked files",
+			paths:            []string{"dir"},
+			includeUntracked: true,
+			expected:         []string{"stash", "push", "--include-untracked", "-m", "A stash message", "--", "dir"},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).
+				ExpectGitArgs(s.expected, "", nil)
+			instance := buildStashCommands(commonDeps{runner: runner})
+
+			assert.NoError(t, instance.SavePaths("A stash message", s.paths, s.includeUntracked))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestStashSavePatch(t *testing.T) {
+	patchPath := ""
+	expectTempIndex := func(cmdObj oscommands.ICmdObj) {
+		envVars := cmdObj.GetEnvVars()
+		assert.True(t, strings.HasPrefix(envVars[len(envVars)-1], "GIT_INDEX_FILE="), "expected command to use temporary index")
+	}
+	expectApply := func(flag string, useTempIndex bool) func(cmdObj oscommands.ICmdObj) (string, error) {
+		return func(cmdObj oscommands.ICmdObj) (string, error) {
+			matches := regexp.MustCompile(`^git apply --` + flag + ` "(.*)"$`).FindStringSubmatch(cmdObj.ToString())
+			if !assert.Len(t, matches, 2, "unexpected command: "+cmdObj.ToString()) {
+				return "", nil
+			}
+			content, err := ioutil.ReadFile(matches[1])
+			assert.NoError(t, err)
+			assert.Equal(t, "the patch", string(content))
+			if useTempIndex {
+				patchPath = matches[1]
+				expectTempIndex(cmdObj)
+			} else {
+				assert.Equal(t, patchPath, matches[1])
+			}
+			return "", nil
+		}
+	}
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"write-tree"}, "indextree\n", nil).
+		ExpectFunc(func(cmdObj oscommands.ICmdObj) (string, error) {
+			assert.Equal(t, "git read-tree indextree", cmdObj.ToString())
+			expectTempIndex(cmdObj)
+			return "", nil
+		}).
+		ExpectFunc(expectApply("cached", true)).
+		ExpectFunc(func(cmdObj oscommands.ICmdObj) (string, error) {
+			assert.Equal(t, "git write-tree", cmdObj.ToString())
+			expectTempIndex(cmdObj)
+			return "worktreetree\n", nil
+		}).
+		ExpectGitArgs([]string{"commit-tree", "indextree", "-p", "HEAD", "-m", "index on My message"}, "indexcommit\n", nil).
+		ExpectGitArgs([]string{"commit-tree", "worktreetree", "-p", "HEAD", "-p", "indexcommit", "-m", "My message"}, "stashcommit\n", nil).
+		ExpectGitArgs([]string{"stash", "store", "-m", "My message", "stashcommit"}, "", nil).
+		ExpectFunc(expectApply("reverse", false))
+	instance := buildStashCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.SavePatch("My message", "the patch"))
+	runner.CheckForMissingCalls()
+}
+
+func TestStashRename(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rev-parse", "stash@{2}"}, "f0d0b9a5\n", nil).
+		ExpectGitArgs([]string{"stash", "store", "-m", "New message", "f0d0b9a5"}, "", nil).
+		ExpectGitArgs([]string{"stash", "drop", "stash@{3}"}, "", nil)
+	instance := buildStashCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Rename(2, "New message"))
+	runner.CheckForMissingCalls()
+}
+
+func TestStashCreateBranch(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"stash", "branch", "new-branch", "stash@{1}"}, "", nil)
+	instance := buildStashCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.CreateBranch(1, "new-branch"))
+	runner.CheckForMissingCalls()
+}
+
 func TestStashStashEntryCmdObj(t *testing.T) {
 	type scenario struct {
 		testName    string
@@ -2,6 +2,7 @@ package git_commands
 
 import (
 	"path/filepath"
+	"strings"
 
 	gogit "github.com/jesseduffield/go-git/v5"
 	"github.com/jesseduffield/lazygit/pkg/commands/types/enums"
@@ -59,3 +60,21 @@ func (self *StatusCommands) IsBareRepo() bool {
 	_, err := self.repo.Worktree()
 	return err == gogit.ErrIsBareRepository
 }
+
+// DotGitDir returns the path of the repo's git directory. This is normally
+// '.git' but for worktrees and submodules it's wherever the '.git' file points.
+func (self *StatusCommands) DotGitDir() string {
+	return self.dotGitDir
+}
+
+// CommonGitDir returns the path of the git directory shared by all of the repo's
+// worktrees, which is where refs and packed-refs live. Outside of a linked
+// worktree this is the same as DotGitDir.
+func (self *StatusCommands) CommonGitDir() (string, error) {
+	output, err := self.cmd.New("git rev-parse --git-common-dir").DontLog().RunWithOutput()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output), nil
+}
@@ -0,0 +1,19 @@
+package git_commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCommonGitDir(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		Expect("git rev-parse --git-common-dir", "/repo/.git\n", nil)
+	instance := buildStatusCommands(commonDeps{runner: runner})
+
+	commonGitDir, err := instance.CommonGitDir()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "/repo/.git", commonGitDir)
+	runner.CheckForMissingCalls()
+}
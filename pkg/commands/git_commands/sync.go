@@ -116,6 +116,28 @@ func (self *SyncCommands) FastForward(branchName string, remoteName string, remo
 	return self.cmd.New(cmdStr).PromptOnCredentialRequest().Run()
 }
 
+// FetchPullRequest fetches the given pull request ref into a local branch. We
+// don't force the update, so if the branch has commits that the pull request
+// doesn't (e.g. your own, or because the pull request was force-pushed) the
+// fetch fails rather than losing them. Git won't fetch into the checked out
+// branch, so for that use FetchPullRequestHead instead
+func (self *SyncCommands) FetchPullRequest(remoteName string, ref string, branchName string) error {
+	cmdStr := fmt.Sprintf("git fetch %s %s", self.cmd.Quote(remoteName), self.cmd.Quote(ref+":"+branchName))
+	return self.cmd.New(cmdStr).PromptOnCredentialRequest().Run()
+}
+
+// FetchPullRequestHead fetches the given pull request ref into FETCH_HEAD
+func (self *SyncCommands) FetchPullRequestHead(remoteName string, ref string) error {
+	cmdStr := fmt.Sprintf("git fetch %s %s", self.cmd.Quote(remoteName), self.cmd.Quote(ref))
+	return self.cmd.New(cmdStr).PromptOnCredentialRequest().Run()
+}
+
+// FastForwardToFetchHead fast-forwards the checked out branch to whatever we
+// last fetched
+func (self *SyncCommands) FastForwardToFetchHead() error {
+	return self.cmd.New("git merge --ff-only FETCH_HEAD").Run()
+}
+
 func (self *SyncCommands) FetchRemote(remoteName string) error {
 	cmdStr := fmt.Sprintf("git fetch %s", self.cmd.Quote(remoteName))
 	return self.cmd.New(cmdStr).PromptOnCredentialRequest().Run()
@@ -92,3 +92,23 @@ func TestSyncPush(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncFetchPullRequest(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"fetch", "upstream", "refs/pull/12/head:pr/12"}, "", nil)
+	instance := buildSyncCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.FetchPullRequest("upstream", "refs/pull/12/head", "pr/12"))
+	runner.CheckForMissingCalls()
+}
+
+func TestSyncFetchPullRequestHead(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"fetch", "origin", "refs/pull/12/head"}, "", nil).
+		ExpectGitArgs([]string{"merge", "--ff-only", "FETCH_HEAD"}, "", nil)
+	instance := buildSyncCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.FetchPullRequestHead("origin", "refs/pull/12/head"))
+	assert.NoError(t, instance.FastForwardToFetchHead())
+	runner.CheckForMissingCalls()
+}
@@ -29,3 +29,19 @@ func (self *TagCommands) Delete(tagName string) error {
 func (self *TagCommands) Push(remoteName string, tagName string) error {
 	return self.cmd.New(fmt.Sprintf("git push %s %s", self.cmd.Quote(remoteName), self.cmd.Quote(tagName))).PromptOnCredentialRequest().Run()
 }
+
+func (self *TagCommands) DeleteRemote(remoteName string, tagName string) error {
+	return self.cmd.New(fmt.Sprintf("git push %s --delete %s", self.cmd.Quote(remoteName), self.cmd.Quote("refs/tags/"+tagName))).PromptOnCredentialRequest().Run()
+}
+
+// FetchTags fetches all tags from the remote. If prune is true, any local tags
+// that no longer exist on the remote are deleted. We prune with an explicit tags
+// refspec because --prune on its own would also prune remote-tracking branches
+func (self *TagCommands) FetchTags(remoteName string, prune bool) error {
+	cmdStr := fmt.Sprintf("git fetch %s --tags", self.cmd.Quote(remoteName))
+	if prune {
+		cmdStr = fmt.Sprintf("git fetch %s --prune %s", self.cmd.Quote(remoteName), self.cmd.Quote("refs/tags/*:refs/tags/*"))
+	}
+
+	return self.cmd.New(cmdStr).PromptOnCredentialRequest().Run()
+}
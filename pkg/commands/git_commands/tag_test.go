@@ -0,0 +1,50 @@
+package git_commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagDeleteRemote(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"push", "origin", "--delete", "refs/tags/v1.0.0"}, "", nil)
+	instance := buildTagCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.DeleteRemote("origin", "v1.0.0"))
+	runner.CheckForMissingCalls()
+}
+
+func TestTagFetchTags(t *testing.T) {
+	type scenario struct {
+		testName string
+		prune    bool
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "Without pruning",
+			prune:    false,
+			expected: []string{"fetch", "origin", "--tags"},
+		},
+		{
+			testName: "With pruning",
+			prune:    true,
+			expected: []string{"fetch", "origin", "--prune", "refs/tags/*:refs/tags/*"},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).
+				ExpectGitArgs(s.expected, "", nil)
+			instance := buildTagCommands(commonDeps{runner: runner})
+
+			assert.NoError(t, instance.FetchTags("origin", s.prune))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-errors/errors"
@@ -242,18 +244,45 @@ func (self *WorkingTreeCommands) WorktreeFileDiffCmdObj(node models.IFile, plain
 }
 
 func (self *WorkingTreeCommands) ApplyPatch(patch string, flags ...string) error {
+	filepath, err := self.SaveTemporaryPatch(patch)
+	if err != nil {
+		return err
+	}
+
+	return self.ApplyPatchFileCmdObj(filepath, flags...).Run()
+}
+
+// CheckPatch reports what would happen if we applied the patch with the given
+// flags, without applying it. The error is non-nil if the patch doesn't apply
+// cleanly
+func (self *WorkingTreeCommands) CheckPatch(patch string, flags ...string) (string, error) {
+	filepath, err := self.SaveTemporaryPatch(patch)
+	if err != nil {
+		return "", err
+	}
+
+	return self.ApplyPatchFileCmdObj(filepath, append(flags, "check", "stat", "verbose")...).DontLog().RunWithOutput()
+}
+
+// SaveTemporaryPatch writes the patch to a file in our temp dir and returns the
+// file's path
+func (self *WorkingTreeCommands) SaveTemporaryPatch(patch string) (string, error) {
 	filepath := filepath.Join(oscommands.GetTempDir(), utils.GetCurrentRepoName(), time.Now().Format("Jan _2 15.04.05.000000000")+".patch")
 	self.Log.Infof("saving temporary patch to %s", filepath)
 	if err := self.os.CreateFileWithContent(filepath, patch); err != nil {
-		return err
+		return "", err
 	}
 
+	return filepath, nil
+}
+
+func (self *WorkingTreeCommands) ApplyPatchFileCmdObj(filepath string, flags ...string) oscommands.ICmdObj {
 	flagStr := ""
 	for _, flag := range flags {
 		flagStr += " --" + flag
 	}
 
-	return self.cmd.New(fmt.Sprintf("git apply%s %s", flagStr, self.cmd.Quote(filepath))).Run()
+	return self.cmd.New(fmt.Sprintf("git apply%s %s", flagStr, self.cmd.Quote(filepath)))
 }
 
 // ShowFileDiff get the diff of specified from and to. Typically this will be used for a single commit so it'll be 123abc^..123abc
@@ -288,6 +317,154 @@ func (self *WorkingTreeCommands) CheckoutFile(commitSha, fileName string) error
 	return self.cmd.New(fmt.Sprintf("git checkout %s -- %s", commitSha, self.cmd.Quote(fileName))).Run()
 }
 
+// ResolveConflictWithOurs resolves a conflicted file by keeping our version of it
+func (self *WorkingTreeCommands) ResolveConflictWithOurs(fileName string) error {
+	return self.resolveConflictWithSide(fileName, "--ours")
+}
+
+// ResolveConflictWithTheirs resolves a conflicted file by keeping their version of it
+func (self *WorkingTreeCommands) ResolveConflictWithTheirs(fileName string) error {
+	return self.resolveConflictWithSide(fileName, "--theirs")
+}
+
+func (self *WorkingTreeCommands) resolveConflictWithSide(fileName string, sideFlag string) error {
+	if err := self.cmd.New(fmt.Sprintf("git checkout %s -- %s", sideFlag, self.cmd.Quote(fileName))).Run(); err != nil {
+		return err
+	}
+
+	return self.StageFile(fileName)
+}
+
+// ResolveConflictAsDeleted resolves a conflicted file by deleting it
+func (self *WorkingTreeCommands) ResolveConflictAsDeleted(fileName string) error {
+	return self.cmd.New("git rm -- " + self.cmd.Quote(fileName)).Run()
+}
+
+// ResolveConflictKeepingBoth resolves a conflicted file by writing our version
+// and their version to separate files, which replace the original
+func (self *WorkingTreeCommands) ResolveConflictKeepingBoth(fileName string, oursName string, theirsName string) error {
+	if filepath.Clean(oursName) == filepath.Clean(theirsName) {
+		return errors.New(self.Tr.ErrConflictVersionNamesMatch)
+	}
+	// the conflicted file gets removed once both versions are written out
+	if filepath.Clean(oursName) == filepath.Clean(fileName) || filepath.Clean(theirsName) == filepath.Clean(fileName) {
+		return errors.New(self.Tr.ErrConflictVersionNameIsOriginal)
+	}
+	for _, name := range []string{oursName, theirsName} {
+		exists, err := self.os.FileExists(name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New(fmt.Sprintf(self.Tr.ErrConflictVersionFileExists, name))
+		}
+	}
+
+	modes, err := self.stageModes(fileName)
+	if err != nil {
+		return err
+	}
+
+	versions := []struct {
+		stage int
+		name  string
+	}{
+		{stage: STAGE_OURS, name: oursName},
+		{stage: STAGE_THEIRS, name: theirsName},
+	}
+
+	for _, version := range versions {
+		if err := self.checkoutStageTo(fileName, version.stage, version.name, modes[version.stage]); err != nil {
+			return err
+		}
+	}
+
+	if err := self.ResolveConflictAsDeleted(fileName); err != nil {
+		return err
+	}
+
+	return self.cmd.New(fmt.Sprintf("git add -- %s %s", self.cmd.Quote(oursName), self.cmd.Quote(theirsName))).Run()
+}
+
+// stageModes returns the file mode of each of the conflicted file's index stages
+func (self *WorkingTreeCommands) stageModes(fileName string) (map[int]os.FileMode, error) {
+	output, err := self.cmd.New("git ls-files --stage -- " + self.cmd.Quote(fileName)).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	modes := map[int]os.FileMode{}
+	for _, line := range utils.SplitLines(output) {
+		// e.g. '100755 5716ca5987cbf97d6bb54920bea6adde242d87e6 2	script.sh'
+		fields := strings.Fields(strings.SplitN(line, "\t", 2)[0])
+		if len(fields) != 3 {
+			continue
+		}
+		stage, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+
+		modes[stage] = 0644
+		if fields[0] == "100755" {
+			modes[stage] = 0755
+		}
+	}
+
+	return modes, nil
+}
+
+// checkoutStageTo writes the given index stage of the file to a new path. We go
+// through `git checkout-index` rather than reading the output of `git show` so
+// that nothing git prints to stderr ends up in the file.
+func (self *WorkingTreeCommands) checkoutStageTo(fileName string, stage int, path string, mode os.FileMode) error {
+	output, err := self.cmd.New(
+		fmt.Sprintf("git checkout-index --stage=%d --temp -- %s", stage, self.cmd.Quote(fileName)),
+	).DontLog().RunWithOutput()
+	if err != nil {
+		return err
+	}
+
+	// the output is the temp file's name followed by a tab and the file's name
+	tempName := strings.SplitN(strings.TrimSpace(output), "\t", 2)[0]
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.Rename(tempName, path); err != nil {
+		return err
+	}
+
+	return os.Chmod(path, mode)
+}
+
+// IgnoredPaths returns the untracked paths that git ignores beneath the given
+// paths, or in the whole repo if none are given. Ignored directories are given
+// as a whole, with a trailing slash, rather than file by file.
+func (self *WorkingTreeCommands) IgnoredPaths(paths ...string) ([]string, error) {
+	cmdStr := "git ls-files -z --others --ignored --exclude-standard --directory"
+	if len(paths) > 0 {
+		quotedPaths := make([]string, len(paths))
+		for i, path := range paths {
+			quotedPaths[i] = self.cmd.Quote(path)
+		}
+		cmdStr += " -- " + strings.Join(quotedPaths, " ")
+	}
+
+	output, err := self.cmd.New(cmdStr).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	ignoredPaths := []string{}
+	for _, path := range strings.Split(output, "\x00") {
+		if path != "" {
+			ignoredPaths = append(ignoredPaths, path)
+		}
+	}
+
+	return ignoredPaths, nil
+}
+
 // DiscardAnyUnstagedFileChanges discards any unstages file changes via `git checkout -- .`
 func (self *WorkingTreeCommands) DiscardAnyUnstagedFileChanges() error {
 	return self.cmd.New("git checkout -- .").Run()
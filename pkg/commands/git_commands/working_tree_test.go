@@ -3,6 +3,8 @@ package git_commands
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -460,6 +462,28 @@ func TestWorkingTreeApplyPatch(t *testing.T) {
 	}
 }
 
+func TestWorkingTreeCheckPatch(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectFunc(func(cmdObj oscommands.ICmdObj) (string, error) {
+			re := regexp.MustCompile(`^git apply --cached --check --stat --verbose "(.*)"$`)
+			cmdStr := cmdObj.ToString()
+			matches := re.FindStringSubmatch(cmdStr)
+			assert.Equal(t, 2, len(matches), fmt.Sprintf("unexpected command: %s", cmdStr))
+
+			content, err := ioutil.ReadFile(matches[1])
+			assert.NoError(t, err)
+			assert.Equal(t, "test", string(content))
+
+			return "Checking patch file.txt...", nil
+		})
+	instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+	output, err := instance.CheckPatch("test", "cached")
+	assert.NoError(t, err)
+	assert.Equal(t, "Checking patch file.txt...", output)
+	runner.CheckForMissingCalls()
+}
+
 func TestWorkingTreeDiscardUnstagedFileChanges(t *testing.T) {
 	type scenario struct {
 		testName string
@@ -574,3 +598,174 @@ func TestWorkingTreeResetHard(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkingTreeResolveConflict(t *testing.T) {
+	type scenario struct {
+		testName string
+		resolve  func(*WorkingTreeCommands) error
+		runner   *oscommands.FakeCmdObjRunner
+		test     func(error)
+	}
+
+	noError := func(err error) {
+		assert.NoError(t, err)
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "keep ours",
+			resolve: func(instance *WorkingTreeCommands) error {
+				return instance.ResolveConflictWithOurs("test.txt")
+			},
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git checkout --ours -- "test.txt"`, "", nil).
+				Expect(`git add -- "test.txt"`, "", nil),
+			test: noError,
+		},
+		{
+			testName: "keep theirs",
+			resolve: func(instance *WorkingTreeCommands) error {
+				return instance.ResolveConflictWithTheirs("test.txt")
+			},
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git checkout --theirs -- "test.txt"`, "", nil).
+				Expect(`git add -- "test.txt"`, "", nil),
+			test: noError,
+		},
+		{
+			testName: "keep theirs when checkout fails",
+			resolve: func(instance *WorkingTreeCommands) error {
+				return instance.ResolveConflictWithTheirs("test.txt")
+			},
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git checkout --theirs -- "test.txt"`, "", errors.New("error: path 'test.txt' does not have their version")),
+			test: func(err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			testName: "keep deleted",
+			resolve: func(instance *WorkingTreeCommands) error {
+				return instance.ResolveConflictAsDeleted("test.txt")
+			},
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git rm -- "test.txt"`, "", nil),
+			test: noError,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner})
+			s.test(s.resolve(instance))
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestWorkingTreeResolveConflictKeepingBoth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-resolve-conflict")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// checkout-index writes each stage to a temp file which we then move into place
+	oursTempName := filepath.Join(dir, ".merge_file_ours")
+	theirsTempName := filepath.Join(dir, ".merge_file_theirs")
+	assert.NoError(t, ioutil.WriteFile(oursTempName, []byte("ours\x00"), 0600))
+	assert.NoError(t, ioutil.WriteFile(theirsTempName, []byte("theirs\x00"), 0600))
+
+	oursName := filepath.Join(dir, "bin", "tool.ours")
+	theirsName := filepath.Join(dir, "bin", "tool.theirs")
+
+	runner := oscommands.NewFakeRunner(t).
+		Expect(`git ls-files --stage -- "bin/tool"`, "100644 aaa 1\tbin/tool\n100755 bbb 2\tbin/tool\n100644 ccc 3\tbin/tool\n", nil).
+		Expect(`git checkout-index --stage=2 --temp -- "bin/tool"`, oursTempName+"\tbin/tool\n", nil).
+		Expect(`git checkout-index --stage=3 --temp -- "bin/tool"`, theirsTempName+"\tbin/tool\n", nil).
+		Expect(`git rm -- "bin/tool"`, "", nil).
+		Expect(fmt.Sprintf(`git add -- "%s" "%s"`, oursName, theirsName), "", nil)
+	instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.ResolveConflictKeepingBoth("bin/tool", oursName, theirsName))
+	runner.CheckForMissingCalls()
+
+	for name, expected := range map[string]struct {
+		content string
+		mode    os.FileMode
+	}{
+		oursName:   {content: "ours\x00", mode: 0755},
+		theirsName: {content: "theirs\x00", mode: 0644},
+	} {
+		content, err := ioutil.ReadFile(name)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected.content, string(content))
+
+		info, err := os.Stat(name)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected.mode, info.Mode().Perm())
+	}
+}
+
+func TestWorkingTreeResolveConflictKeepingBothRefusesToOverwrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-resolve-conflict")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	oursName := filepath.Join(dir, "image.ours.png")
+	theirsName := filepath.Join(dir, "image.theirs.png")
+	assert.NoError(t, ioutil.WriteFile(theirsName, []byte("precious"), 0644))
+
+	runner := oscommands.NewFakeRunner(t)
+	instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+	assert.Error(t, instance.ResolveConflictKeepingBoth("image.png", oursName, theirsName))
+	runner.CheckForMissingCalls()
+
+	content, err := ioutil.ReadFile(theirsName)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "precious", string(content))
+	_, err = os.Stat(oursName)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestWorkingTreeResolveConflictKeepingBothRejectsClashingNames(t *testing.T) {
+	type scenario struct {
+		testName   string
+		oursName   string
+		theirsName string
+	}
+
+	scenarios := []scenario{
+		{testName: "names match", oursName: "image.png.new", theirsName: "./image.png.new"},
+		{testName: "ours is the conflicted file", oursName: "image.png", theirsName: "image.theirs.png"},
+		{testName: "theirs is the conflicted file", oursName: "image.ours.png", theirsName: "./image.png"},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t)
+			instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+			assert.Error(t, instance.ResolveConflictKeepingBoth("image.png", s.oursName, s.theirsName))
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestWorkingTreeIgnoredPaths(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		Expect("git ls-files -z --others --ignored --exclude-standard --directory", "node_modules/\x00src/debug.log\x00", nil).
+		Expect(`git ls-files -z --others --ignored --exclude-standard --directory -- "new dir"`, "", nil)
+	instance := buildWorkingTreeCommands(commonDeps{runner: runner})
+
+	ignoredPaths, err := instance.IgnoredPaths()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"node_modules/", "src/debug.log"}, ignoredPaths)
+
+	ignoredPaths, err = instance.IgnoredPaths("new dir")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{}, ignoredPaths)
+
+	runner.CheckForMissingCalls()
+}
@@ -0,0 +1,65 @@
+package git_commands
+
+import (
+	"fmt"
+)
+
+type WorktreeCommands struct {
+	*GitCommon
+}
+
+func NewWorktreeCommands(gitCommon *GitCommon) *WorktreeCommands {
+	return &WorktreeCommands{
+		GitCommon: gitCommon,
+	}
+}
+
+type NewWorktreeOpts struct {
+	Path string
+	// the branch or commit to base the worktree off. If Branch is empty and
+	// Base is a local branch, that branch is checked out in the new worktree,
+	// otherwise the worktree starts off in a detached head state.
+	Base string
+	// if set, a new branch with this name is created at Base and checked out
+	Branch string
+}
+
+func (self *WorktreeCommands) New(opts NewWorktreeOpts) error {
+	branchArg := ""
+	if opts.Branch != "" {
+		branchArg = fmt.Sprintf(" -b %s", self.cmd.Quote(opts.Branch))
+	}
+
+	baseArg := ""
+	if opts.Base != "" {
+		baseArg = " " + self.cmd.Quote(opts.Base)
+	}
+
+	return self.cmd.New(fmt.Sprintf("git worktree add%s %s%s", branchArg, self.cmd.Quote(opts.Path), baseArg)).Run()
+}
+
+func (self *WorktreeCommands) Delete(path string, force bool) error {
+	forceArg := ""
+	if force {
+		forceArg = " --force"
+	}
+
+	return self.cmd.New(fmt.Sprintf("git worktree remove%s %s", forceArg, self.cmd.Quote(path))).Run()
+}
+
+func (self *WorktreeCommands) Prune() error {
+	return self.cmd.New("git worktree prune").Run()
+}
+
+func (self *WorktreeCommands) Lock(path string, reason string) error {
+	reasonArg := ""
+	if reason != "" {
+		reasonArg = fmt.Sprintf(" --reason %s", self.cmd.Quote(reason))
+	}
+
+	return self.cmd.New(fmt.Sprintf("git worktree lock%s %s", reasonArg, self.cmd.Quote(path))).Run()
+}
+
+func (self *WorktreeCommands) Unlock(path string) error {
+	return self.cmd.New(fmt.Sprintf("git worktree unlock %s", self.cmd.Quote(path))).Run()
+}
@@ -0,0 +1,128 @@
+package git_commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorktreeNew(t *testing.T) {
+	type scenario struct {
+		testName string
+		opts     NewWorktreeOpts
+		runner   *oscommands.FakeCmdObjRunner
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "From existing branch",
+			opts:     NewWorktreeOpts{Path: "../feature", Base: "feature"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"worktree", "add", "../feature", "feature"}, "", nil),
+		},
+		{
+			testName: "With new branch",
+			opts:     NewWorktreeOpts{Path: "../feature", Base: "abc123", Branch: "new-feature"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"worktree", "add", "-b", "new-feature", "../feature", "abc123"}, "", nil),
+		},
+		{
+			testName: "Without base",
+			opts:     NewWorktreeOpts{Path: "../feature"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"worktree", "add", "../feature"}, "", nil),
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			instance := buildWorktreeCommands(commonDeps{runner: s.runner})
+			assert.NoError(t, instance.New(s.opts))
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestWorktreeDelete(t *testing.T) {
+	type scenario struct {
+		testName string
+		force    bool
+		runner   *oscommands.FakeCmdObjRunner
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "Not forced",
+			force:    false,
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"worktree", "remove", "/worktrees/feature"}, "", nil),
+		},
+		{
+			testName: "Forced",
+			force:    true,
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"worktree", "remove", "--force", "/worktrees/feature"}, "", nil),
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			instance := buildWorktreeCommands(commonDeps{runner: s.runner})
+			assert.NoError(t, instance.Delete("/worktrees/feature", s.force))
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestWorktreeLock(t *testing.T) {
+	type scenario struct {
+		testName string
+		reason   string
+		runner   *oscommands.FakeCmdObjRunner
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "Without reason",
+			reason:   "",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"worktree", "lock", "/worktrees/feature"}, "", nil),
+		},
+		{
+			testName: "With reason",
+			reason:   "on a usb drive",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"worktree", "lock", "--reason", "on a usb drive", "/worktrees/feature"}, "", nil),
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			instance := buildWorktreeCommands(commonDeps{runner: s.runner})
+			assert.NoError(t, instance.Lock("/worktrees/feature", s.reason))
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestWorktreeUnlock(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"worktree", "unlock", "/worktrees/feature"}, "", nil)
+	instance := buildWorktreeCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Unlock("/worktrees/feature"))
+	runner.CheckForMissingCalls()
+}
+
+func TestWorktreePrune(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"worktree", "prune"}, "", nil)
+	instance := buildWorktreeCommands(commonDeps{runner: runner})
+
+	assert.NoError(t, instance.Prune())
+	runner.CheckForMissingCalls()
+}
@@ -14,6 +14,7 @@ var githubServiceDef = ServiceDefinition{
 	pullRequestURLIntoDefaultBranch: "/compare/{{.From}}?expand=1",
 	pullRequestURLIntoTargetBranch:  "/compare/{{.To}}...{{.From}}?expand=1",
 	commitURL:                       "/commit/{{.CommitSha}}",
+	pullRequestRef:                  "refs/pull/{{.Number}}/head",
 	regexStrings:                    defaultUrlRegexStrings,
 }
 
@@ -30,6 +31,7 @@ var gitLabServiceDef = ServiceDefinition{
 	pullRequestURLIntoDefaultBranch: "/merge_requests/new?merge_request[source_branch]={{.From}}",
 	pullRequestURLIntoTargetBranch:  "/merge_requests/new?merge_request[source_branch]={{.From}}&merge_request[target_branch]={{.To}}",
 	commitURL:                       "/commit/{{.CommitSha}}",
+	pullRequestRef:                  "refs/merge-requests/{{.Number}}/head",
 	regexStrings:                    defaultUrlRegexStrings,
 }
 
@@ -0,0 +1,323 @@
+package hosting_service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// Whereas the rest of this package only builds URLs for the browser, a forge
+// client talks to the hosting service's REST API so that we can show
+// information about pull requests within lazygit itself.
+
+type ForgeClient interface {
+	// GetOpenPullRequests returns the open pull requests of the repo, keyed by
+	// the name of their head branch
+	GetOpenPullRequests() (map[string]*models.PullRequest, error)
+}
+
+type ForgeClientConfig struct {
+	// if empty, we fall back to the GITHUB_TOKEN or GITLAB_TOKEN env var
+	GithubToken string
+	GitlabToken string
+	// if empty, we derive the base URL from the service's web domain. Set this
+	// for self-hosted instances with an unusual API path, or to point lazygit
+	// at a mock server
+	ApiBaseURL string
+}
+
+// GetForgeClient returns a client for the API of the hosting service of the
+// remote URL
+func (self *HostingServiceMgr) GetForgeClient(config ForgeClientConfig) (ForgeClient, error) {
+	serviceDomain, err := self.getServiceDomain(self.remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	repoInfo, err := serviceDomain.serviceDefinition.getRepoInfoFromURL(self.remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	switch serviceDomain.serviceDefinition.provider {
+	case "github":
+		baseURL := config.ApiBaseURL
+		if baseURL == "" {
+			baseURL = githubApiBaseURL(serviceDomain.webDomain)
+		}
+		return &githubClient{
+			forgeHTTPClient: forgeHTTPClient{
+				httpClient: httpClient,
+				baseURL:    strings.TrimSuffix(baseURL, "/"),
+				headers: map[string]string{
+					"Accept":        "application/vnd.github+json",
+					"Authorization": "Bearer " + tokenOrEnv(config.GithubToken, "GITHUB_TOKEN"),
+				},
+			},
+			repoInfo: repoInfo,
+		}, nil
+	case "gitlab":
+		baseURL := config.ApiBaseURL
+		if baseURL == "" {
+			baseURL = fmt.Sprintf("https://%s/api/v4", serviceDomain.webDomain)
+		}
+		return &gitlabClient{
+			forgeHTTPClient: forgeHTTPClient{
+				httpClient: httpClient,
+				baseURL:    strings.TrimSuffix(baseURL, "/"),
+				headers: map[string]string{
+					"PRIVATE-TOKEN": tokenOrEnv(config.GitlabToken, "GITLAB_TOKEN"),
+				},
+			},
+			repoInfo: repoInfo,
+		}, nil
+	default:
+		return nil, errors.New(self.tr.UnsupportedForgeApi)
+	}
+}
+
+// HasForgeToken tells us whether we have an API token for the hosting service
+// of the remote URL, so that we don't go hitting APIs unauthenticated
+func (self *HostingServiceMgr) HasForgeToken(config ForgeClientConfig) bool {
+	serviceDomain, err := self.getServiceDomain(self.remoteURL)
+	if err != nil {
+		return false
+	}
+
+	switch serviceDomain.serviceDefinition.provider {
+	case "github":
+		return tokenOrEnv(config.GithubToken, "GITHUB_TOKEN") != ""
+	case "gitlab":
+		return tokenOrEnv(config.GitlabToken, "GITLAB_TOKEN") != ""
+	default:
+		return false
+	}
+}
+
+func githubApiBaseURL(webDomain string) string {
+	if webDomain == "github.com" {
+		return "https://api.github.com"
+	}
+
+	// GitHub Enterprise
+	return fmt.Sprintf("https://%s/api/v3", webDomain)
+}
+
+func tokenOrEnv(token string, envVar string) string {
+	if token != "" {
+		return token
+	}
+
+	return os.Getenv(envVar)
+}
+
+type forgeHTTPClient struct {
+	httpClient *http.Client
+	baseURL    string
+	headers    map[string]string
+}
+
+// getJSON fetches the given path (relative to the base URL) and decodes the
+// JSON response into result
+func (self *forgeHTTPClient) getJSON(path string, result interface{}) error {
+	_, err := self.getJSONFromURL(self.baseURL+path, result)
+	return err
+}
+
+// getAllPages fetches the given path and every page after it, following the
+// Link header that both GitHub and GitLab use for pagination, and passes the
+// JSON of each page to onPage
+func (self *forgeHTTPClient) getAllPages(path string, onPage func(page json.RawMessage) error) error {
+	url := self.baseURL + path
+	for pageCount := 0; url != ""; pageCount++ {
+		if pageCount == maxForgePages {
+			return errors.New(fmt.Sprintf("GET %s: too many pages", path))
+		}
+
+		var page json.RawMessage
+		var err error
+		url, err = self.getJSONFromURL(url, &page)
+		if err != nil {
+			return err
+		}
+
+		if err := onPage(page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// we stop paginating here so that a huge repo can't keep us busy forever
+const maxForgePages = 20
+
+// getJSONFromURL decodes the JSON response from the given URL into result, and
+// returns the URL of the next page, if there is one
+func (self *forgeHTTPClient) getJSONFromURL(url string, result interface{}) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	for key, value := range self.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := self.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New(fmt.Sprintf("GET %s: %s", req.URL.Path, resp.Status))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return "", err
+	}
+
+	return nextPageURL(resp.Header.Get("Link")), nil
+}
+
+// nextPageURL parses a header like `<https://...?page=2>; rel="next", <https://...?page=5>; rel="last"`
+func nextPageURL(linkHeader string) string {
+	for _, link := range strings.Split(linkHeader, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+
+	return ""
+}
+
+// how many requests we make at once when fetching the CI status of each pull
+// request
+const forgeConcurrencyLimit = 5
+
+// forEachConcurrently calls f with each index from 0 to n-1, running no more
+// than forgeConcurrencyLimit calls at a time, and returns once they're all done
+func forEachConcurrently(n int, f func(i int)) {
+	semaphore := make(chan struct{}, forgeConcurrencyLimit)
+	wg := sync.WaitGroup{}
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// combineCIStatuses returns the overall status of a commit given the statuses
+// reported by each of its checks: any failure fails the lot, otherwise we're
+// pending until every check has succeeded
+func combineCIStatuses(statuses []string) string {
+	result := ""
+	for _, status := range statuses {
+		switch status {
+		case "failure":
+			return "failure"
+		case "pending":
+			result = "pending"
+		case "success":
+			if result == "" {
+				result = "success"
+			}
+		}
+	}
+
+	return result
+}
+
+// cachedForgeClient wraps a forge client so that we only hit the API once per
+// ttl, given that we refresh branches far more often than pull requests change.
+// Failures are cached too, backing off for longer after each consecutive one so
+// that we don't keep hammering an API that's down or rejecting our token
+type cachedForgeClient struct {
+	client ForgeClient
+	ttl    time.Duration
+	now    func() time.Time
+
+	mutex        sync.Mutex
+	fetchedAt    time.Time
+	pullRequests map[string]*models.PullRequest
+	err          error
+	failedAt     time.Time
+	failureCount int
+}
+
+// the longest we'll wait before trying the API again after failures
+const maxForgeBackoff = 30 * time.Minute
+
+func NewCachedForgeClient(client ForgeClient, ttl time.Duration) ForgeClient {
+	return &cachedForgeClient{
+		client: client,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+func (self *cachedForgeClient) GetOpenPullRequests() (map[string]*models.PullRequest, error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.err != nil && self.now().Sub(self.failedAt) < self.backoff() {
+		return nil, self.err
+	}
+
+	if self.err == nil && self.pullRequests != nil && self.now().Sub(self.fetchedAt) < self.ttl {
+		return self.pullRequests, nil
+	}
+
+	pullRequests, err := self.client.GetOpenPullRequests()
+	if err != nil {
+		self.err = err
+		self.failedAt = self.now()
+		self.failureCount++
+		return nil, err
+	}
+
+	self.err = nil
+	self.failureCount = 0
+	self.pullRequests = pullRequests
+	self.fetchedAt = self.now()
+
+	return pullRequests, nil
+}
+
+// backoff doubles the ttl with each consecutive failure
+func (self *cachedForgeClient) backoff() time.Duration {
+	backoff := self.ttl
+	for i := 1; i < self.failureCount && backoff < maxForgeBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxForgeBackoff {
+		return maxForgeBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,260 @@
+package hosting_service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOpenPullRequests(t *testing.T) {
+	type scenario struct {
+		testName             string
+		remoteUrl            string
+		config               ForgeClientConfig
+		responses            map[string]string
+		linkHeaders          map[string]string
+		expectedHeader       [2]string
+		expectedPullRequests map[string]*models.PullRequest
+		expectedErr          bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:  "github",
+			remoteUrl: "git@github.com:peter/calculator.git",
+			config:    ForgeClientConfig{GithubToken: "abc"},
+			responses: map[string]string{
+				"/repos/peter/calculator/pulls": `[
+					{"number": 12, "title": "Add sum", "draft": false, "html_url": "https://github.com/peter/calculator/pull/12", "head": {"ref": "feature/sum", "sha": "aaa", "repo": {"full_name": "peter/calculator"}}, "base": {"repo": {"full_name": "peter/calculator"}}},
+					{"number": 13, "title": "Add minus", "draft": true, "html_url": "https://github.com/peter/calculator/pull/13", "head": {"ref": "feature/minus", "sha": "bbb", "repo": {"full_name": "Peter/Calculator"}}, "base": {"repo": {"full_name": "peter/calculator"}}},
+					{"number": 14, "title": "Fix typo", "draft": false, "html_url": "https://github.com/peter/calculator/pull/14", "head": {"ref": "master", "sha": "ccc", "repo": {"full_name": "paul/calculator"}}, "base": {"repo": {"full_name": "peter/calculator"}}},
+					{"number": 15, "title": "Gone fork", "draft": false, "html_url": "https://github.com/peter/calculator/pull/15", "head": {"ref": "patch-1", "sha": "ddd", "repo": null}, "base": {"repo": {"full_name": "peter/calculator"}}}
+				]`,
+				"/repos/peter/calculator/pulls/page2": `[
+					{"number": 16, "title": "Add times", "draft": false, "html_url": "https://github.com/peter/calculator/pull/16", "head": {"ref": "feature/times", "sha": "eee", "repo": {"full_name": "peter/calculator"}}, "base": {"repo": {"full_name": "peter/calculator"}}},
+					{"number": 17, "title": "Add divide", "draft": false, "html_url": "https://github.com/peter/calculator/pull/17", "head": {"ref": "feature/divide", "sha": "fff", "repo": {"full_name": "peter/calculator"}}, "base": {"repo": {"full_name": "peter/calculator"}}}
+				]`,
+				"/repos/peter/calculator/commits/aaa/status":     `{"state": "error", "total_count": 2}`,
+				"/repos/peter/calculator/commits/aaa/check-runs": `{"check_runs": [{"status": "completed", "conclusion": "success"}]}`,
+				"/repos/peter/calculator/commits/bbb/status":     `{"state": "pending", "total_count": 0}`,
+				"/repos/peter/calculator/commits/bbb/check-runs": `{"check_runs": []}`,
+				// GitHub Actions only reports through check runs
+				"/repos/peter/calculator/commits/eee/status":     `{"state": "pending", "total_count": 0}`,
+				"/repos/peter/calculator/commits/eee/check-runs": `{"check_runs": [{"status": "completed", "conclusion": "skipped"}, {"status": "in_progress", "conclusion": null}]}`,
+				// fff's status can't be fetched, so its CI status is unknown
+			},
+			linkHeaders: map[string]string{
+				"/repos/peter/calculator/pulls": `<{{baseURL}}/repos/peter/calculator/pulls/page2>; rel="next", <{{baseURL}}/repos/peter/calculator/pulls/page2>; rel="last"`,
+			},
+			expectedHeader: [2]string{"Authorization", "Bearer abc"},
+			expectedPullRequests: map[string]*models.PullRequest{
+				"feature/sum": {
+					Number:     12,
+					Title:      "Add sum",
+					HeadBranch: "feature/sum",
+					HeadSha:    "aaa",
+					URL:        "https://github.com/peter/calculator/pull/12",
+					State:      "open",
+					CIStatus:   "failure",
+				},
+				"feature/minus": {
+					Number:     13,
+					Title:      "Add minus",
+					HeadBranch: "feature/minus",
+					HeadSha:    "bbb",
+					URL:        "https://github.com/peter/calculator/pull/13",
+					State:      "draft",
+					CIStatus:   "",
+				},
+				"feature/times": {
+					Number:     16,
+					Title:      "Add times",
+					HeadBranch: "feature/times",
+					HeadSha:    "eee",
+					URL:        "https://github.com/peter/calculator/pull/16",
+					State:      "open",
+					CIStatus:   "pending",
+				},
+				"feature/divide": {
+					Number:     17,
+					Title:      "Add divide",
+					HeadBranch: "feature/divide",
+					HeadSha:    "fff",
+					URL:        "https://github.com/peter/calculator/pull/17",
+					State:      "open",
+					CIStatus:   "",
+				},
+			},
+		},
+		{
+			testName:  "gitlab",
+			remoteUrl: "git@gitlab.com:group/subgroup/calculator.git",
+			config:    ForgeClientConfig{GitlabToken: "xyz"},
+			responses: map[string]string{
+				"/projects/group/subgroup/calculator/merge_requests": `[
+					{"iid": 5, "title": "Add sum", "draft": false, "web_url": "https://gitlab.com/group/subgroup/calculator/-/merge_requests/5", "source_branch": "sum", "sha": "ccc", "source_project_id": 1, "target_project_id": 1},
+					{"iid": 6, "title": "Fix typo", "draft": false, "web_url": "https://gitlab.com/group/subgroup/calculator/-/merge_requests/6", "source_branch": "master", "sha": "ddd", "source_project_id": 2, "target_project_id": 1},
+					{"iid": 7, "title": "Add minus", "draft": true, "web_url": "https://gitlab.com/group/subgroup/calculator/-/merge_requests/7", "source_branch": "minus", "sha": "eee", "source_project_id": 1, "target_project_id": 1}
+				]`,
+				"/projects/group/subgroup/calculator/merge_requests/5/pipelines": `[{"status": "running"}, {"status": "failed"}]`,
+				// 7's pipelines can't be fetched, so its CI status is unknown
+			},
+			expectedHeader: [2]string{"PRIVATE-TOKEN", "xyz"},
+			expectedPullRequests: map[string]*models.PullRequest{
+				"sum": {
+					Number:     5,
+					Title:      "Add sum",
+					HeadBranch: "sum",
+					HeadSha:    "ccc",
+					URL:        "https://gitlab.com/group/subgroup/calculator/-/merge_requests/5",
+					State:      "open",
+					CIStatus:   "pending",
+				},
+				"minus": {
+					Number:     7,
+					Title:      "Add minus",
+					HeadBranch: "minus",
+					HeadSha:    "eee",
+					URL:        "https://gitlab.com/group/subgroup/calculator/-/merge_requests/7",
+					State:      "draft",
+					CIStatus:   "",
+				},
+			},
+		},
+		{
+			testName:    "error response",
+			remoteUrl:   "git@github.com:peter/calculator.git",
+			responses:   map[string]string{},
+			expectedErr: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if s.expectedHeader[0] != "" {
+					assert.Equal(t, s.expectedHeader[1], r.Header.Get(s.expectedHeader[0]))
+				}
+
+				response, ok := s.responses[r.URL.Path]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				if link, ok := s.linkHeaders[r.URL.Path]; ok {
+					w.Header().Set("Link", strings.ReplaceAll(link, "{{baseURL}}", "http://"+r.Host))
+				}
+				fmt.Fprint(w, response)
+			}))
+			defer server.Close()
+
+			tr := i18n.EnglishTranslationSet()
+			hostingServiceMgr := NewHostingServiceMgr(&test.FakeFieldLogger{}, &tr, s.remoteUrl, nil)
+			s.config.ApiBaseURL = server.URL
+			client, err := hostingServiceMgr.GetForgeClient(s.config)
+			assert.NoError(t, err)
+
+			pullRequests, err := client.GetOpenPullRequests()
+			if s.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedPullRequests, pullRequests)
+		})
+	}
+}
+
+func TestGetForgeClientUnsupportedService(t *testing.T) {
+	tr := i18n.EnglishTranslationSet()
+	hostingServiceMgr := NewHostingServiceMgr(&test.FakeFieldLogger{}, &tr, "git@bitbucket.org:johndoe/social_network.git", nil)
+	_, err := hostingServiceMgr.GetForgeClient(ForgeClientConfig{})
+	assert.EqualError(t, err, tr.UnsupportedForgeApi)
+}
+
+type fakeForgeClient struct {
+	calls int
+	err   error
+}
+
+func (self *fakeForgeClient) GetOpenPullRequests() (map[string]*models.PullRequest, error) {
+	self.calls++
+	if self.err != nil {
+		return nil, self.err
+	}
+	return map[string]*models.PullRequest{"feature": {Number: self.calls}}, nil
+}
+
+func TestCachedForgeClient(t *testing.T) {
+	fakeClient := &fakeForgeClient{}
+	now := time.Unix(0, 0)
+	client := &cachedForgeClient{
+		client: fakeClient,
+		ttl:    time.Minute,
+		now:    func() time.Time { return now },
+	}
+
+	for i := 0; i < 2; i++ {
+		pullRequests, err := client.GetOpenPullRequests()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, pullRequests["feature"].Number)
+	}
+
+	now = now.Add(2 * time.Minute)
+
+	pullRequests, err := client.GetOpenPullRequests()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pullRequests["feature"].Number)
+	assert.Equal(t, 2, fakeClient.calls)
+}
+
+func TestCachedForgeClientBacksOffAfterErrors(t *testing.T) {
+	fakeClient := &fakeForgeClient{err: errors.New("401 Unauthorized")}
+	now := time.Unix(0, 0)
+	client := &cachedForgeClient{
+		client: fakeClient,
+		ttl:    time.Minute,
+		now:    func() time.Time { return now },
+	}
+
+	// the first failure is cached for the ttl, the second for twice that
+	_, err := client.GetOpenPullRequests()
+	assert.Error(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = client.GetOpenPullRequests()
+	assert.Error(t, err)
+	assert.Equal(t, 1, fakeClient.calls)
+
+	now = now.Add(time.Minute)
+	_, err = client.GetOpenPullRequests()
+	assert.Error(t, err)
+	assert.Equal(t, 2, fakeClient.calls)
+
+	now = now.Add(90 * time.Second)
+	_, err = client.GetOpenPullRequests()
+	assert.Error(t, err)
+	assert.Equal(t, 2, fakeClient.calls)
+
+	fakeClient.err = nil
+	now = now.Add(time.Minute)
+	pullRequests, err := client.GetOpenPullRequests()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, pullRequests["feature"].Number)
+}
+
+func TestNextPageURL(t *testing.T) {
+	assert.Equal(t, "", nextPageURL(""))
+	assert.Equal(t, "https://api.github.com/x?page=3", nextPageURL(`<https://api.github.com/x?page=1>; rel="prev", <https://api.github.com/x?page=3>; rel="next"`))
+	assert.Equal(t, "", nextPageURL(`<https://api.github.com/x?page=1>; rel="first"`))
+}
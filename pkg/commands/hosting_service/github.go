@@ -0,0 +1,147 @@
+package hosting_service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+type githubRepo struct {
+	FullName string `json:"full_name"`
+}
+
+// see https://docs.github.com/en/rest/pulls/pulls#list-pull-requests
+type githubPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Draft   bool   `json:"draft"`
+	HtmlURL string `json:"html_url"`
+	Head    struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+		// nil if the fork the pull request came from has been deleted
+		Repo *githubRepo `json:"repo"`
+	} `json:"head"`
+	Base struct {
+		Repo *githubRepo `json:"repo"`
+	} `json:"base"`
+}
+
+// see https://docs.github.com/en/rest/commits/statuses#get-the-combined-status-for-a-specific-reference
+type githubCombinedStatus struct {
+	State      string `json:"state"`
+	TotalCount int    `json:"total_count"`
+}
+
+// see https://docs.github.com/en/rest/checks/runs#list-check-runs-for-a-git-reference
+type githubCheckRuns struct {
+	CheckRuns []struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	} `json:"check_runs"`
+}
+
+type githubClient struct {
+	forgeHTTPClient
+	repoInfo *RepoInformation
+}
+
+func (self *githubClient) GetOpenPullRequests() (map[string]*models.PullRequest, error) {
+	githubPullRequests := []githubPullRequest{}
+	path := fmt.Sprintf("/repos/%s/%s/pulls?state=open&per_page=100", self.repoInfo.Owner, self.repoInfo.Repository)
+	err := self.getAllPages(path, func(page json.RawMessage) error {
+		var pagePullRequests []githubPullRequest
+		if err := json.Unmarshal(page, &pagePullRequests); err != nil {
+			return err
+		}
+
+		githubPullRequests = append(githubPullRequests, pagePullRequests...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// pull requests from forks are keyed by the fork's branch name, which will
+	// often clash with one of our own branches (e.g. 'master' or 'patch-1'), so
+	// we only show pull requests whose branch lives in this repo
+	sameRepoPullRequests := []githubPullRequest{}
+	for _, githubPullRequest := range githubPullRequests {
+		head, base := githubPullRequest.Head.Repo, githubPullRequest.Base.Repo
+		if head != nil && base != nil && strings.EqualFold(head.FullName, base.FullName) {
+			sameRepoPullRequests = append(sameRepoPullRequests, githubPullRequest)
+		}
+	}
+
+	// we'd rather show a pull request without its CI status than not at all, so
+	// if we can't get the status (e.g. because we've been rate limited) we leave
+	// it unknown
+	ciStatuses := make([]string, len(sameRepoPullRequests))
+	forEachConcurrently(len(sameRepoPullRequests), func(i int) {
+		ciStatuses[i], _ = self.getCIStatus(sameRepoPullRequests[i].Head.Sha)
+	})
+
+	pullRequests := make(map[string]*models.PullRequest, len(sameRepoPullRequests))
+	for i, githubPullRequest := range sameRepoPullRequests {
+		state := "open"
+		if githubPullRequest.Draft {
+			state = "draft"
+		}
+
+		pullRequests[githubPullRequest.Head.Ref] = &models.PullRequest{
+			Number:     githubPullRequest.Number,
+			Title:      githubPullRequest.Title,
+			HeadBranch: githubPullRequest.Head.Ref,
+			HeadSha:    githubPullRequest.Head.Sha,
+			URL:        githubPullRequest.HtmlURL,
+			State:      state,
+			CIStatus:   ciStatuses[i],
+		}
+	}
+
+	return pullRequests, nil
+}
+
+// getCIStatus combines the commit's legacy statuses with its check runs, given
+// that GitHub Actions only reports through the latter
+func (self *githubClient) getCIStatus(sha string) (string, error) {
+	var status githubCombinedStatus
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s/status", self.repoInfo.Owner, self.repoInfo.Repository, sha)
+	if err := self.getJSON(path, &status); err != nil {
+		return "", err
+	}
+
+	statuses := []string{}
+	if status.TotalCount > 0 {
+		switch status.State {
+		case "success", "pending":
+			statuses = append(statuses, status.State)
+		default:
+			statuses = append(statuses, "failure")
+		}
+	}
+
+	var checkRuns githubCheckRuns
+	path = fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs?per_page=100", self.repoInfo.Owner, self.repoInfo.Repository, sha)
+	if err := self.getJSON(path, &checkRuns); err != nil {
+		return "", err
+	}
+
+	for _, checkRun := range checkRuns.CheckRuns {
+		if checkRun.Status != "completed" {
+			statuses = append(statuses, "pending")
+			continue
+		}
+
+		switch checkRun.Conclusion {
+		case "success", "neutral", "skipped":
+			statuses = append(statuses, "success")
+		default:
+			statuses = append(statuses, "failure")
+		}
+	}
+
+	return combineCIStatuses(statuses), nil
+}
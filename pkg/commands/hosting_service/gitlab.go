@@ -0,0 +1,112 @@
+package hosting_service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// see https://docs.gitlab.com/ee/api/merge_requests.html#list-project-merge-requests
+type gitlabMergeRequest struct {
+	Iid          int    `json:"iid"`
+	Title        string `json:"title"`
+	Draft        bool   `json:"draft"`
+	WebURL       string `json:"web_url"`
+	SourceBranch string `json:"source_branch"`
+	Sha          string `json:"sha"`
+	// these differ when the merge request comes from a fork
+	SourceProjectID int `json:"source_project_id"`
+	TargetProjectID int `json:"target_project_id"`
+}
+
+// see https://docs.gitlab.com/ee/api/merge_requests.html#list-merge-request-pipelines
+type gitlabPipeline struct {
+	Status string `json:"status"`
+}
+
+type gitlabClient struct {
+	forgeHTTPClient
+	repoInfo *RepoInformation
+}
+
+func (self *gitlabClient) GetOpenPullRequests() (map[string]*models.PullRequest, error) {
+	mergeRequests := []gitlabMergeRequest{}
+	path := fmt.Sprintf("/projects/%s/merge_requests?state=opened&per_page=100", self.projectID())
+	err := self.getAllPages(path, func(page json.RawMessage) error {
+		var pageMergeRequests []gitlabMergeRequest
+		if err := json.Unmarshal(page, &pageMergeRequests); err != nil {
+			return err
+		}
+
+		mergeRequests = append(mergeRequests, pageMergeRequests...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// as with GitHub, a fork's branch names would clash with our own
+	sameProjectMergeRequests := []gitlabMergeRequest{}
+	for _, mergeRequest := range mergeRequests {
+		if mergeRequest.SourceProjectID == mergeRequest.TargetProjectID {
+			sameProjectMergeRequests = append(sameProjectMergeRequests, mergeRequest)
+		}
+	}
+
+	// as with GitHub, a pipeline we can't get leaves the CI status unknown
+	ciStatuses := make([]string, len(sameProjectMergeRequests))
+	forEachConcurrently(len(sameProjectMergeRequests), func(i int) {
+		ciStatuses[i], _ = self.getCIStatus(sameProjectMergeRequests[i].Iid)
+	})
+
+	pullRequests := make(map[string]*models.PullRequest, len(sameProjectMergeRequests))
+	for i, mergeRequest := range sameProjectMergeRequests {
+		state := "open"
+		if mergeRequest.Draft {
+			state = "draft"
+		}
+
+		pullRequests[mergeRequest.SourceBranch] = &models.PullRequest{
+			Number:     mergeRequest.Iid,
+			Title:      mergeRequest.Title,
+			HeadBranch: mergeRequest.SourceBranch,
+			HeadSha:    mergeRequest.Sha,
+			URL:        mergeRequest.WebURL,
+			State:      state,
+			CIStatus:   ciStatuses[i],
+		}
+	}
+
+	return pullRequests, nil
+}
+
+// the API lets us use the url-encoded path of the project in place of its ID
+func (self *gitlabClient) projectID() string {
+	return url.PathEscape(self.repoInfo.Owner + "/" + self.repoInfo.Repository)
+}
+
+func (self *gitlabClient) getCIStatus(iid int) (string, error) {
+	var pipelines []gitlabPipeline
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/pipelines", self.projectID(), iid)
+	if err := self.getJSON(path, &pipelines); err != nil {
+		return "", err
+	}
+
+	if len(pipelines) == 0 {
+		return "", nil
+	}
+
+	// pipelines are returned newest first
+	switch pipelines[0].Status {
+	case "success":
+		return "success", nil
+	case "failed", "canceled":
+		return "failure", nil
+	case "skipped", "manual":
+		return "", nil
+	default:
+		return "pending", nil
+	}
+}
@@ -3,6 +3,7 @@ package hosting_service
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -59,6 +60,22 @@ func (self *HostingServiceMgr) GetCommitURL(commitSha string) (string, error) {
 	return pullRequestURL, nil
 }
 
+// GetPullRequestRef returns the ref that the service exposes the head of the
+// given pull request under, so that it can be fetched. Not all services expose
+// such a ref (e.g. bitbucket doesn't)
+func (self *HostingServiceMgr) GetPullRequestRef(number int) (string, error) {
+	gitService, err := self.getService()
+	if err != nil {
+		return "", err
+	}
+
+	if gitService.pullRequestRef == "" {
+		return "", errors.New(self.tr.UnsupportedPullRequestCheckout)
+	}
+
+	return gitService.getPullRequestRef(number), nil
+}
+
 func (self *HostingServiceMgr) getService() (*Service, error) {
 	serviceDomain, err := self.getServiceDomain(self.remoteURL)
 	if err != nil {
@@ -159,6 +176,7 @@ type ServiceDefinition struct {
 	pullRequestURLIntoDefaultBranch string
 	pullRequestURLIntoTargetBranch  string
 	commitURL                       string
+	pullRequestRef                  string
 	regexStrings                    []string
 }
 
@@ -194,6 +212,10 @@ func (self *Service) getCommitURL(commitSha string) string {
 	return self.resolveUrl(self.commitURL, map[string]string{"CommitSha": commitSha})
 }
 
+func (self *Service) getPullRequestRef(number int) string {
+	return utils.ResolvePlaceholderString(self.pullRequestRef, map[string]string{"Number": strconv.Itoa(number)})
+}
+
 func (self *Service) resolveUrl(templateString string, args map[string]string) string {
 	return self.root + utils.ResolvePlaceholderString(templateString, args)
 }
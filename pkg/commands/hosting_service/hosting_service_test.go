@@ -233,3 +233,55 @@ func TestGetPullRequestURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPullRequestRef(t *testing.T) {
+	type scenario struct {
+		testName             string
+		remoteUrl            string
+		configServiceDomains map[string]string
+		expectedRef          string
+		expectedErr          bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:    "github",
+			remoteUrl:   "git@github.com:peter/calculator.git",
+			expectedRef: "refs/pull/12/head",
+		},
+		{
+			testName:    "gitlab",
+			remoteUrl:   "https://gitlab.com/peter/calculator.git",
+			expectedRef: "refs/merge-requests/12/head",
+		},
+		{
+			testName:  "gitlab on a custom domain",
+			remoteUrl: "git@git.work.com:peter/calculator.git",
+			configServiceDomains: map[string]string{
+				"git.work.com": "gitlab:code.work.com",
+			},
+			expectedRef: "refs/merge-requests/12/head",
+		},
+		{
+			testName:    "bitbucket doesn't expose pull request refs",
+			remoteUrl:   "git@bitbucket.org:johndoe/social_network.git",
+			expectedErr: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			tr := i18n.EnglishTranslationSet()
+			hostingServiceMgr := NewHostingServiceMgr(&test.FakeFieldLogger{}, &tr, s.remoteUrl, s.configServiceDomains)
+			ref, err := hostingServiceMgr.GetPullRequestRef(12)
+			if s.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedRef, ref)
+		})
+	}
+}
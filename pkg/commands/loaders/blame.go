@@ -0,0 +1,121 @@
+package loaders
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+type BlameLoader struct {
+	*common.Common
+	cmd oscommands.ICmdObjBuilder
+}
+
+func NewBlameLoader(
+	common *common.Common,
+	cmd oscommands.ICmdObjBuilder,
+) *BlameLoader {
+	return &BlameLoader{
+		Common: common,
+		cmd:    cmd,
+	}
+}
+
+// GetBlame blames the given file as of the given ref. If ref is empty, the
+// file is blamed as it is in the working tree.
+//
+// We parse the output of `git blame --porcelain`, where each line of the file
+// is preceded by a header like '<sha> <orig line> <final line> [<group size>]'.
+// The first time a commit appears, the header is followed by the commit's
+// details (author, summary, etc), and then the line content itself, prefixed
+// with a tab.
+func (self *BlameLoader) GetBlame(filename string, ref string) ([]*models.BlameLine, error) {
+	refArg := ""
+	if ref != "" {
+		refArg = " " + self.cmd.Quote(ref)
+	}
+
+	output, err := self.cmd.New(
+		fmt.Sprintf("git blame --porcelain%s -- %s", refArg, self.cmd.Quote(filename)),
+	).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBlame(output), nil
+}
+
+func parseBlame(output string) []*models.BlameLine {
+	// git only gives us a commit's details the first time it appears so we need
+	// to remember them for subsequent lines
+	commitsBySha := map[string]*models.BlameLine{}
+
+	blameLines := []*models.BlameLine{}
+	var commit *models.BlameLine
+	lineNumber := 0
+	for _, line := range utils.SplitLines(output) {
+		if commit == nil {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+
+			var err error
+			lineNumber, err = strconv.Atoi(fields[2])
+			if err != nil {
+				continue
+			}
+
+			sha := fields[0]
+			if _, ok := commitsBySha[sha]; !ok {
+				commitsBySha[sha] = &models.BlameLine{Sha: sha}
+			}
+			commit = commitsBySha[sha]
+			continue
+		}
+
+		if strings.HasPrefix(line, "\t") {
+			blameLine := *commit
+			blameLine.LineNumber = lineNumber
+			blameLine.Content = strings.TrimPrefix(line, "\t")
+			blameLines = append(blameLines, &blameLine)
+			commit = nil
+			continue
+		}
+
+		key, value := splitBlameHeader(line)
+		switch key {
+		case "author":
+			commit.Author = value
+		case "author-mail":
+			commit.AuthorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			if timestamp, err := strconv.ParseInt(value, 10, 64); err == nil {
+				commit.UnixTimestamp = timestamp
+			}
+		case "summary":
+			commit.Summary = value
+		case "previous":
+			previousFields := strings.SplitN(value, " ", 2)
+			commit.PreviousSha = previousFields[0]
+			if len(previousFields) > 1 {
+				commit.PreviousFilename = previousFields[1]
+			}
+		}
+	}
+
+	return blameLines
+}
+
+func splitBlameHeader(line string) (string, string) {
+	split := strings.SplitN(line, " ", 2)
+	if len(split) < 2 {
+		return split[0], ""
+	}
+	return split[0], split[1]
+}
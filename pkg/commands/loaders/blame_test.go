@@ -0,0 +1,129 @@
+package loaders
+
+import (
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const blameOutput = `1234567890123456789012345678901234567890 1 1 2
+author Jesse Duffield
+author-mail <jesse@example.com>
+author-time 1640000000
+author-tz +1100
+committer Jesse Duffield
+committer-mail <jesse@example.com>
+committer-time 1640000000
+committer-tz +1100
+summary add some stuff
+boundary
+filename file.txt
+	line one
+1234567890123456789012345678901234567890 2 2
+	line two
+abcdefabcdefabcdefabcdefabcdefabcdefabcd 1 3 1
+author Someone Else
+author-mail <someone@example.com>
+author-time 1650000000
+author-tz +0000
+committer Someone Else
+committer-mail <someone@example.com>
+committer-time 1650000000
+committer-tz +0000
+summary change stuff
+previous 1234567890123456789012345678901234567890 old file.txt
+filename file.txt
+	
+`
+
+func TestGetBlame(t *testing.T) {
+	type scenario struct {
+		testName      string
+		ref           string
+		runner        oscommands.ICmdObjRunner
+		expectedLines []*models.BlameLine
+		expectedError error
+	}
+
+	firstCommit := models.BlameLine{
+		Sha:           "1234567890123456789012345678901234567890",
+		Author:        "Jesse Duffield",
+		AuthorEmail:   "jesse@example.com",
+		UnixTimestamp: 1640000000,
+		Summary:       "add some stuff",
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "working tree",
+			ref:      "",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git blame --porcelain -- "file.txt"`, blameOutput, nil),
+			expectedLines: []*models.BlameLine{
+				{
+					Sha:           firstCommit.Sha,
+					Author:        firstCommit.Author,
+					AuthorEmail:   firstCommit.AuthorEmail,
+					UnixTimestamp: firstCommit.UnixTimestamp,
+					Summary:       firstCommit.Summary,
+					LineNumber:    1,
+					Content:       "line one",
+				},
+				{
+					Sha:           firstCommit.Sha,
+					Author:        firstCommit.Author,
+					AuthorEmail:   firstCommit.AuthorEmail,
+					UnixTimestamp: firstCommit.UnixTimestamp,
+					Summary:       firstCommit.Summary,
+					LineNumber:    2,
+					Content:       "line two",
+				},
+				{
+					Sha:              "abcdefabcdefabcdefabcdefabcdefabcdefabcd",
+					Author:           "Someone Else",
+					AuthorEmail:      "someone@example.com",
+					UnixTimestamp:    1650000000,
+					Summary:          "change stuff",
+					LineNumber:       3,
+					PreviousSha:      "1234567890123456789012345678901234567890",
+					PreviousFilename: "old file.txt",
+					Content:          "",
+				},
+			},
+		},
+		{
+			testName: "at a ref",
+			ref:      "abc123",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git blame --porcelain "abc123" -- "file.txt"`, "", nil),
+			expectedLines: []*models.BlameLine{},
+		},
+		{
+			testName: "command fails",
+			ref:      "",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git blame --porcelain -- "file.txt"`, "", errors.New("error")),
+			expectedLines: nil,
+			expectedError: errors.New("error"),
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			loader := NewBlameLoader(utils.NewDummyCommon(), oscommands.NewDummyCmdObjBuilder(s.runner))
+
+			blameLines, err := loader.GetBlame("file.txt", s.ref)
+			assert.EqualValues(t, s.expectedLines, blameLines)
+			if s.expectedError != nil {
+				assert.EqualError(t, err, s.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
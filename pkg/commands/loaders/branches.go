@@ -2,6 +2,8 @@ package loaders
 
 import (
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/jesseduffield/go-git/v5/config"
@@ -21,6 +23,16 @@ import (
 // if we find out we need to use one of these functions in the git.go file, we
 // can just pull them out of here and put them there and then call them from in here
 
+const (
+	// the order in which the branches were last checked out, as per the reflog
+	BRANCH_SORT_ORDER_RECENCY      = "recency"
+	BRANCH_SORT_ORDER_ALPHABETICAL = "alphabetical"
+	// most recently committed to first
+	BRANCH_SORT_ORDER_DATE = "date"
+	// most commits ahead/behind the upstream first
+	BRANCH_SORT_ORDER_DIVERGENCE = "divergence"
+)
+
 type BranchLoaderConfigCommands interface {
 	Branches() (map[string]*config.Branch, error)
 }
@@ -47,8 +59,9 @@ func NewBranchLoader(
 	}
 }
 
-// Load the list of branches for the current repo
-func (self *BranchLoader) Load(reflogCommits []*models.Commit) ([]*models.Branch, error) {
+// Load the list of branches for the current repo. The checked out branch always
+// comes first, with the rest ordered according to sortOrder
+func (self *BranchLoader) Load(reflogCommits []*models.Commit, sortOrder string) ([]*models.Branch, error) {
 	branches := self.obtainBranches()
 
 	reflogBranches := self.obtainReflogBranches(reflogCommits)
@@ -103,9 +116,46 @@ outer:
 		}
 	}
 
+	sortBranches(branches[1:], sortOrder)
+
 	return branches, nil
 }
 
+// sortBranches sorts the given branches in place. Branches are already in
+// recency order so that's our tiebreaker, given we use a stable sort
+func sortBranches(branches []*models.Branch, sortOrder string) {
+	switch sortOrder {
+	case BRANCH_SORT_ORDER_ALPHABETICAL:
+		sort.SliceStable(branches, func(i, j int) bool {
+			return strings.ToLower(branches[i].Name) < strings.ToLower(branches[j].Name)
+		})
+	case BRANCH_SORT_ORDER_DATE:
+		sort.SliceStable(branches, func(i, j int) bool {
+			return branches[i].CommitterDate > branches[j].CommitterDate
+		})
+	case BRANCH_SORT_ORDER_DIVERGENCE:
+		sort.SliceStable(branches, func(i, j int) bool {
+			return divergence(branches[i]) > divergence(branches[j])
+		})
+	}
+}
+
+// divergence returns the number of commits the branch is ahead and behind its
+// upstream, or -1 if we don't know, so that those branches come last
+func divergence(branch *models.Branch) int {
+	pushables, err := strconv.Atoi(branch.Pushables)
+	if err != nil {
+		return -1
+	}
+
+	pullables, err := strconv.Atoi(branch.Pullables)
+	if err != nil {
+		return -1
+	}
+
+	return pushables + pullables
+}
+
 func (self *BranchLoader) obtainBranches() []*models.Branch {
 	output, err := self.getRawBranches()
 	if err != nil {
@@ -121,8 +171,8 @@ func (self *BranchLoader) obtainBranches() []*models.Branch {
 		}
 
 		split := strings.Split(line, SEPARATION_CHAR)
-		if len(split) != 4 {
-			// Ignore line if it isn't separated into 4 parts
+		if len(split) != 5 {
+			// Ignore line if it isn't separated into 5 parts
 			// This is probably a warning message, for more info see:
 			// https://github.com/jesseduffield/lazygit/issues/1385#issuecomment-885580439
 			continue
@@ -136,6 +186,10 @@ func (self *BranchLoader) obtainBranches() []*models.Branch {
 			Head:      split[0] == "*",
 		}
 
+		if committerDate, err := strconv.ParseInt(split[4], 10, 64); err == nil {
+			branch.CommitterDate = committerDate
+		}
+
 		upstreamName := split[2]
 		if upstreamName == "" {
 			// if we're here then it means we do not have a local version of the remote.
@@ -146,6 +200,12 @@ func (self *BranchLoader) obtainBranches() []*models.Branch {
 		}
 
 		track := split[3]
+		if track == "[gone]" {
+			branch.UpstreamGone = true
+			branches = append(branches, branch)
+			continue
+		}
+
 		re := regexp.MustCompile(`ahead (\d+)`)
 		match := re.FindStringSubmatch(track)
 		if len(match) > 1 {
@@ -0,0 +1,88 @@
+package loaders
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/go-git/v5/config"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBranchLoaderConfig struct{}
+
+func (self *fakeBranchLoaderConfig) Branches() (map[string]*config.Branch, error) {
+	return map[string]*config.Branch{}, nil
+}
+
+const rawBranchesOutput = ` |bugfix/b|origin/bugfix/b|[behind 3]|1630000000
+*|master|origin/master||1640000000
+ |feature/a|origin/feature/a|[ahead 1, behind 1]|1620000000
+ |alpha|||1610000000
+ |zeta|origin/zeta||1650000000
+ |old|origin/old|[gone]|1600000000
+a warning from git
+`
+
+func TestLoadBranches(t *testing.T) {
+	type scenario struct {
+		testName      string
+		sortOrder     string
+		expectedNames []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:      "recency",
+			sortOrder:     BRANCH_SORT_ORDER_RECENCY,
+			expectedNames: []string{"master", "feature/a", "alpha", "bugfix/b", "zeta", "old"},
+		},
+		{
+			testName:      "alphabetical",
+			sortOrder:     BRANCH_SORT_ORDER_ALPHABETICAL,
+			expectedNames: []string{"master", "alpha", "bugfix/b", "feature/a", "old", "zeta"},
+		},
+		{
+			testName:      "committer date",
+			sortOrder:     BRANCH_SORT_ORDER_DATE,
+			expectedNames: []string{"master", "zeta", "bugfix/b", "feature/a", "alpha", "old"},
+		},
+		{
+			testName:      "divergence",
+			sortOrder:     BRANCH_SORT_ORDER_DIVERGENCE,
+			expectedNames: []string{"master", "bugfix/b", "feature/a", "zeta", "alpha", "old"},
+		},
+	}
+
+	reflogCommits := []*models.Commit{
+		{Name: "checkout: moving from feature/a to master", UnixTimestamp: 1660000000},
+		{Name: "checkout: moving from alpha to feature/a", UnixTimestamp: 1650000000},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			loader := NewBranchLoader(
+				utils.NewDummyCommon(),
+				func() (string, error) { return rawBranchesOutput, nil },
+				func() (string, string, error) { return "master", "master", nil },
+				&fakeBranchLoaderConfig{},
+			)
+
+			branches, err := loader.Load(reflogCommits, s.sortOrder)
+			assert.NoError(t, err)
+
+			names := make([]string, len(branches))
+			for i, branch := range branches {
+				names[i] = branch.Name
+			}
+			assert.EqualValues(t, s.expectedNames, names)
+
+			assert.True(t, branches[0].Head)
+
+			for _, branch := range branches {
+				assert.Equal(t, branch.Name == "old", branch.UpstreamGone)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
 	"github.com/jesseduffield/lazygit/pkg/commands/types/enums"
 	"github.com/jesseduffield/lazygit/pkg/common"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
@@ -56,12 +57,40 @@ func NewCommitLoader(
 }
 
 type GetCommitsOptions struct {
-	Limit                bool
+	// the maximum number of commits to get from the log, not counting rebasing
+	// commits. Zero means no limit
+	Limit                int
 	FilterPath           string
 	IncludeRebaseCommits bool
 	RefName              string // e.g. "HEAD" or "my_branch"
 	// determines if we show the whole git graph i.e. pass the '--all' flag
 	All bool
+	// e.g. '10,20:path/to/file'. If set, we only get the commits that touched
+	// those lines, as per `git log -L`
+	FilterLineRange string
+	// if set, we only get the commits matching the search
+	Search CommitSearch
+}
+
+type CommitSearchKind int
+
+const (
+	// matches the commit message, as per `git log --grep`
+	COMMIT_SEARCH_MESSAGE CommitSearchKind = iota
+	// matches the commit author, as per `git log --author`
+	COMMIT_SEARCH_AUTHOR
+	// matches commits that change the number of occurrences of a string, as per
+	// `git log -S`
+	COMMIT_SEARCH_ADDED_OR_REMOVED
+	// matches commits whose diff has an added or removed line matching a regex,
+	// as per `git log -G`
+	COMMIT_SEARCH_CHANGED_LINES
+)
+
+// CommitSearch restricts the log to the commits matching a query
+type CommitSearch struct {
+	Kind  CommitSearchKind
+	Query string
 }
 
 // GetCommits obtains the commits of the current branch
@@ -73,7 +102,7 @@ func (self *CommitLoader) GetCommits(opts GetCommitsOptions) ([]*models.Commit,
 		return nil, err
 	}
 
-	if opts.IncludeRebaseCommits && opts.FilterPath == "" {
+	if opts.IncludeRebaseCommits && opts.FilterPath == "" && opts.FilterLineRange == "" {
 		var err error
 		rebasingCommits, err = self.MergeRebasingCommits(commits)
 		if err != nil {
@@ -82,27 +111,11 @@ func (self *CommitLoader) GetCommits(opts GetCommitsOptions) ([]*models.Commit,
 		commits = append(commits, rebasingCommits...)
 	}
 
-	passedFirstPushedCommit := false
-	firstPushedCommit, err := self.getFirstPushedCommit(opts.RefName)
-	if err != nil {
-		// must have no upstream branch so we'll consider everything as pushed
-		passedFirstPushedCommit = true
-	}
-
-	err = self.getLogCmd(opts).RunAndProcessLines(func(line string) (bool, error) {
-		if canExtractCommit(line) {
-			commit := self.extractCommitFromLine(line)
-			if commit.Sha == firstPushedCommit {
-				passedFirstPushedCommit = true
-			}
-			commit.Status = map[bool]string{true: "unpushed", false: "pushed"}[!passedFirstPushedCommit]
-			commits = append(commits, commit)
-		}
-		return false, nil
-	})
+	logCommits, err := self.getLogCommits(opts, 0, false)
 	if err != nil {
 		return nil, err
 	}
+	commits = append(commits, logCommits...)
 
 	if len(commits) == 0 {
 		return commits, nil
@@ -114,7 +127,70 @@ func (self *CommitLoader) GetCommits(opts GetCommitsOptions) ([]*models.Commit,
 		currentCommit.Name = fmt.Sprintf("%s %s", youAreHere, currentCommit.Name)
 	}
 
-	commits, err = self.setCommitMergedStatuses(opts.RefName, commits)
+	commits, err = self.setCommitMergedStatuses(opts.RefName, commits, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+// GetMoreCommits gets the next page of commits following on from the given
+// commits, which must have been obtained with the same options. Only the new
+// commits are returned
+func (self *CommitLoader) GetMoreCommits(commits []*models.Commit, opts GetCommitsOptions) ([]*models.Commit, error) {
+	logCommits := []*models.Commit{}
+	for _, commit := range commits {
+		if !commit.IsTODO() {
+			logCommits = append(logCommits, commit)
+		}
+	}
+
+	passedFirstPushedCommit := false
+	if len(logCommits) > 0 {
+		passedFirstPushedCommit = logCommits[len(logCommits)-1].Status != "unpushed"
+	}
+
+	newCommits, err := self.getLogCommits(opts, len(logCommits), passedFirstPushedCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(newCommits) == 0 {
+		return newCommits, nil
+	}
+
+	passedAncestor := len(logCommits) > 0 && logCommits[len(logCommits)-1].Status == "merged"
+
+	return self.setCommitMergedStatuses(opts.RefName, newCommits, passedAncestor)
+}
+
+// getLogCommits gets the commits from the git log, skipping the first 'skip'
+// commits. Commits above the first pushed commit are marked as unpushed, unless
+// we've already passed it on a previous page
+func (self *CommitLoader) getLogCommits(opts GetCommitsOptions, skip int, passedFirstPushedCommit bool) ([]*models.Commit, error) {
+	firstPushedCommit := ""
+	if !passedFirstPushedCommit {
+		var err error
+		firstPushedCommit, err = self.getFirstPushedCommit(opts.RefName)
+		if err != nil {
+			// must have no upstream branch so we'll consider everything as pushed
+			passedFirstPushedCommit = true
+		}
+	}
+
+	commits := []*models.Commit{}
+	err := self.getLogCmd(opts, skip).RunAndProcessLines(func(line string) (bool, error) {
+		if canExtractCommit(line) {
+			commit := self.extractCommitFromLine(line)
+			if commit.Sha == firstPushedCommit {
+				passedFirstPushedCommit = true
+			}
+			commit.Status = map[bool]string{true: "unpushed", false: "pushed"}[!passedFirstPushedCommit]
+			commits = append(commits, commit)
+		}
+		return false, nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -200,9 +276,16 @@ func (self *CommitLoader) getHydratedRebasingCommits(rebaseMode enums.RebaseMode
 		return nil, nil
 	}
 
-	commitShas := make([]string, len(commits))
-	for i, commit := range commits {
-		commitShas[i] = commit.Sha
+	// some todos (e.g. exec or label lines) have no commit to hydrate
+	commitShas := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		if commit.Sha != "" {
+			commitShas = append(commitShas, commit.Sha)
+		}
+	}
+
+	if len(commitShas) == 0 {
+		return commits, nil
 	}
 
 	// note that we're not filtering these as we do non-rebasing commits just because
@@ -220,6 +303,14 @@ func (self *CommitLoader) getHydratedRebasingCommits(rebaseMode enums.RebaseMode
 	i := 0
 	err = cmdObj.RunAndProcessLines(func(line string) (bool, error) {
 		if canExtractCommit(line) {
+			for i < len(commits) && commits[i].Sha == "" {
+				hydratedCommits = append(hydratedCommits, commits[i])
+				i++
+			}
+			if i == len(commits) {
+				return true, nil
+			}
+
 			commit := self.extractCommitFromLine(line)
 			matchingCommit := commits[i]
 			commit.Action = matchingCommit.Action
@@ -232,7 +323,7 @@ func (self *CommitLoader) getHydratedRebasingCommits(rebaseMode enums.RebaseMode
 	if err != nil {
 		return nil, err
 	}
-	return hydratedCommits, nil
+	return append(hydratedCommits, commits[i:]...), nil
 }
 
 // getRebasingCommits obtains the commits that we're in the process of rebasing
@@ -288,6 +379,8 @@ func (self *CommitLoader) getNormalRebasingCommits() ([]*models.Commit, error) {
 // git-rebase-todo example:
 // pick ac446ae94ee560bdb8d1d057278657b251aaef17 ac446ae
 // pick afb893148791a2fbd8091aeb81deba4930c73031 afb8931
+// exec make test
+// label onto
 
 // git-rebase-todo.backup example:
 // pick 49cbba374296938ea86bbd4bf4fee2f6ba5cccf6 third commit on master
@@ -305,27 +398,45 @@ func (self *CommitLoader) getInteractiveRebasingCommits() ([]*models.Commit, err
 		return nil, nil
 	}
 
+	todos, err := todo.Parse(string(bytesContent))
+	if err != nil {
+		self.Log.Error(fmt.Sprintf("error occurred while parsing git-rebase-todo file: %s", err.Error()))
+		return nil, nil
+	}
+
 	commits := []*models.Commit{}
-	lines := strings.Split(string(bytesContent), "\n")
-	for _, line := range lines {
-		if line == "" || line == "noop" {
-			return commits, nil
-		}
-		if strings.HasPrefix(line, "#") {
+	for _, t := range todos {
+		if t.Command == todo.Comment || t.Command == todo.Noop {
 			continue
 		}
-		splitLine := strings.Split(line, " ")
 		commits = append([]*models.Commit{{
-			Sha:    splitLine[1],
-			Name:   strings.Join(splitLine[2:], " "),
+			Sha:    t.Commit,
+			Name:   todoName(t),
 			Status: "rebasing",
-			Action: splitLine[0],
+			Action: t.Command,
 		}}, commits...)
 	}
 
 	return commits, nil
 }
 
+// todoName returns what we display for a todo in place of a commit's subject
+func todoName(t todo.Todo) string {
+	switch t.Command {
+	case todo.Exec:
+		return t.ExecCommand
+	case todo.Label, todo.Reset, todo.UpdateRef:
+		return t.Label
+	case todo.Merge:
+		if t.Msg != "" {
+			return t.Msg
+		}
+		return t.Label
+	default:
+		return t.Msg
+	}
+}
+
 // assuming the file starts like this:
 // From e93d4193e6dd45ca9cf3a5a273d7ba6cd8b8fb20 Mon Sep 17 00:00:00 2001
 // From: Lazygit Tester <test@example.com>
@@ -342,17 +453,23 @@ func (self *CommitLoader) commitFromPatch(content string) *models.Commit {
 	}
 }
 
-func (self *CommitLoader) setCommitMergedStatuses(refName string, commits []*models.Commit) ([]*models.Commit, error) {
-	ancestor, err := self.getMergeBase(refName)
-	if err != nil {
-		return nil, err
-	}
-	if ancestor == "" {
-		return commits, nil
+// setCommitMergedStatuses marks the pushed commits from the merge base onwards as
+// merged. passedAncestor is for when we've already passed the merge base on a
+// previous page of commits
+func (self *CommitLoader) setCommitMergedStatuses(refName string, commits []*models.Commit, passedAncestor bool) ([]*models.Commit, error) {
+	ancestor := ""
+	if !passedAncestor {
+		var err error
+		ancestor, err = self.getMergeBase(refName)
+		if err != nil {
+			return nil, err
+		}
+		if ancestor == "" {
+			return commits, nil
+		}
 	}
-	passedAncestor := false
 	for i, commit := range commits {
-		if strings.HasPrefix(ancestor, commit.Sha) {
+		if !passedAncestor && strings.HasPrefix(ancestor, commit.Sha) {
 			passedAncestor = true
 		}
 		if commit.Status != "pushed" {
@@ -408,19 +525,27 @@ func (self *CommitLoader) getFirstPushedCommit(refName string) (string, error) {
 }
 
 // getLog gets the git log.
-func (self *CommitLoader) getLogCmd(opts GetCommitsOptions) oscommands.ICmdObj {
+func (self *CommitLoader) getLogCmd(opts GetCommitsOptions, skip int) oscommands.ICmdObj {
 	limitFlag := ""
-	if opts.Limit {
-		limitFlag = " -300"
+	if opts.Limit > 0 {
+		limitFlag = fmt.Sprintf(" -%d", opts.Limit)
+	}
+	if skip > 0 {
+		limitFlag += fmt.Sprintf(" --skip=%d", skip)
 	}
 
 	filterFlag := ""
-	if opts.FilterPath != "" {
+	if opts.FilterLineRange != "" {
+		// -L would give us the diff of each commit by default, which we don't want here
+		filterFlag = fmt.Sprintf(" --no-patch -L %s", self.cmd.Quote(opts.FilterLineRange))
+	} else if opts.FilterPath != "" {
 		filterFlag = fmt.Sprintf(" --follow -- %s", self.cmd.Quote(opts.FilterPath))
 	}
 
 	config := self.UserConfig.Git.Log
 
+	searchFlag := self.getSearchFlag(opts.Search)
+
 	orderFlag := "--" + config.Order
 	allFlag := ""
 	if opts.All {
@@ -429,18 +554,37 @@ func (self *CommitLoader) getLogCmd(opts GetCommitsOptions) oscommands.ICmdObj {
 
 	return self.cmd.New(
 		fmt.Sprintf(
-			"git log %s %s %s --oneline %s%s --abbrev=%d%s",
+			"git log %s %s %s --oneline %s%s --abbrev=%d%s%s",
 			self.cmd.Quote(opts.RefName),
 			orderFlag,
 			allFlag,
 			prettyFormat,
 			limitFlag,
 			20,
+			searchFlag,
 			filterFlag,
 		),
 	).DontLog()
 }
 
+func (self *CommitLoader) getSearchFlag(search CommitSearch) string {
+	if search.Query == "" {
+		return ""
+	}
+
+	query := self.cmd.Quote(search.Query)
+	switch search.Kind {
+	case COMMIT_SEARCH_AUTHOR:
+		return " --regexp-ignore-case --author " + query
+	case COMMIT_SEARCH_ADDED_OR_REMOVED:
+		return " -S " + query
+	case COMMIT_SEARCH_CHANGED_LINES:
+		return " -G " + query
+	default:
+		return " --regexp-ignore-case --grep " + query
+	}
+}
+
 var prettyFormat = fmt.Sprintf(
 	"--pretty=format:\"%%H%s%%at%s%%aN%s%%d%s%%p%s%%s\"",
 	SEPARATION_CHAR,
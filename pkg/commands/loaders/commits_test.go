@@ -6,6 +6,7 @@ import (
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
 	"github.com/jesseduffield/lazygit/pkg/commands/types/enums"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/stretchr/testify/assert"
@@ -80,7 +81,7 @@ func TestGetCommits(t *testing.T) {
 					Sha:           "0eea75e8c631fba6b58135697835d58ba4c18dbc",
 					Name:          "better typing for rebase mode",
 					Status:        "unpushed",
-					Action:        "",
+					Action:        todo.NoCommand,
 					Tags:          []string{},
 					ExtraInfo:     "(HEAD -> better-tests)",
 					Author:        "Jesse Duffield",
@@ -93,7 +94,7 @@ func TestGetCommits(t *testing.T) {
 					Sha:           "b21997d6b4cbdf84b149d8e6a2c4d06a8e9ec164",
 					Name:          "fix logging",
 					Status:        "pushed",
-					Action:        "",
+					Action:        todo.NoCommand,
 					Tags:          []string{},
 					ExtraInfo:     "(origin/better-tests)",
 					Author:        "Jesse Duffield",
@@ -106,7 +107,7 @@ func TestGetCommits(t *testing.T) {
 					Sha:           "e94e8fc5b6fab4cb755f29f1bdb3ee5e001df35c",
 					Name:          "refactor",
 					Status:        "pushed",
-					Action:        "",
+					Action:        todo.NoCommand,
 					Tags:          []string{},
 					ExtraInfo:     "",
 					Author:        "Jesse Duffield",
@@ -119,7 +120,7 @@ func TestGetCommits(t *testing.T) {
 					Sha:           "d8084cd558925eb7c9c38afeed5725c21653ab90",
 					Name:          "WIP",
 					Status:        "pushed",
-					Action:        "",
+					Action:        todo.NoCommand,
 					Tags:          []string{},
 					ExtraInfo:     "",
 					Author:        "Jesse Duffield",
@@ -132,7 +133,7 @@ func TestGetCommits(t *testing.T) {
 					Sha:           "65f910ebd85283b5cce9bf67d03d3f1a9ea3813a",
 					Name:          "WIP",
 					Status:        "pushed",
-					Action:        "",
+					Action:        todo.NoCommand,
 					Tags:          []string{},
 					ExtraInfo:     "",
 					Author:        "Jesse Duffield",
@@ -145,7 +146,7 @@ func TestGetCommits(t *testing.T) {
 					Sha:           "26c07b1ab33860a1a7591a0638f9925ccf497ffa",
 					Name:          "WIP",
 					Status:        "merged",
-					Action:        "",
+					Action:        todo.NoCommand,
 					Tags:          []string{},
 					ExtraInfo:     "",
 					Author:        "Jesse Duffield",
@@ -158,7 +159,7 @@ func TestGetCommits(t *testing.T) {
 					Sha:           "3d4470a6c072208722e5ae9a54bcb9634959a1c5",
 					Name:          "WIP",
 					Status:        "merged",
-					Action:        "",
+					Action:        todo.NoCommand,
 					Tags:          []string{},
 					ExtraInfo:     "",
 					Author:        "Jesse Duffield",
@@ -171,7 +172,7 @@ func TestGetCommits(t *testing.T) {
 					Sha:           "053a66a7be3da43aacdc7aa78e1fe757b82c4dd2",
 					Name:          "refactoring the config struct",
 					Status:        "merged",
-					Action:        "",
+					Action:        todo.NoCommand,
 					Tags:          []string{},
 					ExtraInfo:     "",
 					Author:        "Jesse Duffield",
@@ -183,6 +184,42 @@ func TestGetCommits(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			testName:          "should filter by line range",
+			rebaseMode:        enums.REBASE_MODE_NONE,
+			currentBranchName: "master",
+			opts:              GetCommitsOptions{RefName: "HEAD", IncludeRebaseCommits: true, FilterPath: "pkg/file.go", FilterLineRange: "10,20:pkg/file.go"},
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git merge-base "HEAD" "HEAD"@{u}`, "b21997d6b4cbdf84b149d8e6a2c4d06a8e9ec164", nil).
+				Expect(`git log "HEAD" --topo-order  --oneline --pretty=format:"%H|%at|%aN|%d|%p|%s" --abbrev=20 --no-patch -L "10,20:pkg/file.go"`, "", nil),
+
+			expectedCommits: []*models.Commit{},
+			expectedError:   nil,
+		},
+		{
+			testName:          "should search commit messages",
+			rebaseMode:        enums.REBASE_MODE_NONE,
+			currentBranchName: "master",
+			opts:              GetCommitsOptions{RefName: "HEAD", Search: CommitSearch{Kind: COMMIT_SEARCH_MESSAGE, Query: "fix bug"}},
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git merge-base "HEAD" "HEAD"@{u}`, "b21997d6b4cbdf84b149d8e6a2c4d06a8e9ec164", nil).
+				Expect(`git log "HEAD" --topo-order  --oneline --pretty=format:"%H|%at|%aN|%d|%p|%s" --abbrev=20 --regexp-ignore-case --grep "fix bug"`, "", nil),
+
+			expectedCommits: []*models.Commit{},
+			expectedError:   nil,
+		},
+		{
+			testName:          "should search for added or removed text within a path",
+			rebaseMode:        enums.REBASE_MODE_NONE,
+			currentBranchName: "master",
+			opts:              GetCommitsOptions{RefName: "HEAD", FilterPath: "pkg/file.go", Search: CommitSearch{Kind: COMMIT_SEARCH_ADDED_OR_REMOVED, Query: "myFunc"}},
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git merge-base "HEAD" "HEAD"@{u}`, "b21997d6b4cbdf84b149d8e6a2c4d06a8e9ec164", nil).
+				Expect(`git log "HEAD" --topo-order  --oneline --pretty=format:"%H|%at|%aN|%d|%p|%s" --abbrev=20 -S "myFunc" --follow -- "pkg/file.go"`, "", nil),
+
+			expectedCommits: []*models.Commit{},
+			expectedError:   nil,
+		},
 	}
 
 	for _, scenario := range scenarios {
@@ -211,3 +248,105 @@ func TestGetCommits(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMoreCommits(t *testing.T) {
+	const page = `b21997d6b4cbdf84b149d8e6a2c4d06a8e9ec164|1640824515|Jesse Duffield| (origin/better-tests)|e94e8fc5b6fab4cb755f|fix logging
+e94e8fc5b6fab4cb755f29f1bdb3ee5e001df35c|1640823749|Jesse Duffield||d8084cd558925eb7c9c3|refactor`
+
+	type scenario struct {
+		testName         string
+		runner           *oscommands.FakeCmdObjRunner
+		existingCommits  []*models.Commit
+		expectedStatuses []string
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "continuing on from unpushed commits",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git merge-base "HEAD" "HEAD"@{u}`, "b21997d6b4cbdf84b149d8e6a2c4d06a8e9ec164", nil).
+				Expect(`git log "HEAD" --topo-order  --oneline --pretty=format:"%H|%at|%aN|%d|%p|%s" -2 --skip=1 --abbrev=20`, page, nil).
+				Expect(`git merge-base "HEAD" "master"`, "e94e8fc5b6fab4cb755f29f1bdb3ee5e001df35c", nil),
+			existingCommits: []*models.Commit{
+				{Sha: "1111", Name: "a rebasing commit", Status: "rebasing", Action: todo.Pick},
+				{Sha: "0eea75e8c631fba6b58135697835d58ba4c18dbc", Status: "unpushed"},
+			},
+			expectedStatuses: []string{"pushed", "merged"},
+		},
+		{
+			testName: "continuing on from merged commits",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git log "HEAD" --topo-order  --oneline --pretty=format:"%H|%at|%aN|%d|%p|%s" -2 --skip=1 --abbrev=20`, page, nil),
+			existingCommits: []*models.Commit{
+				{Sha: "0eea75e8c631fba6b58135697835d58ba4c18dbc", Status: "merged"},
+			},
+			expectedStatuses: []string{"merged", "merged"},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			loader := NewDummyCommitLoader()
+			loader.cmd = oscommands.NewDummyCmdObjBuilder(s.runner)
+
+			commits, err := loader.GetMoreCommits(s.existingCommits, GetCommitsOptions{RefName: "HEAD", Limit: 2})
+			assert.NoError(t, err)
+
+			statuses := make([]string, len(commits))
+			for i, commit := range commits {
+				statuses[i] = commit.Status
+			}
+			assert.EqualValues(t, s.expectedStatuses, statuses)
+			assert.Equal(t, "b21997d6b4cbdf84b149d8e6a2c4d06a8e9ec164", commits[0].Sha)
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestGetInteractiveRebasingCommits(t *testing.T) {
+	todoContent := "pick 1111 first commit\n" +
+		"exec make test\n" +
+		"label feature\n" +
+		"pick 2222 second commit\n" +
+		"\n" +
+		"# Rebase 0000..2222 onto 0000 (4 commands)\n"
+
+	runner := oscommands.NewFakeRunner(t).
+		Expect(
+			`git show 2222 1111 --no-patch --oneline --pretty=format:"%H|%at|%aN|%d|%p|%s" --abbrev=20`,
+			"2222|1640826609|Jesse Duffield| (HEAD)|0000|second commit\n1111|1640826608|Jesse Duffield||0000|first commit",
+			nil,
+		)
+
+	builder := &CommitLoader{
+		Common:    utils.NewDummyCommon(),
+		cmd:       oscommands.NewDummyCmdObjBuilder(runner),
+		dotGitDir: ".git",
+		readFile: func(filename string) ([]byte, error) {
+			return []byte(todoContent), nil
+		},
+	}
+
+	commits, err := builder.getHydratedRebasingCommits(enums.REBASE_MODE_INTERACTIVE)
+	assert.NoError(t, err)
+
+	type todoCommit struct {
+		Sha    string
+		Name   string
+		Action todo.TodoCommand
+	}
+	result := make([]todoCommit, len(commits))
+	for i, commit := range commits {
+		assert.Equal(t, "rebasing", commit.Status)
+		result[i] = todoCommit{Sha: commit.Sha, Name: commit.Name, Action: commit.Action}
+	}
+
+	assert.EqualValues(t, []todoCommit{
+		{Sha: "2222", Name: "second commit", Action: todo.Pick},
+		{Sha: "", Name: "feature", Action: todo.Label},
+		{Sha: "", Name: "make test", Action: todo.Exec},
+		{Sha: "1111", Name: "first commit", Action: todo.Pick},
+	}, result)
+	runner.CheckForMissingCalls()
+}
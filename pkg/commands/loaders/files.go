@@ -2,6 +2,8 @@ package loaders
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
@@ -17,14 +19,16 @@ type FileLoaderConfig interface {
 type FileLoader struct {
 	*common.Common
 	cmd         oscommands.ICmdObjBuilder
+	dotGitDir   string
 	config      FileLoaderConfig
 	getFileType func(string) string
 }
 
-func NewFileLoader(cmn *common.Common, cmd oscommands.ICmdObjBuilder, config FileLoaderConfig) *FileLoader {
+func NewFileLoader(cmn *common.Common, cmd oscommands.ICmdObjBuilder, dotGitDir string, config FileLoaderConfig) *FileLoader {
 	return &FileLoader{
 		Common:      cmn,
 		cmd:         cmd,
+		dotGitDir:   dotGitDir,
 		getFileType: oscommands.FileType,
 		config:      config,
 	}
@@ -79,9 +83,44 @@ func (self *FileLoader) GetStatusFiles(opts GetStatusFileOptions) []*models.File
 		files = append(files, file)
 	}
 
+	self.markRerereResolvedFiles(files)
+
 	return files
 }
 
+// markRerereResolvedFiles flags the conflicted files that git rerere has resolved
+// using a recorded resolution. These still show as conflicted in git status but
+// they aren't among the paths `git rerere remaining` considers unresolved. Git
+// only writes MERGE_RR when rerere is enabled, and if it isn't, `git rerere
+// remaining` prints nothing, so we need to check for it first
+func (self *FileLoader) markRerereResolvedFiles(files []*models.File) {
+	conflictedFiles := []*models.File{}
+	for _, file := range files {
+		if file.HasMergeConflicts {
+			conflictedFiles = append(conflictedFiles, file)
+		}
+	}
+
+	if len(conflictedFiles) == 0 {
+		return
+	}
+
+	if _, err := os.Stat(filepath.Join(self.dotGitDir, "MERGE_RR")); err != nil {
+		return
+	}
+
+	output, err := self.cmd.New("git rerere remaining").DontLog().RunWithOutput()
+	if err != nil {
+		self.Log.Error(err)
+		return
+	}
+
+	remaining := utils.SplitLines(output)
+	for _, file := range conflictedFiles {
+		file.ResolvedByRerere = !utils.IncludesString(remaining, file.Name)
+	}
+}
+
 // GitStatus returns the file status of the repo
 type GitStatusOptions struct {
 	NoRenames         bool
@@ -1,6 +1,9 @@
 package loaders
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
@@ -201,6 +204,35 @@ func TestFileGetStatusFiles(t *testing.T) {
 	}
 }
 
+func TestFileGetStatusFilesMarksRerereResolvedFiles(t *testing.T) {
+	dotGitDir, err := ioutil.TempDir("", "lazygit-rerere")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dotGitDir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dotGitDir, "MERGE_RR"), []byte{}, 0644))
+
+	runner := oscommands.NewFakeRunner(t).
+		Expect(`git status --untracked-files=yes --porcelain -z`, "UU file1.txt\x00UU file2.txt\x00M  file3.txt", nil).
+		Expect(`git rerere remaining`, "file2.txt\n", nil)
+
+	loader := &FileLoader{
+		Common:      utils.NewDummyCommon(),
+		cmd:         oscommands.NewDummyCmdObjBuilder(runner),
+		dotGitDir:   dotGitDir,
+		config:      &FakeFileLoaderConfig{showUntrackedFiles: "yes"},
+		getFileType: func(string) string { return "file" },
+	}
+
+	files := loader.GetStatusFiles(GetStatusFileOptions{})
+	runner.CheckForMissingCalls()
+
+	resolvedByRerere := map[string]bool{}
+	for _, file := range files {
+		resolvedByRerere[file.Name] = file.ResolvedByRerere
+	}
+	assert.EqualValues(t, map[string]bool{"file1.txt": true, "file2.txt": false, "file3.txt": false}, resolvedByRerere)
+}
+
 type FakeFileLoaderConfig struct {
 	showUntrackedFiles string
 }
@@ -1,6 +1,8 @@
 package loaders
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
@@ -9,6 +11,27 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
+// the orders we can list tags in
+const (
+	// newest first
+	TAG_SORT_ORDER_DATE = "date"
+	// highest version first, so that v1.10.0 comes before v1.9.0
+	TAG_SORT_ORDER_VERSION = "version"
+	// alphabetical
+	TAG_SORT_ORDER_NAME = "name"
+)
+
+var tagSortKeys = map[string]string{
+	TAG_SORT_ORDER_DATE:    "-creatordate",
+	TAG_SORT_ORDER_VERSION: "-v:refname",
+	TAG_SORT_ORDER_NAME:    "refname",
+}
+
+// %(*objectname) is the sha of the commit an annotated tag points to, and is
+// empty for lightweight tags. %(creatordate) is the tagger date for annotated
+// tags and the committer date for lightweight tags.
+const tagFormat = "%(refname:strip=2)%00%(objecttype)%00%(objectname)%00%(*objectname)%00%(taggername)%00%(creatordate:unix)%00%(contents:subject)"
+
 type TagLoader struct {
 	*common.Common
 	cmd oscommands.ICmdObjBuilder
@@ -24,28 +47,59 @@ func NewTagLoader(
 	}
 }
 
-func (self *TagLoader) GetTags() ([]*models.Tag, error) {
-	// get remote branches, sorted  by creation date (descending)
-	// see: https://git-scm.com/docs/git-tag#Documentation/git-tag.txt---sortltkeygt
-	remoteBranchesStr, err := self.cmd.New(`git tag --list --sort=-creatordate`).DontLog().RunWithOutput()
+func (self *TagLoader) GetTags(sortOrder string) ([]*models.Tag, error) {
+	sortKey, ok := tagSortKeys[sortOrder]
+	if !ok {
+		sortKey = tagSortKeys[TAG_SORT_ORDER_DATE]
+	}
+
+	// see: https://git-scm.com/docs/git-for-each-ref#_field_names
+	cmdStr := fmt.Sprintf("git for-each-ref --sort=%s --format=%s refs/tags", sortKey, self.cmd.Quote(tagFormat))
+	output, err := self.cmd.New(cmdStr).DontLog().RunWithOutput()
 	if err != nil {
 		return nil, err
 	}
 
-	content := utils.TrimTrailingNewline(remoteBranchesStr)
+	content := utils.TrimTrailingNewline(output)
 	if content == "" {
 		return nil, nil
 	}
 
-	split := strings.Split(content, "\n")
-
-	// first step is to get our remotes from go-git
-	tags := make([]*models.Tag, len(split))
-	for i, tagName := range split {
-		tags[i] = &models.Tag{
-			Name: tagName,
+	lines := strings.Split(content, "\n")
+	tags := make([]*models.Tag, 0, len(lines))
+	for _, line := range lines {
+		tag, ok := parseTagLine(line)
+		if !ok {
+			continue
 		}
+		tags = append(tags, tag)
 	}
 
 	return tags, nil
 }
+
+func parseTagLine(line string) (*models.Tag, bool) {
+	split := strings.Split(line, "\x00")
+	if len(split) != 7 {
+		return nil, false
+	}
+
+	tag := &models.Tag{
+		Name:        split[0],
+		IsAnnotated: split[1] == "tag",
+		TargetSha:   split[2],
+	}
+
+	if tag.IsAnnotated {
+		if split[3] != "" {
+			tag.TargetSha = split[3]
+		}
+		tag.Tagger = split[4]
+		tag.Message = split[6]
+	}
+
+	// swallowing the error because a missing date isn't worth failing over
+	tag.Date, _ = strconv.ParseInt(split[5], 10, 64)
+
+	return tag, true
+}
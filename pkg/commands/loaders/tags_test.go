@@ -0,0 +1,90 @@
+package loaders
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const tagsOutput = "v1.1.0\x00tag\x00f1f1f1\x00a1a1a1\x00Jesse Duffield\x001640000000\x00Release v1.1.0\n" +
+	"v1.0.0\x00commit\x00b2b2b2\x00\x00\x001630000000\x00some commit subject\n" +
+	"a warning from git\n"
+
+func TestGetTags(t *testing.T) {
+	type scenario struct {
+		testName     string
+		sortOrder    string
+		runner       *oscommands.FakeCmdObjRunner
+		expectedTags []*models.Tag
+		expectedErr  error
+	}
+
+	expectSort := func(sortKey string, output string) *oscommands.FakeCmdObjRunner {
+		return oscommands.NewFakeRunner(t).
+			ExpectArgs([]string{"git", "for-each-ref", "--sort=" + sortKey, "--format=" + tagFormat, "refs/tags"}, output, nil)
+	}
+
+	scenarios := []scenario{
+		{
+			testName:     "no tags",
+			sortOrder:    TAG_SORT_ORDER_DATE,
+			runner:       expectSort("-creatordate", ""),
+			expectedTags: nil,
+		},
+		{
+			testName:  "annotated and lightweight tags",
+			sortOrder: TAG_SORT_ORDER_DATE,
+			runner:    expectSort("-creatordate", tagsOutput),
+			expectedTags: []*models.Tag{
+				{
+					Name:        "v1.1.0",
+					IsAnnotated: true,
+					TargetSha:   "a1a1a1",
+					Tagger:      "Jesse Duffield",
+					Message:     "Release v1.1.0",
+					Date:        1640000000,
+				},
+				{
+					Name:      "v1.0.0",
+					TargetSha: "b2b2b2",
+					Date:      1630000000,
+				},
+			},
+		},
+		{
+			testName:     "version sort",
+			sortOrder:    TAG_SORT_ORDER_VERSION,
+			runner:       expectSort("-v:refname", ""),
+			expectedTags: nil,
+		},
+		{
+			testName:     "name sort",
+			sortOrder:    TAG_SORT_ORDER_NAME,
+			runner:       expectSort("refname", ""),
+			expectedTags: nil,
+		},
+		{
+			testName:     "unknown sort order falls back to date",
+			sortOrder:    "blah",
+			runner:       expectSort("-creatordate", ""),
+			expectedTags: nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			loader := NewTagLoader(utils.NewDummyCommon(), oscommands.NewDummyCmdObjBuilder(s.runner))
+
+			tags, err := loader.GetTags(s.sortOrder)
+
+			assert.Equal(t, s.expectedTags, tags)
+			assert.Equal(t, s.expectedErr, err)
+
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package loaders
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+type WorktreeLoader struct {
+	*common.Common
+	cmd   oscommands.ICmdObjBuilder
+	getwd func() (string, error)
+}
+
+func NewWorktreeLoader(
+	common *common.Common,
+	cmd oscommands.ICmdObjBuilder,
+	getwd func() (string, error),
+) *WorktreeLoader {
+	return &WorktreeLoader{
+		Common: common,
+		cmd:    cmd,
+		getwd:  getwd,
+	}
+}
+
+// GetWorktrees parses the output of `git worktree list --porcelain`, which looks like:
+//
+// worktree /path/to/main
+// HEAD 1234abc...
+// branch refs/heads/master
+//
+// worktree /path/to/linked
+// HEAD 5678def...
+// detached
+// locked reason for locking
+//
+// The first entry is always the main worktree.
+func (self *WorktreeLoader) GetWorktrees() ([]*models.Worktree, error) {
+	output, err := self.cmd.New("git worktree list --porcelain").DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	currentDir, err := self.getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees := []*models.Worktree{}
+	var current *models.Worktree
+	for _, line := range utils.SplitLines(output) {
+		if strings.HasPrefix(line, "worktree ") {
+			current = &models.Worktree{
+				Path: strings.TrimPrefix(line, "worktree "),
+				Main: len(worktrees) == 0,
+			}
+			current.Current = samePath(current.Path, currentDir)
+			worktrees = append(worktrees, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "HEAD "):
+			current.Head = strings.TrimPrefix(line, "HEAD ")
+		case strings.HasPrefix(line, "branch "):
+			current.Branch = strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+		case line == "bare":
+			current.Bare = true
+		case line == "detached":
+			current.Detached = true
+		case line == "locked" || strings.HasPrefix(line, "locked "):
+			current.Locked = true
+			current.LockReason = strings.TrimPrefix(strings.TrimPrefix(line, "locked"), " ")
+		case line == "prunable" || strings.HasPrefix(line, "prunable "):
+			current.Prunable = true
+		}
+	}
+
+	return worktrees, nil
+}
+
+func samePath(a string, b string) bool {
+	resolve := func(path string) string {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			return filepath.Clean(resolved)
+		}
+		return filepath.Clean(path)
+	}
+
+	return resolve(a) == resolve(b)
+}
@@ -0,0 +1,134 @@
+package loaders
+
+import (
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWorktrees(t *testing.T) {
+	type scenario struct {
+		testName          string
+		runner            oscommands.ICmdObjRunner
+		expectedWorktrees []*models.Worktree
+		expectedError     error
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "No linked worktrees",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git worktree list --porcelain`, "worktree /repo\nHEAD 1234567890abcdef\nbranch refs/heads/master\n\n", nil),
+			expectedWorktrees: []*models.Worktree{
+				{
+					Path:    "/repo",
+					Head:    "1234567890abcdef",
+					Branch:  "master",
+					Main:    true,
+					Current: true,
+				},
+			},
+		},
+		{
+			testName: "Several worktrees",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(
+					`git worktree list --porcelain`,
+					`worktree /repo
+HEAD 1234567890abcdef
+branch refs/heads/master
+
+worktree /worktrees/feature
+HEAD abcdef1234567890
+branch refs/heads/feature/blah
+locked
+
+worktree /worktrees/detached
+HEAD fedcba0987654321
+detached
+locked moved to usb drive
+
+worktree /worktrees/gone
+HEAD 0987654321fedcba
+detached
+prunable gitdir file points to non-existent location
+`,
+					nil,
+				),
+			expectedWorktrees: []*models.Worktree{
+				{
+					Path:    "/repo",
+					Head:    "1234567890abcdef",
+					Branch:  "master",
+					Main:    true,
+					Current: true,
+				},
+				{
+					Path:   "/worktrees/feature",
+					Head:   "abcdef1234567890",
+					Branch: "feature/blah",
+					Locked: true,
+				},
+				{
+					Path:       "/worktrees/detached",
+					Head:       "fedcba0987654321",
+					Detached:   true,
+					Locked:     true,
+					LockReason: "moved to usb drive",
+				},
+				{
+					Path:     "/worktrees/gone",
+					Head:     "0987654321fedcba",
+					Detached: true,
+					Prunable: true,
+				},
+			},
+		},
+		{
+			testName: "Bare main repo",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git worktree list --porcelain`, "worktree /repo.git\nbare\n\nworktree /repo\nHEAD 1234567890abcdef\nbranch refs/heads/master\n", nil),
+			expectedWorktrees: []*models.Worktree{
+				{
+					Path: "/repo.git",
+					Bare: true,
+					Main: true,
+				},
+				{
+					Path:    "/repo",
+					Head:    "1234567890abcdef",
+					Branch:  "master",
+					Current: true,
+				},
+			},
+		},
+		{
+			testName: "Command fails",
+			runner: oscommands.NewFakeRunner(t).
+				Expect(`git worktree list --porcelain`, "", errors.New("error")),
+			expectedWorktrees: nil,
+			expectedError:     errors.New("error"),
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			cmd := oscommands.NewDummyCmdObjBuilder(s.runner)
+
+			loader := NewWorktreeLoader(utils.NewDummyCommon(), cmd, func() (string, error) { return "/repo", nil })
+
+			worktrees, err := loader.GetWorktrees()
+			assert.EqualValues(t, s.expectedWorktrees, worktrees)
+			if s.expectedError != nil {
+				assert.EqualError(t, err, s.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
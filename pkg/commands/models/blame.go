@@ -0,0 +1,44 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// BlameLine : A line of a file along with the commit that last changed it,
+// as reported by `git blame`
+type BlameLine struct {
+	Sha           string
+	Author        string
+	AuthorEmail   string
+	UnixTimestamp int64
+	Summary       string
+	// the line number in the blamed version of the file, starting at 1
+	LineNumber int
+	// the sha of the commit's parent and the path of the file in that parent,
+	// if the commit isn't a root/boundary commit. These are what we need to
+	// blame the line as it was before this commit changed it
+	PreviousSha      string
+	PreviousFilename string
+	Content          string
+}
+
+func (b *BlameLine) ShortSha() string {
+	return utils.ShortSha(b.Sha)
+}
+
+// IsCommitted tells us whether the line has been committed yet. Lines that
+// are only in the working tree are given an all-zeroes sha by git blame
+func (b *BlameLine) IsCommitted() bool {
+	return strings.Trim(b.Sha, "0") != ""
+}
+
+func (b *BlameLine) ID() string {
+	return b.Sha
+}
+
+func (b *BlameLine) Description() string {
+	return fmt.Sprintf("%s: %s", b.ShortSha(), b.Summary)
+}
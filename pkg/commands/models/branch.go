@@ -15,6 +15,19 @@ type Branch struct {
 	// 'git@github.com:tiwood/lazygit.git'
 	UpstreamRemote string
 	UpstreamBranch string
+	// true when the branch's upstream has been deleted on the remote, and
+	// then pruned locally
+	UpstreamGone bool
+	// unix timestamp of the branch's latest commit
+	CommitterDate int64
+}
+
+// BranchDivergence is how far a branch has diverged from its base branch, which
+// unlike its upstream is something like 'origin/main'
+type BranchDivergence struct {
+	Base   string
+	Ahead  string
+	Behind string
 }
 
 func (b *Branch) RefName() string {
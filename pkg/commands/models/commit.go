@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -11,7 +12,7 @@ type Commit struct {
 	Sha           string
 	Name          string
 	Status        string // one of "unpushed", "pushed", "merged", "rebasing" or "selected"
-	Action        string // one of "", "pick", "edit", "squash", "reword", "drop", "fixup"
+	Action        todo.TodoCommand
 	Tags          []string
 	ExtraInfo     string // something like 'HEAD -> master, tag: v0.15.2'
 	Author        string
@@ -34,6 +35,11 @@ func (c *Commit) ID() string {
 }
 
 func (c *Commit) Description() string {
+	if c.Sha == "" {
+		// e.g. an exec line in a rebase todo
+		return fmt.Sprintf("%s %s", c.Action, c.Name)
+	}
+
 	return fmt.Sprintf("%s %s", c.Sha[:7], c.Name)
 }
 
@@ -44,5 +50,5 @@ func (c *Commit) IsMerge() bool {
 // returns true if this commit is not actually in the git log but instead
 // is from a TODO file for an interactive rebase.
 func (c *Commit) IsTODO() bool {
-	return c.Action != ""
+	return c.Action != todo.NoCommand
 }
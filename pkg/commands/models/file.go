@@ -19,6 +19,10 @@ type File struct {
 	DisplayString           string
 	Type                    string // one of 'file', 'directory', and 'other'
 	ShortStatus             string // e.g. 'AD', ' A', 'M ', '??'
+
+	// true if git rerere has resolved the file's conflicts using a resolution it
+	// recorded earlier. The file remains unmerged until it's staged
+	ResolvedByRerere bool
 }
 
 // sometimes we need to deal with either a node (which contains a file) or an actual file
@@ -0,0 +1,20 @@
+package models
+
+// PullRequest : A pull request (or merge request, in GitLab's terms) fetched
+// from a forge's API
+type PullRequest struct {
+	Number     int
+	Title      string
+	HeadBranch string
+	HeadSha    string
+	URL        string
+	// one of 'open' or 'draft'. We only fetch open pull requests
+	State string
+	// the combined status of the CI checks on the head commit: one of 'success',
+	// 'pending', 'failure', or empty if there are no checks
+	CIStatus string
+}
+
+func (pr *PullRequest) IsDraft() bool {
+	return pr.State == "draft"
+}
@@ -0,0 +1,23 @@
+package models
+
+// RerereEntry : A conflict recorded by git rerere, stored in a directory of
+// .git/rr-cache
+type RerereEntry struct {
+	// the hash of the conflict, which is also the name of the entry's directory
+	Hash string
+	// when the entry was last written to
+	UnixTimestamp int64
+	// false if rerere has recorded the conflict but not yet how it was resolved
+	Resolved bool
+	// rerere doesn't record which file a conflict came from so to help identify
+	// it we show the first line of the conflict
+	Preview string
+}
+
+func (e *RerereEntry) ID() string {
+	return e.Hash
+}
+
+func (e *RerereEntry) Description() string {
+	return e.Hash
+}
@@ -3,6 +3,18 @@ package models
 // Tag : A git tag
 type Tag struct {
 	Name string
+	// annotated tags are tag objects with their own tagger, date and message,
+	// whereas lightweight tags just point straight at a commit
+	IsAnnotated bool
+	// the sha of the commit the tag points to
+	TargetSha string
+	// empty for lightweight tags
+	Tagger string
+	// the subject of the tag message. Empty for lightweight tags
+	Message string
+	// unix timestamp of when the tag was created. For lightweight tags this is
+	// the date of the commit it points to
+	Date int64
 }
 
 func (t *Tag) RefName() string {
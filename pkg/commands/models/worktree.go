@@ -0,0 +1,52 @@
+package models
+
+// Worktree : A git worktree
+type Worktree struct {
+	Path string
+	// the sha of the commit the worktree's HEAD points to
+	Head string
+	// short name of the checked out branch. Empty if the worktree is bare or in
+	// a detached head state
+	Branch   string
+	Bare     bool
+	Detached bool
+	Locked   bool
+	// only set if the worktree was locked with a reason
+	LockReason string
+	// prunable worktrees are ones whose directory has been removed without
+	// telling git, meaning `git worktree prune` would clean them up
+	Prunable bool
+	// the main worktree is the one that holds the actual .git directory
+	Main bool
+	// whether lazygit is currently open in this worktree
+	Current bool
+}
+
+func (w *Worktree) RefName() string {
+	return w.Path
+}
+
+func (w *Worktree) ID() string {
+	return w.RefName()
+}
+
+func (w *Worktree) Description() string {
+	return w.RefName()
+}
+
+// HeadDescription returns the branch name, or the short sha if we're detached
+func (w *Worktree) HeadDescription() string {
+	if w.Bare {
+		return "(bare)"
+	}
+
+	if w.Branch != "" {
+		return w.Branch
+	}
+
+	if len(w.Head) > 7 {
+		return w.Head[:7]
+	}
+
+	return w.Head
+}
@@ -26,6 +26,22 @@ type fileInfo struct {
 	diff                string
 }
 
+// PatchState is everything we need to rebuild a patch, so that it can be saved
+// and re-opened later
+type PatchState struct {
+	From      string
+	To        string
+	Reverse   bool
+	CanRebase bool
+	// keyed by filename. Only files which are part of the patch are included
+	Files map[string]*FileState
+}
+
+type FileState struct {
+	Mode                PatchStatus
+	IncludedLineIndices []int
+}
+
 type applyPatchFunc func(patch string, flags ...string) error
 type loadFileDiffFunc func(from string, to string, reverse bool, filename string, plain bool) (string, error)
 
@@ -67,6 +83,53 @@ func (p *PatchManager) Start(from, to string, reverse bool, canRebase bool) {
 	p.fileInfoMap = map[string]*fileInfo{}
 }
 
+// State returns the current state of the patch
+func (p *PatchManager) State() *PatchState {
+	files := map[string]*FileState{}
+	for filename, info := range p.fileInfoMap {
+		if info.mode == UNSELECTED {
+			continue
+		}
+
+		files[filename] = &FileState{
+			Mode:                info.mode,
+			IncludedLineIndices: info.includedLineIndices,
+		}
+	}
+
+	return &PatchState{
+		From:      p.From,
+		To:        p.To,
+		Reverse:   p.reverse,
+		CanRebase: p.CanRebase,
+		Files:     files,
+	}
+}
+
+// Restore starts a new patch from a previously saved state, loading the diff of
+// each of its files again
+func (p *PatchManager) Restore(state *PatchState) error {
+	p.Start(state.From, state.To, state.Reverse, state.CanRebase)
+
+	for filename, fileState := range state.Files {
+		info, err := p.getFileInfo(filename)
+		if err != nil {
+			p.Reset()
+			return err
+		}
+
+		switch fileState.Mode {
+		case WHOLE:
+			p.addFileWhole(info)
+		case PART:
+			info.mode = PART
+			info.includedLineIndices = fileState.IncludedLineIndices
+		}
+	}
+
+	return nil
+}
+
 func (p *PatchManager) addFileWhole(info *fileInfo) {
 	info.mode = WHOLE
 	lineCount := len(strings.Split(info.diff, "\n"))
@@ -0,0 +1,56 @@
+package patch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchManagerStateAndRestore(t *testing.T) {
+	diffs := map[string]string{
+		"filename": simpleDiff,
+		"other":    simpleDiff,
+		"unused":   simpleDiff,
+	}
+	loadFileDiff := func(from string, to string, reverse bool, filename string, plain bool) (string, error) {
+		diff, ok := diffs[filename]
+		if !ok {
+			return "", errors.New("no such file")
+		}
+		return diff, nil
+	}
+
+	original := NewPatchManager(utils.NewDummyLog(), nil, loadFileDiff)
+	original.Start("abc123^", "abc123", false, true)
+	assert.NoError(t, original.AddFileWhole("filename"))
+	assert.NoError(t, original.AddFileLineRange("other", 6, 7))
+	assert.NoError(t, original.AddFileWhole("unused"))
+	assert.NoError(t, original.RemoveFile("unused"))
+
+	state := original.State()
+	assert.EqualValues(t, &PatchState{
+		From:      "abc123^",
+		To:        "abc123",
+		Reverse:   false,
+		CanRebase: true,
+		Files: map[string]*FileState{
+			"filename": {Mode: WHOLE, IncludedLineIndices: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+			"other":    {Mode: PART, IncludedLineIndices: []int{6, 7}},
+		},
+	}, state)
+
+	restored := NewPatchManager(utils.NewDummyLog(), nil, loadFileDiff)
+	assert.NoError(t, restored.Restore(state))
+	assert.True(t, restored.Active())
+	assert.False(t, restored.NewPatchRequired("abc123^", "abc123", false))
+	assert.Equal(t, WHOLE, restored.GetFileStatus("filename", "abc123"))
+	assert.Equal(t, PART, restored.GetFileStatus("other", "abc123"))
+	assert.Equal(t, UNSELECTED, restored.GetFileStatus("unused", "abc123"))
+	assert.Equal(t, original.RenderAggregatedPatchColored(true), restored.RenderAggregatedPatchColored(true))
+
+	state.Files["missing"] = &FileState{Mode: WHOLE}
+	assert.Error(t, restored.Restore(state))
+	assert.False(t, restored.Active())
+}
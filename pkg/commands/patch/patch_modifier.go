@@ -138,6 +138,16 @@ func ModifiedPatchForLines(log *logrus.Entry, filename string, diffText string,
 
 // I want to know, given a hunk, what line a given index is on
 func (hunk *PatchHunk) LineNumberOfLine(idx int) int {
+	return hunk.newStart + nLinesWithPrefix(hunk.bodyLinesBefore(idx), []string{"+", " "})
+}
+
+// OldLineNumberOfLine is like LineNumberOfLine except it gives us the line number
+// in the original version of the file i.e. before the hunk was applied
+func (hunk *PatchHunk) OldLineNumberOfLine(idx int) int {
+	return hunk.oldStart + nLinesWithPrefix(hunk.bodyLinesBefore(idx), []string{"-", " "})
+}
+
+func (hunk *PatchHunk) bodyLinesBefore(idx int) []string {
 	n := idx - hunk.FirstLineIdx - 1
 	if n < 0 {
 		n = 0
@@ -145,11 +155,7 @@ func (hunk *PatchHunk) LineNumberOfLine(idx int) int {
 		n = len(hunk.bodyLines) - 1
 	}
 
-	lines := hunk.bodyLines[0:n]
-
-	offset := nLinesWithPrefix(lines, []string{"+", " "})
-
-	return hunk.newStart + offset
+	return hunk.bodyLines[0:n]
 }
 
 func nLinesWithPrefix(lines []string, chars []string) int {
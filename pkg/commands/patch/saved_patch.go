@@ -0,0 +1,23 @@
+package patch
+
+// SavedPatch : A custom patch saved in the repo's git dir under lazygit/patches
+// so that it can be re-opened or applied later, from any of the repo's worktrees
+type SavedPatch struct {
+	Name          string
+	UnixTimestamp int64
+	// the patch as it was rendered when saved, so that we can still apply it if
+	// the commit it was built from no longer exists
+	Patch string
+	State *PatchState
+	// whether the patch was built from a stash entry. If so, State refers to the
+	// entry by its commit rather than its (ever-changing) index
+	Stash bool
+}
+
+func (p *SavedPatch) ID() string {
+	return p.Name
+}
+
+func (p *SavedPatch) Description() string {
+	return p.Name
+}
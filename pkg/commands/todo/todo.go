@@ -0,0 +1,349 @@
+package todo
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// TodoCommand is the command on a line of a git-rebase-todo file
+type TodoCommand int
+
+const (
+	// the zero value is for commits that aren't part of a rebase
+	NoCommand TodoCommand = iota
+
+	Pick
+	Reword
+	Edit
+	Squash
+	Fixup
+	Exec
+	Break
+	Label
+	Reset
+	Merge
+	UpdateRef
+	Drop
+	Noop
+
+	Comment
+)
+
+var commandNames = map[TodoCommand]string{
+	Pick:      "pick",
+	Reword:    "reword",
+	Edit:      "edit",
+	Squash:    "squash",
+	Fixup:     "fixup",
+	Exec:      "exec",
+	Break:     "break",
+	Label:     "label",
+	Reset:     "reset",
+	Merge:     "merge",
+	UpdateRef: "update-ref",
+	Drop:      "drop",
+	Noop:      "noop",
+}
+
+// git writes these when rebase.abbreviateCommands is set
+var commandAbbreviations = map[string]TodoCommand{
+	"p": Pick,
+	"r": Reword,
+	"e": Edit,
+	"s": Squash,
+	"f": Fixup,
+	"x": Exec,
+	"b": Break,
+	"l": Label,
+	"t": Reset,
+	"m": Merge,
+	"u": UpdateRef,
+	"d": Drop,
+}
+
+func (self TodoCommand) String() string {
+	return commandNames[self]
+}
+
+// IsCommit tells us whether the command acts on a commit that the user can
+// change the command of (e.g. from a pick to a drop)
+func (self TodoCommand) IsCommit() bool {
+	switch self {
+	case Pick, Reword, Edit, Squash, Fixup, Drop:
+		return true
+	default:
+		return false
+	}
+}
+
+// Todo is a single line of a git-rebase-todo file
+type Todo struct {
+	Command TodoCommand
+	// the commit that the command acts on, if any. For a merge this is the
+	// commit whose message we reuse
+	Commit string
+	// e.g. '-C' for a merge, or '-c' for a fixup
+	Flag string
+	// the command to run, for an exec
+	ExecCommand string
+	// the label for a label, reset or merge, or the ref for an update-ref
+	Label string
+	// the commit subject, or the text after the '#' for label/reset/merge lines
+	Msg string
+	// the text of a comment line, not including the leading '#'
+	Comment string
+}
+
+func commandFromString(str string) (TodoCommand, bool) {
+	for command, name := range commandNames {
+		if name == str {
+			return command, true
+		}
+	}
+
+	command, ok := commandAbbreviations[str]
+	return command, ok
+}
+
+// Parse parses the content of a git-rebase-todo file
+func Parse(content string) ([]Todo, error) {
+	todos := []Todo{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		todo, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		todos = append(todos, todo)
+	}
+
+	return todos, scanner.Err()
+}
+
+func parseLine(line string) (Todo, error) {
+	if strings.HasPrefix(line, "#") {
+		return Todo{Command: Comment, Comment: strings.TrimPrefix(line, "#")}, nil
+	}
+
+	fields := strings.SplitN(line, " ", 2)
+	command, ok := commandFromString(fields[0])
+	if !ok {
+		return Todo{}, errors.New(fmt.Sprintf("unknown rebase todo command in line '%s'", line))
+	}
+
+	rest := ""
+	if len(fields) > 1 {
+		rest = strings.TrimSpace(fields[1])
+	}
+
+	todo := Todo{Command: command}
+
+	switch command {
+	case Break, Noop:
+	case Exec:
+		todo.ExecCommand = rest
+	case Label, Reset, UpdateRef:
+		todo.Label, todo.Msg = splitMsg(rest)
+	case Merge:
+		if strings.HasPrefix(rest, "-C ") || strings.HasPrefix(rest, "-c ") {
+			parts := strings.SplitN(rest, " ", 3)
+			if len(parts) < 3 {
+				return Todo{}, errors.New(fmt.Sprintf("malformed merge line '%s'", line))
+			}
+			todo.Flag = parts[0]
+			todo.Commit = parts[1]
+			rest = parts[2]
+		}
+		todo.Label, todo.Msg = splitMsg(rest)
+	default:
+		if command == Fixup && (strings.HasPrefix(rest, "-C ") || strings.HasPrefix(rest, "-c ")) {
+			todo.Flag = rest[:2]
+			rest = strings.TrimSpace(rest[3:])
+		}
+		parts := strings.SplitN(rest, " ", 2)
+		if parts[0] == "" {
+			return Todo{}, errors.New(fmt.Sprintf("missing commit in line '%s'", line))
+		}
+		todo.Commit = parts[0]
+		if len(parts) > 1 {
+			todo.Msg = parts[1]
+		}
+	}
+
+	return todo, nil
+}
+
+// splitMsg splits something like 'onto # some message' into 'onto' and
+// 'some message'
+func splitMsg(str string) (string, string) {
+	parts := strings.SplitN(str, "#", 2)
+	if len(parts) == 1 {
+		return strings.TrimSpace(str), ""
+	}
+
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+// String returns the todo as a line of a git-rebase-todo file
+func (self Todo) String() string {
+	switch self.Command {
+	case Comment:
+		return "#" + self.Comment
+	case Break, Noop:
+		return self.Command.String()
+	case Exec:
+		return joinNonEmpty(self.Command.String(), self.ExecCommand)
+	case Label, Reset, UpdateRef:
+		return withMsg(joinNonEmpty(self.Command.String(), self.Label), self.Msg)
+	case Merge:
+		commit := ""
+		if self.Commit != "" {
+			commit = joinNonEmpty(self.Flag, self.Commit)
+		}
+		return withMsg(joinNonEmpty(self.Command.String(), commit, self.Label), self.Msg)
+	default:
+		return joinNonEmpty(self.Command.String(), self.Flag, self.Commit, self.Msg)
+	}
+}
+
+func joinNonEmpty(strs ...string) string {
+	nonEmpty := make([]string, 0, len(strs))
+	for _, str := range strs {
+		if str != "" {
+			nonEmpty = append(nonEmpty, str)
+		}
+	}
+
+	return strings.Join(nonEmpty, " ")
+}
+
+func withMsg(str string, msg string) string {
+	if msg == "" {
+		return str
+	}
+
+	return str + " # " + msg
+}
+
+// Write returns the content of a git-rebase-todo file for the given todos
+func Write(todos []Todo) string {
+	content := ""
+	for _, todo := range todos {
+		content += todo.String() + "\n"
+	}
+
+	return content
+}
+
+// ApplyChanges is for when git has generated the todo itself (e.g. when passing
+// --rebase-merges) and we want to make some changes to it. The changes are
+// given as todo content whose lines set the command of the todo for the given
+// commit. If swapShas is not empty, its first sha is for a commit which will be
+// swapped with the adjacent block of commits given by the remaining shas.
+func ApplyChanges(content string, changesContent string, swapShas []string) (string, error) {
+	todos, err := Parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	changes, err := Parse(changesContent)
+	if err != nil {
+		return "", err
+	}
+
+	for _, change := range changes {
+		idx, ok := findCommit(todos, change.Commit)
+		if !ok {
+			return "", errors.New(fmt.Sprintf("commit %s not found in rebase todo", change.Commit))
+		}
+
+		todos[idx].Command = change.Command
+		if change.Command != Fixup {
+			todos[idx].Flag = ""
+		}
+	}
+
+	if len(swapShas) > 0 {
+		todos, err = swapWithBlock(todos, swapShas[0], swapShas[1:])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return Write(todos), nil
+}
+
+// findCommit returns the index of the todo acting on the given commit. Git
+// abbreviates shas in the todo so we match on either being a prefix of the
+// other
+func findCommit(todos []Todo, sha string) (int, bool) {
+	for i, todo := range todos {
+		if !todo.Command.IsCommit() || todo.Commit == "" {
+			continue
+		}
+
+		if strings.HasPrefix(sha, todo.Commit) || strings.HasPrefix(todo.Commit, sha) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// swapWithBlock moves the todo for the given commit to the other side of the
+// block of todos for the given block shas. The block must be contiguous and the
+// commit must be directly before or after it, otherwise we would be moving a
+// commit across a label, reset or merge line.
+func swapWithBlock(todos []Todo, sha string, blockShas []string) ([]Todo, error) {
+	idx, ok := findCommit(todos, sha)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("commit %s not found in rebase todo", sha))
+	}
+
+	blockStart := len(todos)
+	blockEnd := -1
+	for _, blockSha := range blockShas {
+		blockIdx, ok := findCommit(todos, blockSha)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("commit %s not found in rebase todo", blockSha))
+		}
+		if blockIdx < blockStart {
+			blockStart = blockIdx
+		}
+		if blockIdx > blockEnd {
+			blockEnd = blockIdx
+		}
+	}
+
+	if blockEnd-blockStart+1 != len(blockShas) || (idx != blockStart-1 && idx != blockEnd+1) {
+		return nil, errors.New("commits can only be moved past adjacent commits on the same branch")
+	}
+
+	moved := todos[idx]
+	block := append([]Todo{}, todos[blockStart:blockEnd+1]...)
+
+	result := make([]Todo, 0, len(todos))
+	if idx < blockStart {
+		result = append(result, todos[:idx]...)
+		result = append(result, block...)
+		result = append(result, moved)
+		result = append(result, todos[blockEnd+1:]...)
+	} else {
+		result = append(result, todos[:blockStart]...)
+		result = append(result, moved)
+		result = append(result, block...)
+		result = append(result, todos[idx+1:]...)
+	}
+
+	return result, nil
+}
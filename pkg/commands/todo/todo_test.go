@@ -0,0 +1,183 @@
+package todo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	type scenario struct {
+		testName      string
+		content       string
+		expectedTodos []Todo
+		expectedErr   bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "commits and comments",
+			content:  "pick 1234 first commit\n\n# Rebase 5678..1234 onto 5678\n",
+			expectedTodos: []Todo{
+				{Command: Pick, Commit: "1234", Msg: "first commit"},
+				{Command: Comment, Comment: " Rebase 5678..1234 onto 5678"},
+			},
+		},
+		{
+			testName: "abbreviated commands",
+			content:  "p 1234 first commit\nf -C 5678 second commit\nx make test\nb\n",
+			expectedTodos: []Todo{
+				{Command: Pick, Commit: "1234", Msg: "first commit"},
+				{Command: Fixup, Flag: "-C", Commit: "5678", Msg: "second commit"},
+				{Command: Exec, ExecCommand: "make test"},
+				{Command: Break},
+			},
+		},
+		{
+			testName: "rebase-merges todo",
+			content: "label onto\n" +
+				"reset [new root]\n" +
+				"pick 1234 first commit\n" +
+				"label feature\n" +
+				"reset onto\n" +
+				"merge -C 5678 feature # Merge branch 'feature'\n" +
+				"merge other\n" +
+				"update-ref refs/heads/feature\n",
+			expectedTodos: []Todo{
+				{Command: Label, Label: "onto"},
+				{Command: Reset, Label: "[new root]"},
+				{Command: Pick, Commit: "1234", Msg: "first commit"},
+				{Command: Label, Label: "feature"},
+				{Command: Reset, Label: "onto"},
+				{Command: Merge, Flag: "-C", Commit: "5678", Label: "feature", Msg: "Merge branch 'feature'"},
+				{Command: Merge, Label: "other"},
+				{Command: UpdateRef, Label: "refs/heads/feature"},
+			},
+		},
+		{
+			testName:    "unknown command",
+			content:     "frobnicate 1234\n",
+			expectedErr: true,
+		},
+		{
+			testName:    "missing commit",
+			content:     "pick\n",
+			expectedErr: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			todos, err := Parse(s.content)
+			if s.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedTodos, todos)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	content := "label onto\n" +
+		"pick 1234 first commit\n" +
+		"fixup -C 5678 second commit\n" +
+		"exec make test\n" +
+		"break\n" +
+		"reset onto\n" +
+		"merge -C 9abc feature # Merge branch 'feature'\n" +
+		"# a comment\n"
+
+	todos, err := Parse(content)
+	assert.NoError(t, err)
+	assert.Equal(t, content, Write(todos))
+}
+
+func TestApplyChanges(t *testing.T) {
+	content := "label onto\n" +
+		"reset onto\n" +
+		"pick 1111 first\n" +
+		"label feature\n" +
+		"reset onto\n" +
+		"pick 2222 second\n" +
+		"pick 3333 third\n" +
+		"pick 4444 fourth\n" +
+		"merge -C 5555 feature # Merge branch 'feature'\n"
+
+	type scenario struct {
+		testName        string
+		changes         string
+		swapShas        []string
+		expectedContent string
+		expectedErr     bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "change commands",
+			changes:  "drop 2222aaaa\nsquash 33\n",
+			expectedContent: "label onto\n" +
+				"reset onto\n" +
+				"pick 1111 first\n" +
+				"label feature\n" +
+				"reset onto\n" +
+				"drop 2222 second\n" +
+				"squash 3333 third\n" +
+				"pick 4444 fourth\n" +
+				"merge -C 5555 feature # Merge branch 'feature'\n",
+		},
+		{
+			testName: "move a block of commits earlier",
+			swapShas: []string{"2222", "4444", "3333"},
+			expectedContent: "label onto\n" +
+				"reset onto\n" +
+				"pick 1111 first\n" +
+				"label feature\n" +
+				"reset onto\n" +
+				"pick 3333 third\n" +
+				"pick 4444 fourth\n" +
+				"pick 2222 second\n" +
+				"merge -C 5555 feature # Merge branch 'feature'\n",
+		},
+		{
+			testName: "move a commit earlier",
+			swapShas: []string{"3333", "2222"},
+			expectedContent: "label onto\n" +
+				"reset onto\n" +
+				"pick 1111 first\n" +
+				"label feature\n" +
+				"reset onto\n" +
+				"pick 3333 third\n" +
+				"pick 2222 second\n" +
+				"pick 4444 fourth\n" +
+				"merge -C 5555 feature # Merge branch 'feature'\n",
+		},
+		{
+			testName:    "cannot move a commit across a label",
+			swapShas:    []string{"1111", "2222"},
+			expectedErr: true,
+		},
+		{
+			testName:    "unknown commit",
+			changes:     "drop 9999\n",
+			expectedErr: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.testName, func(t *testing.T) {
+			result, err := ApplyChanges(content, s.changes, s.swapShas)
+			if s.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedContent, result)
+		})
+	}
+}
@@ -297,6 +297,24 @@ type AppState struct {
 	// these are for custom commands typed in directly, not for custom commands in the lazygit config
 	CustomCommandsHistory []string
 	HideCommandLog        bool
+
+	// one of 'date' | 'version' | 'name'
+	TagSortOrder string
+
+	// keyed by repo path
+	BranchListStates map[string]BranchListState
+}
+
+// BranchListState stores how a repo's branches panel is sorted and grouped, and
+// which branch the other branches are compared against
+type BranchListState struct {
+	// one of 'recency' | 'alphabetical' | 'date' | 'divergence'
+	SortOrder string
+	// whether to show branches like 'feature/foo' in a 'feature' folder
+	GroupByPrefix bool
+	// e.g. 'origin/main'. Overridden for a given branch by its 'branch.<name>.base'
+	// git config
+	BaseBranch string
 }
 
 func getDefaultAppState() *AppState {
@@ -15,12 +15,16 @@ type UserConfig struct {
 	DisableStartupPopups bool              `yaml:"disableStartupPopups"`
 	CustomCommands       []CustomCommand   `yaml:"customCommands"`
 	Services             map[string]string `yaml:"services"`
+	Forge                ForgeConfig       `yaml:"forge"`
 	NotARepository       string            `yaml:"notARepository"`
 }
 
 type RefresherConfig struct {
 	RefreshInterval int `yaml:"refreshInterval"`
 	FetchInterval   int `yaml:"fetchInterval"`
+	// if true, we watch the repo for changes and only fall back to polling
+	// every RefreshInterval seconds if the watcher can't be set up
+	WatchFiles bool `yaml:"watchFiles"`
 }
 
 type GuiConfig struct {
@@ -74,6 +78,15 @@ type GitConfig struct {
 	ParseEmoji      bool      `yaml:"parseEmoji"`
 	Log             LogConfig `yaml:"log"`
 	DiffContextSize int       `yaml:"diffContextSize"`
+	// one of 'never', 'always' or 'prompt'. Determines whether interactive
+	// rebases (e.g. squashing or moving commits) pass --rebase-merges so that
+	// merge commits are preserved rather than dropped
+	RebaseMerges string `yaml:"rebaseMerges"`
+	// the branch that other branches get merged into e.g. 'main'. If empty we
+	// use whichever of 'main' or 'master' exists
+	MainBranch string `yaml:"mainBranch"`
+	// branches without a commit in this many days are suggested for cleanup
+	StaleBranchDays int `yaml:"staleBranchDays"`
 }
 
 type PagingConfig struct {
@@ -83,7 +96,20 @@ type PagingConfig struct {
 }
 
 type CommitConfig struct {
-	SignOff bool `yaml:"signOff"`
+	SignOff bool             `yaml:"signOff"`
+	Lint    CommitLintConfig `yaml:"lint"`
+}
+
+// CommitLintConfig determines which rules commit messages are checked against.
+// A zero value disables the corresponding rule
+type CommitLintConfig struct {
+	MaxSubjectLength    int  `yaml:"maxSubjectLength"`
+	BlankSecondLine     bool `yaml:"blankSecondLine"`
+	MaxBodyLineLength   int  `yaml:"maxBodyLineLength"`
+	ConventionalCommits bool `yaml:"conventionalCommits"`
+	// if true, we refuse to commit (or reword a commit) when a rule is broken,
+	// rather than just showing a warning
+	BlockOnError bool `yaml:"blockOnError"`
 }
 
 type MergingConfig struct {
@@ -107,15 +133,18 @@ type UpdateConfig struct {
 }
 
 type KeybindingConfig struct {
-	Universal   KeybindingUniversalConfig   `yaml:"universal"`
-	Status      KeybindingStatusConfig      `yaml:"status"`
-	Files       KeybindingFilesConfig       `yaml:"files"`
-	Branches    KeybindingBranchesConfig    `yaml:"branches"`
-	Commits     KeybindingCommitsConfig     `yaml:"commits"`
-	Stash       KeybindingStashConfig       `yaml:"stash"`
-	CommitFiles KeybindingCommitFilesConfig `yaml:"commitFiles"`
-	Main        KeybindingMainConfig        `yaml:"main"`
-	Submodules  KeybindingSubmodulesConfig  `yaml:"submodules"`
+	Universal     KeybindingUniversalConfig     `yaml:"universal"`
+	Status        KeybindingStatusConfig        `yaml:"status"`
+	Files         KeybindingFilesConfig         `yaml:"files"`
+	Branches      KeybindingBranchesConfig      `yaml:"branches"`
+	Commits       KeybindingCommitsConfig       `yaml:"commits"`
+	CommitMessage KeybindingCommitMessageConfig `yaml:"commitMessage"`
+	Stash         KeybindingStashConfig         `yaml:"stash"`
+	CommitFiles   KeybindingCommitFilesConfig   `yaml:"commitFiles"`
+	Main          KeybindingMainConfig          `yaml:"main"`
+	Submodules    KeybindingSubmodulesConfig    `yaml:"submodules"`
+	Worktrees     KeybindingWorktreesConfig     `yaml:"worktrees"`
+	Blame         KeybindingBlameConfig         `yaml:"blame"`
 }
 
 // damn looks like we have some inconsistencies here with -alt and -alt1
@@ -145,6 +174,7 @@ type KeybindingUniversalConfig struct {
 	NextMatch                    string   `yaml:"nextMatch"`
 	PrevMatch                    string   `yaml:"prevMatch"`
 	StartSearch                  string   `yaml:"startSearch"`
+	StartFilter                  string   `yaml:"startFilter"`
 	OptionMenu                   string   `yaml:"optionMenu"`
 	OptionMenuAlt1               string   `yaml:"optionMenu-alt1"`
 	Select                       string   `yaml:"select"`
@@ -184,6 +214,7 @@ type KeybindingUniversalConfig struct {
 	ToggleWhitespaceInDiffView   string   `yaml:"toggleWhitespaceInDiffView"`
 	IncreaseContextInDiffView    string   `yaml:"increaseContextInDiffView"`
 	DecreaseContextInDiffView    string   `yaml:"decreaseContextInDiffView"`
+	ToggleRangeSelect            string   `yaml:"toggleRangeSelect"`
 }
 
 type KeybindingStatusConfig struct {
@@ -207,6 +238,8 @@ type KeybindingFilesConfig struct {
 	ToggleTreeView           string `yaml:"toggleTreeView"`
 	OpenMergeTool            string `yaml:"openMergeTool"`
 	OpenStatusFilter         string `yaml:"openStatusFilter"`
+	ViewBlame                string `yaml:"viewBlame"`
+	ViewRerereOptions        string `yaml:"viewRerereOptions"`
 }
 
 type KeybindingBranchesConfig struct {
@@ -214,6 +247,7 @@ type KeybindingBranchesConfig struct {
 	ViewPullRequestOptions string `yaml:"viewPullRequestOptions"`
 	CopyPullRequestURL     string `yaml:"copyPullRequestURL"`
 	CheckoutBranchByName   string `yaml:"checkoutBranchByName"`
+	CheckoutPullRequest    string `yaml:"checkoutPullRequest"`
 	ForceCheckoutBranch    string `yaml:"forceCheckoutBranch"`
 	RebaseBranch           string `yaml:"rebaseBranch"`
 	RenameBranch           string `yaml:"renameBranch"`
@@ -223,6 +257,11 @@ type KeybindingBranchesConfig struct {
 	PushTag                string `yaml:"pushTag"`
 	SetUpstream            string `yaml:"setUpstream"`
 	FetchRemote            string `yaml:"fetchRemote"`
+	SortOrder              string `yaml:"sortOrder"`
+	DeleteRemoteTag        string `yaml:"deleteRemoteTag"`
+	CleanupBranches        string `yaml:"cleanupBranches"`
+	SetBaseBranch          string `yaml:"setBaseBranch"`
+	RebaseOntoBaseBranch   string `yaml:"rebaseOntoBaseBranch"`
 }
 
 type KeybindingCommitsConfig struct {
@@ -237,6 +276,8 @@ type KeybindingCommitsConfig struct {
 	MoveUpCommit                 string `yaml:"moveUpCommit"`
 	AmendToCommit                string `yaml:"amendToCommit"`
 	PickCommit                   string `yaml:"pickCommit"`
+	InsertExecTodo               string `yaml:"insertExecTodo"`
+	InsertBreakTodo              string `yaml:"insertBreakTodo"`
 	RevertCommit                 string `yaml:"revertCommit"`
 	CherryPickCopy               string `yaml:"cherryPickCopy"`
 	CherryPickCopyRange          string `yaml:"cherryPickCopyRange"`
@@ -246,23 +287,34 @@ type KeybindingCommitsConfig struct {
 	ResetCherryPick              string `yaml:"resetCherryPick"`
 	CopyCommitMessageToClipboard string `yaml:"copyCommitMessageToClipboard"`
 	OpenLogMenu                  string `yaml:"openLogMenu"`
+	SearchHistory                string `yaml:"searchHistory"`
 	OpenInBrowser                string `yaml:"openInBrowser"`
 	ViewBisectOptions            string `yaml:"viewBisectOptions"`
 }
 
+type KeybindingCommitMessageConfig struct {
+	AddTrailer         string `yaml:"addTrailer"`
+	ConventionalCommit string `yaml:"conventionalCommit"`
+}
+
 type KeybindingStashConfig struct {
-	PopStash string `yaml:"popStash"`
+	PopStash              string `yaml:"popStash"`
+	RenameStash           string `yaml:"renameStash"`
+	CreateBranchFromStash string `yaml:"createBranchFromStash"`
 }
 
 type KeybindingCommitFilesConfig struct {
 	CheckoutCommitFile string `yaml:"checkoutCommitFile"`
+	ViewBlame          string `yaml:"viewBlame"`
 }
 
 type KeybindingMainConfig struct {
-	ToggleDragSelect    string `yaml:"toggleDragSelect"`
-	ToggleDragSelectAlt string `yaml:"toggleDragSelect-alt"`
-	ToggleSelectHunk    string `yaml:"toggleSelectHunk"`
-	PickBothHunks       string `yaml:"pickBothHunks"`
+	ToggleDragSelect        string `yaml:"toggleDragSelect"`
+	ToggleDragSelectAlt     string `yaml:"toggleDragSelect-alt"`
+	ToggleSelectHunk        string `yaml:"toggleSelectHunk"`
+	PickBothHunks           string `yaml:"pickBothHunks"`
+	ToggleThreeWayMergeView string `yaml:"toggleThreeWayMergeView"`
+	EditConflict            string `yaml:"editConflict"`
 }
 
 type KeybindingSubmodulesConfig struct {
@@ -271,6 +323,31 @@ type KeybindingSubmodulesConfig struct {
 	BulkMenu string `yaml:"bulkMenu"`
 }
 
+type KeybindingWorktreesConfig struct {
+	Prune      string `yaml:"prune"`
+	ToggleLock string `yaml:"toggleLock"`
+}
+
+type KeybindingBlameConfig struct {
+	BlameParent string `yaml:"blameParent"`
+}
+
+type ForgeConfig struct {
+	// show the number and CI status of each branch's open pull request in the
+	// branches panel. Only supported for GitHub and GitLab, and only done when
+	// an API token is available. Off by default because it hits the API in the
+	// background
+	ShowPullRequests bool `yaml:"showPullRequests"`
+	// if empty, the GITHUB_TOKEN and GITLAB_TOKEN env vars are used
+	GithubToken string `yaml:"githubToken"`
+	GitlabToken string `yaml:"gitlabToken"`
+	// overrides the API base URL derived from the remote e.g. for self-hosted
+	// instances with a non-standard API path
+	ApiBaseURL string `yaml:"apiBaseUrl"`
+	// how long in seconds to cache pull requests before fetching them again
+	CacheDuration int `yaml:"cacheDuration"`
+}
+
 // OSConfig contains config on the level of the os
 type OSConfig struct {
 	// EditCommand is the command for editing a file
@@ -357,6 +434,13 @@ func GetDefaultConfig() *UserConfig {
 				UseConfig: false},
 			Commit: CommitConfig{
 				SignOff: false,
+				Lint: CommitLintConfig{
+					MaxSubjectLength:    0,
+					BlankSecondLine:     false,
+					MaxBodyLineLength:   0,
+					ConventionalCommits: false,
+					BlockOnError:        false,
+				},
 			},
 			Merging: MergingConfig{
 				ManualCommit: false,
@@ -374,10 +458,14 @@ func GetDefaultConfig() *UserConfig {
 			CommitPrefixes:      map[string]CommitPrefixConfig(nil),
 			ParseEmoji:          false,
 			DiffContextSize:     3,
+			RebaseMerges:        "never",
+			MainBranch:          "",
+			StaleBranchDays:     90,
 		},
 		Refresher: RefresherConfig{
 			RefreshInterval: 10,
 			FetchInterval:   60,
+			WatchFiles:      true,
 		},
 		Update: UpdateConfig{
 			Method: "prompt",
@@ -414,6 +502,7 @@ func GetDefaultConfig() *UserConfig {
 				NextMatch:                    "n",
 				PrevMatch:                    "N",
 				StartSearch:                  "/",
+				StartFilter:                  "\\",
 				OptionMenu:                   "x",
 				OptionMenuAlt1:               "?",
 				Select:                       "<space>",
@@ -453,6 +542,7 @@ func GetDefaultConfig() *UserConfig {
 				ToggleWhitespaceInDiffView:   "<c-w>",
 				IncreaseContextInDiffView:    "}",
 				DecreaseContextInDiffView:    "{",
+				ToggleRangeSelect:            "V",
 			},
 			Status: KeybindingStatusConfig{
 				CheckForUpdate:      "u",
@@ -474,12 +564,15 @@ func GetDefaultConfig() *UserConfig {
 				ToggleTreeView:           "`",
 				OpenMergeTool:            "M",
 				OpenStatusFilter:         "<c-b>",
+				ViewBlame:                "B",
+				ViewRerereOptions:        "X",
 			},
 			Branches: KeybindingBranchesConfig{
 				CopyPullRequestURL:     "<c-y>",
 				CreatePullRequest:      "o",
 				ViewPullRequestOptions: "O",
 				CheckoutBranchByName:   "c",
+				CheckoutPullRequest:    "G",
 				ForceCheckoutBranch:    "F",
 				RebaseBranch:           "r",
 				RenameBranch:           "R",
@@ -489,6 +582,11 @@ func GetDefaultConfig() *UserConfig {
 				PushTag:                "P",
 				SetUpstream:            "u",
 				FetchRemote:            "f",
+				SortOrder:              "s",
+				DeleteRemoteTag:        "D",
+				CleanupBranches:        "C",
+				SetBaseBranch:          "B",
+				RebaseOntoBaseBranch:   "b",
 			},
 			Commits: KeybindingCommitsConfig{
 				SquashDown:                   "s",
@@ -502,6 +600,8 @@ func GetDefaultConfig() *UserConfig {
 				MoveUpCommit:                 "<c-k>",
 				AmendToCommit:                "A",
 				PickCommit:                   "p",
+				InsertExecTodo:               "X",
+				InsertBreakTodo:              "B",
 				RevertCommit:                 "t",
 				CherryPickCopy:               "c",
 				CherryPickCopyRange:          "C",
@@ -511,31 +611,52 @@ func GetDefaultConfig() *UserConfig {
 				ResetCherryPick:              "<c-R>",
 				CopyCommitMessageToClipboard: "<c-y>",
 				OpenLogMenu:                  "<c-l>",
+				SearchHistory:                "<c-f>",
 				OpenInBrowser:                "o",
 				ViewBisectOptions:            "b",
 			},
+			CommitMessage: KeybindingCommitMessageConfig{
+				AddTrailer:         "<c-t>",
+				ConventionalCommit: "<c-g>",
+			},
 			Stash: KeybindingStashConfig{
-				PopStash: "g",
+				PopStash:              "g",
+				RenameStash:           "r",
+				CreateBranchFromStash: "b",
 			},
 			CommitFiles: KeybindingCommitFilesConfig{
 				CheckoutCommitFile: "c",
+				ViewBlame:          "B",
 			},
 			Main: KeybindingMainConfig{
-				ToggleDragSelect:    "v",
-				ToggleDragSelectAlt: "V",
-				ToggleSelectHunk:    "a",
-				PickBothHunks:       "b",
+				ToggleDragSelect:        "v",
+				ToggleDragSelectAlt:     "V",
+				ToggleSelectHunk:        "a",
+				PickBothHunks:           "b",
+				ToggleThreeWayMergeView: "t",
+				EditConflict:            "e",
 			},
 			Submodules: KeybindingSubmodulesConfig{
 				Init:     "i",
 				Update:   "u",
 				BulkMenu: "b",
 			},
+			Worktrees: KeybindingWorktreesConfig{
+				Prune:      "p",
+				ToggleLock: "t",
+			},
+			Blame: KeybindingBlameConfig{
+				BlameParent: "b",
+			},
 		},
 		OS:                   GetPlatformDefaultConfig(),
 		DisableStartupPopups: false,
 		CustomCommands:       []CustomCommand(nil),
 		Services:             map[string]string(nil),
-		NotARepository:       "prompt",
+		Forge: ForgeConfig{
+			ShowPullRequests: false,
+			CacheDuration:    60,
+		},
+		NotARepository: "prompt",
 	}
 }
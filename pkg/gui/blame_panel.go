@@ -0,0 +1,166 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// list panel functions
+
+func (gui *Gui) getSelectedBlameLine() *models.BlameLine {
+	selectedLine := gui.State.Panels.Blame.SelectedLineIdx
+	if selectedLine == -1 || selectedLine > len(gui.State.BlameLines)-1 {
+		return nil
+	}
+
+	return gui.State.BlameLines[selectedLine]
+}
+
+func (gui *Gui) onBlameFocusLost() error {
+	gui.Views.Main.Footer = ""
+	_ = gui.Views.Main.SetOriginX(0)
+
+	return nil
+}
+
+func (gui *Gui) renderBlame() error {
+	title := "Blame: " + gui.State.Panels.Blame.filename
+	if ref := gui.State.Panels.Blame.ref; ref != "" {
+		// refs are usually full shas so we shorten them, but they can also be
+		// branch names or stash refs which we show as-is
+		if len(ref) == 40 {
+			ref = utils.ShortSha(ref)
+		}
+		title += " @ " + ref
+	}
+
+	content := utils.RenderDisplayStrings(presentation.GetBlameDisplayStrings(gui.State.BlameLines))
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title:     title,
+			noWrap:    true,
+			highlight: true,
+			task:      NewRenderStringTask(content),
+		},
+	})
+}
+
+// blame-specific handlers
+
+func (gui *Gui) handleBlameFile() error {
+	node := gui.getSelectedFileNode()
+	if node == nil || node.File == nil {
+		return nil
+	}
+
+	return gui.openBlame(node.GetPath(), "", 0)
+}
+
+func (gui *Gui) handleBlameCommitFile() error {
+	node := gui.getSelectedCommitFileNode()
+	if node == nil || node.File == nil {
+		return nil
+	}
+
+	return gui.openBlame(node.GetPath(), gui.State.Panels.CommitFiles.refName, 0)
+}
+
+func (gui *Gui) openBlame(filename string, ref string, selectedLineIdx int) error {
+	blameLines, err := gui.Git.Loaders.Blame.GetBlame(filename, ref)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.BlameLines = blameLines
+	gui.State.Panels.Blame.filename = filename
+	gui.State.Panels.Blame.ref = ref
+	gui.State.Panels.Blame.SelectedLineIdx = selectedLineIdx
+	gui.refreshSelectedLine(gui.State.Panels.Blame, len(blameLines))
+
+	if err := gui.pushContext(gui.State.Contexts.Blame); err != nil {
+		return err
+	}
+
+	return gui.renderBlame()
+}
+
+func (gui *Gui) handleBlameEscape() error {
+	return gui.returnFromContext()
+}
+
+func (gui *Gui) handleGoToBlamedCommit() error {
+	blameLine := gui.getSelectedBlameLine()
+	if blameLine == nil {
+		return nil
+	}
+
+	if !blameLine.IsCommitted() {
+		return gui.createErrorPanel(gui.Tr.BlameLineNotCommitted)
+	}
+
+	return gui.goToCommit(blameLine.Sha)
+}
+
+// goToCommit selects the given commit in the commits panel, or, if it's not
+// reachable from HEAD, shows it in the sub-commits panel
+func (gui *Gui) goToCommit(sha string) error {
+	findCommit := func() int {
+		for i, commit := range gui.State.Commits {
+			if commit.Sha == sha {
+				return i
+			}
+		}
+		return -1
+	}
+
+	idx := findCommit()
+	if idx == -1 && gui.State.Panels.Commits.LimitCommits && gui.Git.Commit.IsAncestorOfHead(sha) {
+		// we lazyload commits so the one we want might just not be loaded yet, in
+		// which case we page them in until we reach it rather than loading the
+		// whole history at once
+		for idx == -1 && !gui.State.Panels.Commits.AllCommitsLoaded {
+			if err := gui.loadMoreCommits(); err != nil {
+				return err
+			}
+			idx = findCommit()
+		}
+	}
+
+	if idx == -1 {
+		return gui.switchToSubCommitsContext(sha)
+	}
+
+	gui.State.Panels.Commits.SelectedLineIdx = idx
+	return gui.pushContext(gui.State.Contexts.BranchCommits)
+}
+
+func (gui *Gui) handleBlameParent() error {
+	blameLine := gui.getSelectedBlameLine()
+	if blameLine == nil {
+		return nil
+	}
+
+	if !blameLine.IsCommitted() {
+		return gui.createErrorPanel(gui.Tr.BlameLineNotCommitted)
+	}
+
+	if blameLine.PreviousSha == "" {
+		return gui.createErrorPanel(
+			utils.ResolvePlaceholderString(
+				gui.Tr.BlameNoParent,
+				map[string]string{"sha": blameLine.ShortSha()},
+			),
+		)
+	}
+
+	filename := blameLine.PreviousFilename
+	if filename == "" {
+		filename = gui.State.Panels.Blame.filename
+	}
+
+	// the parent's version of the file will be different, but staying roughly
+	// where we were is better than jumping back to the top
+	return gui.openBlame(filename, blameLine.PreviousSha, gui.State.Panels.Blame.SelectedLineIdx)
+}
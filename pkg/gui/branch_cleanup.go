@@ -0,0 +1,266 @@
+package gui
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// a local branch that we suggest deleting, along with why
+type branchCleanupCandidate struct {
+	branch *models.Branch
+	// fully merged into the main branch
+	merged bool
+	// its upstream has been deleted
+	gone bool
+	// no commits in a while
+	stale bool
+	// whether the user has ticked it for deletion
+	selected bool
+}
+
+// getBranchCleanupCandidates returns the branches which are merged into the main
+// branch, have lost their upstream, or haven't been committed to since
+// staleBefore. Only merged branches start off selected given they're the only
+// ones that are safe to delete: a branch whose upstream has gone, or which is
+// stale, may still have commits on it that aren't anywhere else
+func getBranchCleanupCandidates(branches []*models.Branch, mergedBranchNames []string, mainBranch string, staleBefore int64) []*branchCleanupCandidate {
+	merged := map[string]bool{}
+	for _, name := range mergedBranchNames {
+		merged[name] = true
+	}
+
+	candidates := []*branchCleanupCandidate{}
+	for _, branch := range branches {
+		if branch.Head || branch.Name == mainBranch {
+			continue
+		}
+
+		candidate := &branchCleanupCandidate{
+			branch: branch,
+			merged: merged[branch.Name],
+			gone:   branch.UpstreamGone,
+			stale:  branch.CommitterDate != 0 && branch.CommitterDate < staleBefore,
+		}
+		if !candidate.merged && !candidate.gone && !candidate.stale {
+			continue
+		}
+
+		candidate.selected = candidate.merged
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
+// getMainBranch returns the configured main branch, falling back to 'main' or
+// 'master' if we have one of those locally
+// canDeleteUpstream tells us whether we can delete a branch's upstream without
+// losing any commits, i.e. whether we know it has nothing to pull
+func canDeleteUpstream(branch *models.Branch) bool {
+	return branch.Pullables == "0"
+}
+
+func (gui *Gui) getMainBranch() string {
+	if gui.UserConfig.Git.MainBranch != "" {
+		return gui.UserConfig.Git.MainBranch
+	}
+
+	for _, name := range []string{"main", "master"} {
+		for _, branch := range gui.State.Branches {
+			if branch.Name == name {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
+
+func (gui *Gui) handleCleanupBranches() error {
+	mainBranch := gui.getMainBranch()
+	if mainBranch == "" {
+		return gui.createErrorPanel(gui.Tr.CouldNotDetermineMainBranch)
+	}
+
+	mergedBranchNames, err := gui.Git.Branch.MergedInto(mainBranch)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	staleBefore := time.Now().AddDate(0, 0, -gui.UserConfig.Git.StaleBranchDays).Unix()
+	candidates := getBranchCleanupCandidates(gui.State.Branches, mergedBranchNames, mainBranch, staleBefore)
+	if len(candidates) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoBranchesToCleanUp)
+	}
+
+	return gui.createBranchCleanupMenu(candidates, mainBranch, 0)
+}
+
+// createBranchCleanupMenu shows a menu with a checkbox for each candidate branch.
+// Pressing on a branch toggles its checkbox and re-opens the menu at the same spot
+func (gui *Gui) createBranchCleanupMenu(candidates []*branchCleanupCandidate, mainBranch string, selectedLineIdx int) error {
+	selectedCount := 0
+	for _, candidate := range candidates {
+		if candidate.selected {
+			selectedCount++
+		}
+	}
+	selectedCountStr := utils.ResolvePlaceholderString(gui.Tr.LcSelectedCount, map[string]string{
+		"count": strconv.Itoa(selectedCount),
+	})
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.LcDeleteSelectedBranches, selectedCountStr},
+			onPress: func() error {
+				return gui.deleteCleanupBranches(candidates, mainBranch, false)
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.LcDeleteSelectedBranchesAndRemotes, selectedCountStr},
+			onPress: func() error {
+				return gui.deleteCleanupBranches(candidates, mainBranch, true)
+			},
+		},
+	}
+
+	for i, candidate := range candidates {
+		i := i
+		candidate := candidate
+
+		checkbox := "[ ]"
+		if candidate.selected {
+			checkbox = style.FgGreen.Sprint("[x]")
+		}
+		name := presentation.GetBranchTextStyle(candidate.branch.Name).Sprint(candidate.branch.Name)
+
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{
+				checkbox + " " + name,
+				style.FgYellow.Sprint(gui.branchCleanupReasons(candidate, mainBranch)),
+			},
+			onPress: func() error {
+				candidate.selected = !candidate.selected
+				return gui.createBranchCleanupMenu(candidates, mainBranch, i+2)
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.CleanupBranchesMenuTitle, menuItems, createMenuOptions{showCancel: true, selectedLineIdx: selectedLineIdx})
+}
+
+func (gui *Gui) branchCleanupReasons(candidate *branchCleanupCandidate, mainBranch string) string {
+	reasons := []string{}
+	if candidate.merged {
+		reasons = append(reasons, utils.ResolvePlaceholderString(gui.Tr.LcMergedIntoBranch, map[string]string{
+			"branchName": mainBranch,
+		}))
+	}
+	if candidate.gone {
+		reasons = append(reasons, gui.Tr.LcUpstreamGone)
+	}
+	if candidate.stale {
+		reasons = append(reasons, utils.ResolvePlaceholderString(gui.Tr.LcNoCommitsForDays, map[string]string{
+			"days": strconv.Itoa(gui.UserConfig.Git.StaleBranchDays),
+		}))
+	}
+
+	return strings.Join(reasons, ", ")
+}
+
+// deleteCleanupBranches force deletes the selected branches, given that a branch
+// merged into the main branch may not be merged into HEAD. If any of them aren't
+// merged, we warn the user that their commits will be lost. We never delete a
+// remote branch that has commits the local branch doesn't (or that we can't
+// tell about), given that those commits would be lost for everybody.
+func (gui *Gui) deleteCleanupBranches(candidates []*branchCleanupCandidate, mainBranch string, deleteRemote bool) error {
+	branches := []*models.Branch{}
+	unmergedBranchNames := []string{}
+	keptUpstreams := map[string]bool{}
+	keptUpstreamNames := []string{}
+	for _, candidate := range candidates {
+		if candidate.selected {
+			branch := candidate.branch
+			branches = append(branches, branch)
+			if !candidate.merged {
+				unmergedBranchNames = append(unmergedBranchNames, branch.Name)
+			}
+			if deleteRemote && branch.IsTrackingRemote() && !branch.UpstreamGone && !canDeleteUpstream(branch) {
+				keptUpstreams[branch.Name] = true
+				keptUpstreamNames = append(keptUpstreamNames, branch.UpstreamRemote+"/"+branch.UpstreamBranch)
+			}
+		}
+	}
+
+	if len(branches) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoBranchesSelected)
+	}
+
+	title := gui.Tr.CleanupBranchesMenuTitle
+	prompt := utils.ResolvePlaceholderString(gui.Tr.CleanupBranchesPrompt, map[string]string{
+		"count": strconv.Itoa(len(branches)),
+	})
+	if len(unmergedBranchNames) > 0 {
+		title = gui.Tr.CleanupUnmergedBranchesTitle
+		prompt = utils.ResolvePlaceholderString(gui.Tr.CleanupUnmergedBranchesPrompt, map[string]string{
+			"count":      strconv.Itoa(len(branches)),
+			"mainBranch": mainBranch,
+			"branches":   strings.Join(unmergedBranchNames, "\n"),
+		})
+	}
+	if len(keptUpstreamNames) > 0 {
+		prompt += utils.ResolvePlaceholderString(gui.Tr.CleanupKeptUpstreamsNote, map[string]string{
+			"branches": strings.Join(keptUpstreamNames, "\n"),
+		})
+	}
+
+	return gui.ask(askOpts{
+		title:  title,
+		prompt: prompt,
+		handleConfirm: func() error {
+			return gui.WithWaitingStatus(gui.Tr.DeletingStatus, func() error {
+				gui.logAction(gui.Tr.Actions.CleanupBranches)
+				// we carry on past failures so that one bad branch doesn't stop us
+				// cleaning up the rest
+				errorMessages := []string{}
+				for _, branch := range branches {
+					if err := gui.Git.Branch.Delete(branch.Name, true); err != nil {
+						errorMessages = append(errorMessages, err.Error())
+						continue
+					}
+
+					if deleteRemote && branch.IsTrackingRemote() && !branch.UpstreamGone && !keptUpstreams[branch.Name] {
+						err := gui.Git.Remote.DeleteRemoteBranch(branch.UpstreamRemote, branch.UpstreamBranch)
+						if err != nil {
+							errorMessages = append(errorMessages, err.Error())
+						}
+					}
+				}
+
+				if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{BRANCHES, REMOTES}}); err != nil {
+					return err
+				}
+
+				var err error
+				if len(errorMessages) > 0 {
+					err = errors.New(strings.Join(errorMessages, "\n"))
+				}
+
+				if deleteRemote {
+					// deleting remote branches may have prompted for credentials
+					gui.handleCredentialsPopup(err)
+					return nil
+				}
+
+				return err
+			})
+		},
+	})
+}
@@ -0,0 +1,35 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBranchCleanupCandidates(t *testing.T) {
+	current := &models.Branch{Name: "feature/current", Head: true, CommitterDate: 100}
+	main := &models.Branch{Name: "main", CommitterDate: 100}
+	merged := &models.Branch{Name: "feature/merged", CommitterDate: 2000}
+	gone := &models.Branch{Name: "feature/gone", UpstreamGone: true, CommitterDate: 2000}
+	stale := &models.Branch{Name: "feature/stale", CommitterDate: 500}
+	active := &models.Branch{Name: "feature/active", CommitterDate: 2000}
+
+	branches := []*models.Branch{current, main, merged, gone, stale, active}
+	mergedBranchNames := []string{"main", "feature/current", "feature/merged"}
+
+	candidates := getBranchCleanupCandidates(branches, mergedBranchNames, "main", 1000)
+
+	assert.EqualValues(t, []*branchCleanupCandidate{
+		{branch: merged, merged: true, selected: true},
+		{branch: gone, gone: true, selected: false},
+		{branch: stale, stale: true, selected: false},
+	}, candidates)
+}
+
+func TestCanDeleteUpstream(t *testing.T) {
+	assert.True(t, canDeleteUpstream(&models.Branch{Name: "feature/pushed", Pushables: "2", Pullables: "0"}))
+	assert.False(t, canDeleteUpstream(&models.Branch{Name: "feature/behind", Pushables: "0", Pullables: "3"}))
+	// we don't know what's on the upstream if it hasn't been fetched
+	assert.False(t, canDeleteUpstream(&models.Branch{Name: "feature/unknown", Pushables: "?", Pullables: "?"}))
+}
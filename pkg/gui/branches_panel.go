@@ -3,26 +3,36 @@ package gui
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // list panel functions
 
-func (gui *Gui) getSelectedBranch() *models.Branch {
-	if len(gui.State.Branches) == 0 {
+func (gui *Gui) getSelectedBranchNode() *filetree.BranchNode {
+	selectedLine := gui.State.Panels.Branches.SelectedLineIdx
+	if selectedLine == -1 {
 		return nil
 	}
 
-	selectedLine := gui.State.Panels.Branches.SelectedLineIdx
-	if selectedLine == -1 {
+	return gui.State.BranchTreeViewModel.GetItemAtIndex(selectedLine)
+}
+
+// getSelectedBranch returns nil if a folder of branches is selected
+func (gui *Gui) getSelectedBranch() *models.Branch {
+	node := gui.getSelectedBranchNode()
+	if node == nil {
 		return nil
 	}
 
-	return gui.State.Branches[selectedLine]
+	return node.Branch
 }
 
 func (gui *Gui) branchesRenderToMain() error {
@@ -60,18 +70,125 @@ func (gui *Gui) refreshBranches() {
 		}
 	}
 
-	branches, err := gui.Git.Loaders.Branches.Load(reflogCommits)
+	branches, err := gui.Git.Loaders.Branches.Load(reflogCommits, gui.getBranchListState().SortOrder)
 	if err != nil {
 		_ = gui.surfaceError(err)
 	}
 
 	gui.State.Branches = branches
+	gui.State.BranchTreeViewModel.SetBranches(branches)
 
 	if err := gui.postRefreshUpdate(gui.State.Contexts.Branches); err != nil {
 		gui.Log.Error(err)
 	}
 
 	gui.refreshStatus()
+
+	gui.refreshPullRequests()
+
+	gui.refreshBranchDivergences()
+}
+
+// getBaseBranch returns the branch that the given branch is compared against, if
+// one has been set
+func (gui *Gui) getBaseBranch(branchName string, branchBases map[string]string) string {
+	if base, ok := branchBases[branchName]; ok {
+		return base
+	}
+
+	return gui.getBranchListState().BaseBranch
+}
+
+// refreshBranchDivergences works out how far each branch is ahead/behind its
+// base branch in the background, given that it takes a git call per branch, and
+// re-renders the branches panel once we're done. Refreshes can come in quick
+// succession (e.g. from the file watcher), so if a run is already in flight we
+// just note that another one is needed once it's done.
+func (gui *Gui) refreshBranchDivergences() {
+	branchBases := gui.Git.Branch.GetBranchBases()
+	if gui.getBranchListState().BaseBranch == "" && len(branchBases) == 0 {
+		gui.State.BranchDivergences = nil
+		return
+	}
+
+	state := gui.State
+	gui.Mutexes.BranchDivergencesMutex.Lock()
+	if state.IsRefreshingBranchDivergences {
+		state.BranchDivergencesOutdated = true
+		gui.Mutexes.BranchDivergencesMutex.Unlock()
+		return
+	}
+	state.IsRefreshingBranchDivergences = true
+	gui.Mutexes.BranchDivergencesMutex.Unlock()
+
+	bases := map[string]string{}
+	for _, branch := range state.Branches {
+		base := gui.getBaseBranch(branch.Name, branchBases)
+		if base != "" && base != branch.Name && branch.IsRealBranch() {
+			bases[branch.Name] = base
+		}
+	}
+
+	go utils.Safe(func() {
+		divergences := make(map[string]*models.BranchDivergence, len(bases))
+		for branchName, base := range bases {
+			ahead, behind := gui.Git.Branch.GetBaseDifferenceCount(branchName, base)
+			divergences[branchName] = &models.BranchDivergence{Base: base, Ahead: ahead, Behind: behind}
+		}
+
+		gui.OnUIThread(func() error {
+			state.BranchDivergences = divergences
+
+			gui.Mutexes.BranchDivergencesMutex.Lock()
+			state.IsRefreshingBranchDivergences = false
+			outdated := state.BranchDivergencesOutdated
+			state.BranchDivergencesOutdated = false
+			gui.Mutexes.BranchDivergencesMutex.Unlock()
+
+			if gui.State != state {
+				// we've since switched repos
+				return nil
+			}
+
+			if outdated {
+				gui.refreshBranchDivergences()
+			}
+
+			return gui.postRefreshUpdate(gui.State.Contexts.Branches)
+		})
+	})
+}
+
+// refreshPullRequests fetches the repo's open pull requests in the background
+// and re-renders the branches panel once we have them
+func (gui *Gui) refreshPullRequests() {
+	if !gui.UserConfig.Forge.ShowPullRequests {
+		return
+	}
+
+	client := gui.getForgeClient()
+	if client == nil {
+		return
+	}
+
+	state := gui.State
+	go utils.Safe(func() {
+		pullRequests, err := client.GetOpenPullRequests()
+		if err != nil {
+			gui.Log.Error(err)
+			return
+		}
+
+		gui.OnUIThread(func() error {
+			state.PullRequests = pullRequests
+			if gui.State != state {
+				// we've since switched repos
+				return nil
+			}
+
+			return gui.postRefreshUpdate(gui.State.Contexts.Branches)
+		})
+	})
 }
 
 // specific functions
@@ -80,10 +197,17 @@ func (gui *Gui) handleBranchPress() error {
 	if gui.State.Panels.Branches.SelectedLineIdx == -1 {
 		return nil
 	}
-	if gui.State.Panels.Branches.SelectedLineIdx == 0 {
-		return gui.createErrorPanel(gui.Tr.AlreadyCheckedOutBranch)
+	if node := gui.getSelectedBranchNode(); node != nil && !node.IsLeaf() {
+		return gui.handleToggleBranchFolderCollapsed()
 	}
 	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	// the checked out branch isn't necessarily at the top when the list is filtered
+	if branch.Head {
+		return gui.createErrorPanel(gui.Tr.AlreadyCheckedOutBranch)
+	}
 	gui.logAction(gui.Tr.Actions.CheckoutBranch)
 	return gui.handleCheckoutRef(branch.Name, handleCheckoutRefOptions{})
 }
@@ -174,7 +298,6 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 	cmdOptions := git_commands.CheckoutOptions{Force: false, EnvVars: options.EnvVars}
 
 	onSuccess := func() {
-		gui.State.Panels.Branches.SelectedLineIdx = 0
 		gui.State.Panels.Commits.SelectedLineIdx = 0
 		// loading a heap of commits is slow so we limit them whenever doing a reset
 		gui.State.Panels.Commits.LimitCommits = true
@@ -204,12 +327,12 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 
 						onSuccess()
 						if err := gui.Git.Stash.Pop(0); err != nil {
-							if err := gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI}); err != nil {
+							if err := gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI, then: gui.selectCheckedOutBranch}); err != nil {
 								return err
 							}
 							return gui.surfaceError(err)
 						}
-						return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI})
+						return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI, then: gui.selectCheckedOutBranch})
 					},
 				})
 			}
@@ -220,7 +343,7 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 		}
 		onSuccess()
 
-		return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI})
+		return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI, then: gui.selectCheckedOutBranch})
 	})
 }
 
@@ -253,6 +376,28 @@ func (gui *Gui) getCheckedOutBranch() *models.Branch {
 	return gui.State.Branches[0]
 }
 
+// selectBranchByName moves the selection onto the given branch. We can't
+// assume the branch is at the top of the list because the list may be
+// filtered or grouped by prefix.
+func (gui *Gui) selectBranchByName(name string) {
+	gui.State.BranchTreeViewModel.ExpandToPath(name)
+	index, found := gui.State.BranchTreeViewModel.GetIndexForPath(name)
+	if !found {
+		index = 0
+	}
+	gui.State.Panels.Branches.SetSelectedLineIdx(index)
+
+	if err := gui.postRefreshUpdate(gui.State.Contexts.Branches); err != nil {
+		gui.Log.Error(err)
+	}
+}
+
+func (gui *Gui) selectCheckedOutBranch() {
+	if branch := gui.getCheckedOutBranch(); branch != nil {
+		gui.selectBranchByName(branch.Name)
+	}
+}
+
 func (gui *Gui) createNewBranchWithName(newBranchName string) error {
 	branch := gui.getSelectedBranch()
 	if branch == nil {
@@ -263,8 +408,7 @@ func (gui *Gui) createNewBranchWithName(newBranchName string) error {
 		return gui.surfaceError(err)
 	}
 
-	gui.State.Panels.Branches.SelectedLineIdx = 0
-	return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+	return gui.refreshSidePanels(refreshOptions{then: func() { gui.selectBranchByName(newBranchName) }})
 }
 
 func (gui *Gui) handleDeleteBranch() error {
@@ -272,6 +416,10 @@ func (gui *Gui) handleDeleteBranch() error {
 }
 
 func (gui *Gui) deleteBranch(force bool) error {
+	if gui.State.Panels.Branches.IsSelectingRange() {
+		return gui.deleteSelectedBranches()
+	}
+
 	selectedBranch := gui.getSelectedBranch()
 	if selectedBranch == nil {
 		return nil
@@ -315,6 +463,86 @@ func (gui *Gui) deleteNamedBranch(selectedBranch *models.Branch, force bool) err
 	})
 }
 
+func (gui *Gui) deleteSelectedBranches() error {
+	startIdx, endIdx := gui.State.Panels.Branches.GetSelectedRange()
+	if startIdx == -1 || len(gui.State.Branches) == 0 {
+		return nil
+	}
+
+	// folders in the range are skipped: only the branches we can see are deleted
+	branches := []*models.Branch{}
+	for _, node := range gui.State.BranchTreeViewModel.GetAllItems()[startIdx : endIdx+1] {
+		if node.IsLeaf() {
+			branches = append(branches, node.Branch)
+		}
+	}
+
+	checkedOutBranch := gui.getCheckedOutBranch()
+	for _, branch := range branches {
+		if branch.Name == checkedOutBranch.Name {
+			return gui.createErrorPanel(gui.Tr.CantDeleteCheckOutBranch)
+		}
+	}
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.DeleteBranch,
+		prompt: gui.Tr.DeleteSelectedBranchesMessage,
+		handleConfirm: func() error {
+			gui.logAction(gui.Tr.Actions.DeleteBranch)
+			unmergedBranches := []*models.Branch{}
+			for _, branch := range branches {
+				if err := gui.Git.Branch.Delete(branch.Name, false); err != nil {
+					if !strings.Contains(err.Error(), "git branch -D ") {
+						_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
+						return gui.createErrorPanel(err.Error())
+					}
+					unmergedBranches = append(unmergedBranches, branch)
+				}
+			}
+
+			gui.State.Panels.Branches.CancelRangeSelect()
+
+			if len(unmergedBranches) > 0 {
+				return gui.forceDeleteBranches(unmergedBranches)
+			}
+
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
+		},
+	})
+}
+
+func (gui *Gui) forceDeleteBranches(branches []*models.Branch) error {
+	branchNames := make([]string, len(branches))
+	for i, branch := range branches {
+		branchNames[i] = branch.Name
+	}
+
+	message := utils.ResolvePlaceholderString(
+		gui.Tr.ForceDeleteBranchesMessage,
+		map[string]string{
+			"branchNames": strings.Join(branchNames, ", "),
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.DeleteBranch,
+		prompt: message,
+		handleConfirm: func() error {
+			gui.logAction(gui.Tr.Actions.DeleteBranch)
+			for _, branchName := range branchNames {
+				if err := gui.Git.Branch.Delete(branchName, true); err != nil {
+					_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
+					return gui.surfaceError(err)
+				}
+			}
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
+		},
+		handleClose: func() error {
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
+		},
+	})
+}
+
 func (gui *Gui) mergeBranchIntoCheckedOutBranch(branchName string) error {
 	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
 		return err
@@ -416,7 +644,7 @@ func (gui *Gui) handleFastForward() error {
 	go utils.Safe(func() {
 		_ = gui.createLoaderPanel(message)
 
-		if gui.State.Panels.Branches.SelectedLineIdx == 0 {
+		if branch.Head {
 			_ = gui.pullWithLock(PullFilesOptions{action: action, FastForwardOnly: true})
 		} else {
 			gui.logAction(action)
@@ -457,14 +685,7 @@ func (gui *Gui) handleRenameBranch() error {
 				gui.refreshBranches()
 
 				// now that we've got our stuff again we need to find that branch and reselect it.
-				for i, newBranch := range gui.State.Branches {
-					if newBranch.Name == newBranchName {
-						gui.State.Panels.Branches.SetSelectedLineIdx(i)
-						if err := gui.State.Contexts.Branches.HandleRender(); err != nil {
-							return err
-						}
-					}
-				}
+				gui.selectBranchByName(newBranchName)
 
 				return nil
 			},
@@ -518,7 +739,8 @@ func (gui *Gui) handleNewBranchOffCurrentItem() error {
 		initialContent: prefilledName,
 		handleConfirm: func(response string) error {
 			gui.logAction(gui.Tr.Actions.CreateBranch)
-			if err := gui.Git.Branch.New(sanitizedBranchName(response), item.ID()); err != nil {
+			newBranchName := sanitizedBranchName(response)
+			if err := gui.Git.Branch.New(newBranchName, item.ID()); err != nil {
 				return err
 			}
 
@@ -534,13 +756,137 @@ func (gui *Gui) handleNewBranchOffCurrentItem() error {
 				}
 			}
 
-			gui.State.Panels.Branches.SelectedLineIdx = 0
+			return gui.refreshSidePanels(refreshOptions{then: func() { gui.selectBranchByName(newBranchName) }})
+		},
+	})
+}
 
-			return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+func (gui *Gui) handleEnterBranch() error {
+	if node := gui.getSelectedBranchNode(); node != nil && !node.IsLeaf() {
+		return gui.handleToggleBranchFolderCollapsed()
+	}
+
+	return gui.handleSwitchToSubCommits()
+}
+
+func (gui *Gui) handleToggleBranchFolderCollapsed() error {
+	node := gui.getSelectedBranchNode()
+	if node == nil {
+		return nil
+	}
+
+	gui.State.BranchTreeViewModel.ToggleCollapsed(node.GetPath())
+
+	return gui.postRefreshUpdate(gui.State.Contexts.Branches)
+}
+
+// getBranchListState returns how the current repo's branches are sorted and
+// grouped
+func (gui *Gui) getBranchListState() config.BranchListState {
+	repo, err := os.Getwd()
+	if err != nil {
+		gui.Log.Error(err)
+		return config.BranchListState{}
+	}
+
+	return gui.Config.GetAppState().BranchListStates[repo]
+}
+
+func (gui *Gui) saveBranchListState(state config.BranchListState) error {
+	repo, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	appState := gui.Config.GetAppState()
+	if appState.BranchListStates == nil {
+		appState.BranchListStates = map[string]config.BranchListState{}
+	}
+	appState.BranchListStates[repo] = state
+
+	return gui.Config.SaveAppState()
+}
+
+func (gui *Gui) handleBranchSortOrderMenu() error {
+	updateState := func(update func(state *config.BranchListState)) error {
+		state := gui.getBranchListState()
+		update(&state)
+		if err := gui.saveBranchListState(state); err != nil {
+			gui.Log.Error(err)
+		}
+
+		gui.State.BranchTreeViewModel.SetGroupByPrefix(state.GroupByPrefix)
+		gui.State.Panels.Branches.SetSelectedLineIdx(0)
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
+	}
+
+	setSortOrder := func(sortOrder string) func() error {
+		return func() error {
+			return updateState(func(state *config.BranchListState) { state.SortOrder = sortOrder })
+		}
+	}
+
+	groupByPrefix := gui.getBranchListState().GroupByPrefix
+	groupByPrefixDisplayString := gui.Tr.LcGroupBranchesByPrefix
+	if groupByPrefix {
+		groupByPrefixDisplayString = gui.Tr.LcUngroupBranches
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.LcSortByRecency,
+			onPress:       setSortOrder(loaders.BRANCH_SORT_ORDER_RECENCY),
+		},
+		{
+			displayString: gui.Tr.LcSortAlphabetically,
+			onPress:       setSortOrder(loaders.BRANCH_SORT_ORDER_ALPHABETICAL),
+		},
+		{
+			displayString: gui.Tr.LcSortByCommitDate,
+			onPress:       setSortOrder(loaders.BRANCH_SORT_ORDER_DATE),
+		},
+		{
+			displayString: gui.Tr.LcSortByDivergence,
+			onPress:       setSortOrder(loaders.BRANCH_SORT_ORDER_DIVERGENCE),
+		},
+		{
+			displayString: groupByPrefixDisplayString,
+			onPress: func() error {
+				return updateState(func(state *config.BranchListState) { state.GroupByPrefix = !groupByPrefix })
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.BranchSortOrderMenuTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleSetBaseBranch() error {
+	return gui.prompt(promptOpts{
+		title:               gui.Tr.SetBaseBranchPrompt,
+		initialContent:      gui.getBranchListState().BaseBranch,
+		findSuggestionsFunc: gui.getRefsSuggestionsFunc(),
+		handleConfirm: func(baseBranch string) error {
+			state := gui.getBranchListState()
+			state.BaseBranch = strings.TrimSpace(baseBranch)
+			if err := gui.saveBranchListState(state); err != nil {
+				gui.Log.Error(err)
+			}
+
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
 		},
 	})
 }
 
+func (gui *Gui) handleRebaseOntoBaseBranch() error {
+	checkedOutBranch := gui.getCheckedOutBranch()
+	base := gui.getBaseBranch(checkedOutBranch.Name, gui.Git.Branch.GetBranchBases())
+	if base == "" {
+		return gui.createErrorPanel(gui.Tr.NoBaseBranch)
+	}
+
+	return gui.handleRebaseOntoBranch(base)
+}
+
 // sanitizedBranchName will remove all spaces in favor of a dash "-" to meet
 // git's branch naming requirement.
 func sanitizedBranchName(input string) string {
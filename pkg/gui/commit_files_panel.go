@@ -261,6 +261,7 @@ func (gui *Gui) switchToCommitFilesContext(refName string, canRebase bool, conte
 
 	gui.State.Panels.CommitFiles.SelectedLineIdx = 0
 	gui.State.Panels.CommitFiles.refName = refName
+	gui.State.CommitFileTreeViewModel.SetTextFilter("")
 	gui.State.Panels.CommitFiles.canRebase = canRebase
 	gui.State.Contexts.CommitFiles.SetParentContext(context)
 	gui.State.Contexts.CommitFiles.SetWindowName(windowName)
@@ -5,6 +5,9 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -15,6 +18,10 @@ func (gui *Gui) handleCommitConfirm() error {
 		return gui.createErrorPanel(gui.Tr.CommitWithoutMessageErr)
 	}
 
+	if err := gui.Git.Commit.ValidateMessage(message); err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+
 	cmdObj := gui.Git.Commit.CommitCmdObj(message)
 	gui.logAction(gui.Tr.Actions.Commit)
 
@@ -31,6 +38,17 @@ func (gui *Gui) handleCommitClose() error {
 }
 
 func (gui *Gui) handleCommitMessageFocused() error {
+	return gui.renderCommitMessageOptions()
+}
+
+// renderCommitMessageOptions shows any lint warnings for the commit message in
+// the options view, falling back to the usual keybinding hints
+func (gui *Gui) renderCommitMessageOptions() error {
+	warnings := gui.Git.Commit.LintMessage(gui.Views.CommitMessage.TextArea.GetContent())
+	if len(warnings) > 0 {
+		return gui.renderString(gui.Views.Options, style.FgRed.Sprint(strings.Join(warnings, "; ")))
+	}
+
 	message := utils.ResolvePlaceholderString(
 		gui.Tr.CommitMessageConfirm,
 		map[string]string{
@@ -55,3 +73,91 @@ func (gui *Gui) RenderCommitLength() {
 
 	gui.Views.CommitMessage.Subtitle = gui.getBufferLength(gui.Views.CommitMessage)
 }
+
+func (gui *Gui) handleCommitMessageTrailerMenu() error {
+	promptForTrailer := func(key string, findSuggestionsFunc func(string) []*types.Suggestion) func() error {
+		return func() error {
+			return gui.prompt(promptOpts{
+				title:               key + ":",
+				findSuggestionsFunc: findSuggestionsFunc,
+				handleConfirm: func(value string) error {
+					return gui.addCommitMessageTrailer(key, value)
+				},
+			})
+		}
+	}
+
+	authorSuggestionsFunc := gui.getAuthorSuggestionsFunc()
+	menuItems := []*menuItem{
+		{
+			displayString: "Signed-off-by",
+			onPress: func() error {
+				return gui.addCommitMessageTrailer("Signed-off-by", gui.Git.Config.GetUserIdentity())
+			},
+		},
+		{
+			displayString: "Co-authored-by",
+			onPress:       promptForTrailer("Co-authored-by", authorSuggestionsFunc),
+		},
+		{
+			displayString: "Reviewed-by",
+			onPress:       promptForTrailer("Reviewed-by", authorSuggestionsFunc),
+		},
+		{
+			displayString: "Fixes",
+			onPress:       promptForTrailer("Fixes", nil),
+		},
+	}
+
+	return gui.createMenu(gui.Tr.AddTrailer, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) addCommitMessageTrailer(key string, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	message := gui.Views.CommitMessage.TextArea.GetContent()
+	return gui.setCommitMessage(git_commands.AddTrailer(message, key, value))
+}
+
+var conventionalCommitTypes = []string{
+	"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert",
+}
+
+func (gui *Gui) handleConventionalCommitMenu() error {
+	menuItems := make([]*menuItem, len(conventionalCommitTypes))
+	for i, commitType := range conventionalCommitTypes {
+		commitType := commitType
+		menuItems[i] = &menuItem{
+			displayString: commitType,
+			onPress: func() error {
+				return gui.prompt(promptOpts{
+					title: gui.Tr.ConventionalCommitScope,
+					handleConfirm: func(scope string) error {
+						message := gui.Views.CommitMessage.TextArea.GetContent()
+						return gui.setCommitMessage(
+							git_commands.SetConventionalCommitType(message, commitType, strings.TrimSpace(scope)),
+						)
+					},
+				})
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.ConventionalCommitType, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) setCommitMessage(message string) error {
+	view := gui.Views.CommitMessage
+	view.ClearTextArea()
+	view.TextArea.TypeString(message)
+	if err := gui.resizePopupPanel(view, view.TextArea.GetContent()); err != nil {
+		return err
+	}
+	view.RenderTextArea()
+	gui.RenderCommitLength()
+
+	return gui.renderCommitMessageOptions()
+}
@@ -0,0 +1,96 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
+)
+
+func (gui *Gui) handleCommitSearchMenu() error {
+	kinds := []struct {
+		kind  loaders.CommitSearchKind
+		label string
+	}{
+		{kind: loaders.COMMIT_SEARCH_MESSAGE, label: gui.Tr.SearchCommitMessages},
+		{kind: loaders.COMMIT_SEARCH_AUTHOR, label: gui.Tr.SearchCommitAuthors},
+		{kind: loaders.COMMIT_SEARCH_ADDED_OR_REMOVED, label: gui.Tr.SearchAddedOrRemovedText},
+		{kind: loaders.COMMIT_SEARCH_CHANGED_LINES, label: gui.Tr.SearchChangedLines},
+	}
+
+	menuItems := make([]*menuItem, len(kinds))
+	for i, kind := range kinds {
+		kind := kind
+		menuItems[i] = &menuItem{
+			displayString: kind.label,
+			onPress: func() error {
+				return gui.prompt(promptOpts{
+					title:          kind.label,
+					initialContent: gui.lastCommitSearchQuery(kind.kind),
+					handleConfirm: func(query string) error {
+						if query == "" {
+							return nil
+						}
+						return gui.searchCommits(loaders.CommitSearch{Kind: kind.kind, Query: query})
+					},
+				})
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SearchCommitHistory, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) lastCommitSearchQuery(kind loaders.CommitSearchKind) string {
+	search := gui.State.Panels.SubCommits.search
+	if search == nil || search.Kind != kind {
+		return ""
+	}
+
+	return search.Query
+}
+
+// searchCommits shows the commits reachable from the commits panel's ref that
+// match the search in the sub-commits panel
+func (gui *Gui) searchCommits(search loaders.CommitSearch) error {
+	return gui.WithWaitingStatus(gui.Tr.SearchingCommitsStatus, func() error {
+		commits, err := gui.Git.Loaders.Commits.GetCommits(
+			loaders.GetCommitsOptions{
+				FilterPath:           gui.State.Modes.Filtering.GetPath(),
+				IncludeRebaseCommits: false,
+				RefName:              gui.branchCommitsRefName(),
+				All:                  gui.State.ShowWholeGitGraph,
+				Search:               search,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		gui.OnUIThread(func() error {
+			if len(commits) == 0 {
+				return gui.createErrorPanel(gui.Tr.NoCommitsMatchSearch)
+			}
+
+			gui.State.SubCommits = commits
+			gui.State.Panels.SubCommits.refName = gui.branchCommitsRefName()
+			gui.State.Panels.SubCommits.search = &search
+			gui.State.Panels.SubCommits.SelectedLineIdx = 0
+			gui.State.Contexts.SubCommits.SetParentContext(gui.State.Contexts.BranchCommits)
+
+			return gui.pushContext(gui.State.Contexts.SubCommits)
+		})
+
+		return nil
+	})
+}
+
+func (gui *Gui) handleEnterSubCommit() error {
+	if gui.State.Panels.SubCommits.search == nil {
+		return gui.handleViewSubCommitFiles()
+	}
+
+	commit := gui.getSelectedSubCommit()
+	if commit == nil {
+		return nil
+	}
+
+	return gui.goToCommit(commit.Sha)
+}
@@ -6,11 +6,19 @@ import (
 
 	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
+	"github.com/jesseduffield/lazygit/pkg/commands/types/enums"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-// after selecting the 200th commit, we'll load in all the rest
-const COMMIT_THRESHOLD = 200
+// we load commits a page at a time so that repos with a huge history don't take
+// forever to load
+const COMMIT_PAGE_SIZE = 300
+
+// once the selected commit is within this many commits of the last loaded
+// commit, we'll load in the next page
+const COMMIT_THRESHOLD = 100
 
 // list panel functions
 
@@ -25,10 +33,11 @@ func (gui *Gui) getSelectedLocalCommit() *models.Commit {
 
 func (gui *Gui) onCommitFocus() error {
 	state := gui.State.Panels.Commits
-	if state.SelectedLineIdx > COMMIT_THRESHOLD && state.LimitCommits {
-		state.LimitCommits = false
+	if state.LimitCommits && !state.AllCommitsLoaded && !state.LoadingMoreCommits &&
+		state.SelectedLineIdx > len(gui.State.Commits)-COMMIT_THRESHOLD {
+		state.LoadingMoreCommits = true
 		go utils.Safe(func() {
-			if err := gui.refreshCommitsWithLimit(); err != nil {
+			if err := gui.loadMoreCommits(); err != nil {
 				_ = gui.surfaceError(err)
 			}
 		})
@@ -44,8 +53,20 @@ func (gui *Gui) branchCommitsRenderToMain() error {
 	commit := gui.getSelectedLocalCommit()
 	if commit == nil {
 		task = NewRenderStringTask(gui.Tr.NoCommitsThisBranch)
+	} else if commit.Sha == "" {
+		// e.g. an exec or break line in the rebase todo
+		task = NewRenderStringTask(commit.Description())
 	} else {
-		cmdObj := gui.Git.Commit.ShowCmdObj(commit.Sha, gui.State.Modes.Filtering.GetPath())
+		var cmdObj oscommands.ICmdObj
+		if gui.State.Modes.Filtering.HasLineRange() {
+			cmdObj = gui.Git.Commit.ShowLineRangeCmdObj(
+				gui.branchCommitsRefName(),
+				gui.State.Modes.Filtering.GetLineRange(),
+				gui.State.Panels.Commits.SelectedLineIdx,
+			)
+		} else {
+			cmdObj = gui.Git.Commit.ShowCmdObj(commit.Sha, gui.State.Modes.Filtering.GetPath())
+		}
 		task = NewRunPtyTask(cmdObj.GetCmd())
 	}
 
@@ -112,34 +133,84 @@ func (gui *Gui) refreshCommits() {
 	wg.Wait()
 }
 
+// branchCommitsRefName returns the ref whose commits we show in the commits panel
+func (gui *Gui) branchCommitsRefName() string {
+	if gui.State.BisectInfo.Started() {
+		return gui.State.BisectInfo.StartSha()
+	}
+
+	return "HEAD"
+}
+
+func (gui *Gui) branchCommitsOptions(limit int) loaders.GetCommitsOptions {
+	return loaders.GetCommitsOptions{
+		Limit:                limit,
+		FilterPath:           gui.State.Modes.Filtering.GetPath(),
+		FilterLineRange:      gui.State.Modes.Filtering.GetLineRange(),
+		IncludeRebaseCommits: true,
+		RefName:              gui.branchCommitsRefName(),
+		// when filtering by line range we show each commit's diff by its position
+		// in the log, so we need the log to be the same as the one we render from
+		All: gui.State.ShowWholeGitGraph && !gui.State.Modes.Filtering.HasLineRange(),
+	}
+}
+
 func (gui *Gui) refreshCommitsWithLimit() error {
 	gui.Mutexes.BranchCommitsMutex.Lock()
 	defer gui.Mutexes.BranchCommitsMutex.Unlock()
 
-	refName := "HEAD"
-	bisectInfo := gui.Git.Bisect.GetInfo()
-	gui.State.BisectInfo = bisectInfo
-	if bisectInfo.Started() {
-		refName = bisectInfo.StartSha()
+	gui.State.BisectInfo = gui.Git.Bisect.GetInfo()
+
+	// we only reload as many pages as we need to get to the selected commit, so
+	// that if you've scrolled down and back up again we let go of the commits
+	// further down
+	state := gui.State.Panels.Commits
+	limit := 0
+	if state.LimitCommits {
+		limit = COMMIT_PAGE_SIZE * (state.SelectedLineIdx/COMMIT_PAGE_SIZE + 1)
 	}
 
-	commits, err := gui.Git.Loaders.Commits.GetCommits(
-		loaders.GetCommitsOptions{
-			Limit:                gui.State.Panels.Commits.LimitCommits,
-			FilterPath:           gui.State.Modes.Filtering.GetPath(),
-			IncludeRebaseCommits: true,
-			RefName:              refName,
-			All:                  gui.State.ShowWholeGitGraph,
-		},
-	)
+	commits, err := gui.Git.Loaders.Commits.GetCommits(gui.branchCommitsOptions(limit))
 	if err != nil {
 		return err
 	}
 	gui.State.Commits = commits
+	state.AllCommitsLoaded = limit == 0 || countLogCommits(commits) < limit
+
+	return gui.postRefreshUpdate(gui.State.Contexts.BranchCommits)
+}
+
+// loadMoreCommits appends the next page of commits to the commits panel
+func (gui *Gui) loadMoreCommits() error {
+	gui.Mutexes.BranchCommitsMutex.Lock()
+	defer gui.Mutexes.BranchCommitsMutex.Unlock()
+
+	state := gui.State.Panels.Commits
+	defer func() { state.LoadingMoreCommits = false }()
+
+	commits, err := gui.Git.Loaders.Commits.GetMoreCommits(gui.State.Commits, gui.branchCommitsOptions(COMMIT_PAGE_SIZE))
+	if err != nil {
+		return err
+	}
+	gui.State.Commits = append(gui.State.Commits, commits...)
+	state.AllCommitsLoaded = len(commits) < COMMIT_PAGE_SIZE
 
 	return gui.postRefreshUpdate(gui.State.Contexts.BranchCommits)
 }
 
+// countLogCommits returns the number of commits that came from the git log i.e.
+// not counting the commits of an in-progress rebase
+func countLogCommits(commits []*models.Commit) int {
+	count := 0
+	for _, commit := range commits {
+		if !commit.IsTODO() {
+			count++
+		}
+	}
+
+	return count
+}
+
 func (gui *Gui) refreshRebaseCommits() error {
 	gui.Mutexes.BranchCommitsMutex.Lock()
 	defer gui.Mutexes.BranchCommitsMutex.Unlock()
@@ -164,7 +235,7 @@ func (gui *Gui) handleCommitSquashDown() error {
 		return gui.createErrorPanel(gui.Tr.YouNoCommitsToSquash)
 	}
 
-	applied, err := gui.handleMidRebaseCommand("squash")
+	applied, err := gui.handleMidRebaseCommand(todo.Squash)
 	if err != nil {
 		return err
 	}
@@ -172,14 +243,26 @@ func (gui *Gui) handleCommitSquashDown() error {
 		return nil
 	}
 
+	prompt := gui.Tr.SureSquashThisCommit
+	if gui.State.Panels.Commits.IsSelectingRange() {
+		prompt = gui.Tr.SureSquashSelectedCommits
+	}
+
+	startIdx, endIdx := gui.State.Panels.Commits.GetSelectedRange()
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.Squash,
-		prompt: gui.Tr.SureSquashThisCommit,
+		prompt: prompt,
 		handleConfirm: func() error {
-			return gui.WithWaitingStatus(gui.Tr.SquashingStatus, func() error {
-				gui.logAction(gui.Tr.Actions.SquashCommitDown)
-				err := gui.Git.Rebase.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "squash")
-				return gui.handleGenericMergeCommandResult(err)
+			return gui.withRebaseMergesChoice(endIdx+2, func(rebaseMerges bool) error {
+				return gui.WithWaitingStatus(gui.Tr.SquashingStatus, func() error {
+					gui.logAction(gui.Tr.Actions.SquashCommitDown)
+					err := gui.interactiveRebaseRange(startIdx, endIdx, todo.Squash, rebaseMerges)
+					if err == nil {
+						gui.State.Panels.Commits.CancelRangeSelect()
+					}
+					return gui.handleGenericMergeCommandResult(err)
+				})
 			})
 		},
 	})
@@ -194,7 +277,7 @@ func (gui *Gui) handleCommitFixup() error {
 		return gui.createErrorPanel(gui.Tr.YouNoCommitsToSquash)
 	}
 
-	applied, err := gui.handleMidRebaseCommand("fixup")
+	applied, err := gui.handleMidRebaseCommand(todo.Fixup)
 	if err != nil {
 		return err
 	}
@@ -202,14 +285,26 @@ func (gui *Gui) handleCommitFixup() error {
 		return nil
 	}
 
+	prompt := gui.Tr.SureFixupThisCommit
+	if gui.State.Panels.Commits.IsSelectingRange() {
+		prompt = gui.Tr.SureFixupSelectedCommits
+	}
+
+	startIdx, endIdx := gui.State.Panels.Commits.GetSelectedRange()
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.Fixup,
-		prompt: gui.Tr.SureFixupThisCommit,
+		prompt: prompt,
 		handleConfirm: func() error {
-			return gui.WithWaitingStatus(gui.Tr.FixingStatus, func() error {
-				gui.logAction(gui.Tr.Actions.FixupCommit)
-				err := gui.Git.Rebase.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "fixup")
-				return gui.handleGenericMergeCommandResult(err)
+			return gui.withRebaseMergesChoice(endIdx+2, func(rebaseMerges bool) error {
+				return gui.WithWaitingStatus(gui.Tr.FixingStatus, func() error {
+					gui.logAction(gui.Tr.Actions.FixupCommit)
+					err := gui.interactiveRebaseRange(startIdx, endIdx, todo.Fixup, rebaseMerges)
+					if err == nil {
+						gui.State.Panels.Commits.CancelRangeSelect()
+					}
+					return gui.handleGenericMergeCommandResult(err)
+				})
 			})
 		},
 	})
@@ -220,7 +315,7 @@ func (gui *Gui) handleRewordCommit() error {
 		return err
 	}
 
-	applied, err := gui.handleMidRebaseCommand("reword")
+	applied, err := gui.handleMidRebaseCommand(todo.Reword)
 	if err != nil {
 		return err
 	}
@@ -258,7 +353,7 @@ func (gui *Gui) handleRewordCommitEditor() error {
 		return err
 	}
 
-	applied, err := gui.handleMidRebaseCommand("reword")
+	applied, err := gui.handleMidRebaseCommand(todo.Reword)
 	if err != nil {
 		return err
 	}
@@ -278,42 +373,105 @@ func (gui *Gui) handleRewordCommitEditor() error {
 	return nil
 }
 
-// handleMidRebaseCommand sees if the selected commit is in fact a rebasing
-// commit meaning you are trying to edit the todo file rather than actually
+// handleMidRebaseCommand sees if the selected commits are in fact rebasing
+// commits meaning you are trying to edit the todo file rather than actually
 // begin a rebase. It then updates the todo file with that action
-func (gui *Gui) handleMidRebaseCommand(action string) (bool, error) {
-	selectedCommit := gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx]
-	if selectedCommit.Status != "rebasing" {
+func (gui *Gui) handleMidRebaseCommand(action todo.TodoCommand) (bool, error) {
+	startIdx, endIdx := gui.State.Panels.Commits.GetSelectedRange()
+	if gui.State.Commits[startIdx].Status != "rebasing" {
 		return false, nil
 	}
 
+	// rebasing commits are always at the top, so if the last selected commit is
+	// rebasing then so are all the others
+	if gui.State.Commits[endIdx].Status != "rebasing" {
+		return true, gui.createErrorPanel(gui.Tr.SelectionIncludesNonRebasingCommits)
+	}
+
 	// for now we do not support setting 'reword' because it requires an editor
 	// and that means we either unconditionally wait around for the subprocess to ask for
 	// our input or we set a lazygit client as the EDITOR env variable and have it
 	// request us to edit the commit message when prompted.
-	if action == "reword" {
+	if action == todo.Reword {
 		return true, gui.createErrorPanel(gui.Tr.LcRewordNotSupported)
 	}
 
 	gui.logAction("Update rebase TODO")
-	gui.logCommand(
-		fmt.Sprintf("Updating rebase action of commit %s to '%s'", selectedCommit.ShortSha(), action),
-		false,
-	)
+	for index := startIdx; index <= endIdx; index++ {
+		gui.logCommand(
+			fmt.Sprintf("Updating rebase action of commit %s to '%s'", gui.State.Commits[index].ShortSha(), action),
+			false,
+		)
 
-	if err := gui.Git.Rebase.EditRebaseTodo(gui.State.Panels.Commits.SelectedLineIdx, action); err != nil {
-		return false, gui.surfaceError(err)
+		if err := gui.Git.Rebase.EditRebaseTodo(index, action); err != nil {
+			return false, gui.surfaceError(err)
+		}
 	}
 
 	return true, gui.refreshRebaseCommits()
 }
 
+// withRebaseMergesChoice works out whether an interactive rebase onto the commit
+// at baseIdx should pass --rebase-merges, based on the git.rebaseMerges config.
+// If it's set to 'prompt' and there are merge commits that would otherwise be
+// dropped, we ask the user.
+func (gui *Gui) withRebaseMergesChoice(baseIdx int, f func(rebaseMerges bool) error) error {
+	switch gui.UserConfig.Git.RebaseMerges {
+	case "always":
+		return f(true)
+	case "prompt":
+		if !gui.hasMergeCommitsAbove(baseIdx) {
+			return f(false)
+		}
+
+		menuItems := []*menuItem{
+			{
+				displayString: gui.Tr.LcPreserveMergeCommits,
+				onPress: func() error {
+					return f(true)
+				},
+			},
+			{
+				displayString: gui.Tr.LcFlattenMergeCommits,
+				onPress: func() error {
+					return f(false)
+				},
+			},
+		}
+
+		return gui.createMenu(gui.Tr.RebaseMergesMenuTitle, menuItems, createMenuOptions{showCancel: true})
+	default:
+		return f(false)
+	}
+}
+
+func (gui *Gui) hasMergeCommitsAbove(baseIdx int) bool {
+	for i, commit := range gui.State.Commits {
+		if i >= baseIdx {
+			break
+		}
+		if commit.IsMerge() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (gui *Gui) interactiveRebaseRange(startIdx int, endIdx int, action todo.TodoCommand, rebaseMerges bool) error {
+	if rebaseMerges {
+		return gui.Git.Rebase.InteractiveRebaseMergesRange(gui.State.Commits, startIdx, endIdx, action)
+	}
+
+	return gui.Git.Rebase.InteractiveRebaseRange(gui.State.Commits, startIdx, endIdx, action)
+}
+
 func (gui *Gui) handleCommitDelete() error {
 	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
 		return err
 	}
 
-	applied, err := gui.handleMidRebaseCommand("drop")
+	applied, err := gui.handleMidRebaseCommand(todo.Drop)
 	if err != nil {
 		return err
 	}
@@ -321,14 +479,26 @@ func (gui *Gui) handleCommitDelete() error {
 		return nil
 	}
 
+	prompt := gui.Tr.DeleteCommitPrompt
+	if gui.State.Panels.Commits.IsSelectingRange() {
+		prompt = gui.Tr.DeleteSelectedCommitsPrompt
+	}
+
+	startIdx, endIdx := gui.State.Panels.Commits.GetSelectedRange()
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.DeleteCommitTitle,
-		prompt: gui.Tr.DeleteCommitPrompt,
+		prompt: prompt,
 		handleConfirm: func() error {
-			return gui.WithWaitingStatus(gui.Tr.DeletingStatus, func() error {
-				gui.logAction(gui.Tr.Actions.DropCommit)
-				err := gui.Git.Rebase.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "drop")
-				return gui.handleGenericMergeCommandResult(err)
+			return gui.withRebaseMergesChoice(endIdx+1, func(rebaseMerges bool) error {
+				return gui.WithWaitingStatus(gui.Tr.DeletingStatus, func() error {
+					gui.logAction(gui.Tr.Actions.DropCommit)
+					err := gui.interactiveRebaseRange(startIdx, endIdx, todo.Drop, rebaseMerges)
+					if err == nil {
+						gui.State.Panels.Commits.CancelRangeSelect()
+					}
+					return gui.handleGenericMergeCommandResult(err)
+				})
 			})
 		},
 	})
@@ -339,32 +509,40 @@ func (gui *Gui) handleCommitMoveDown() error {
 		return err
 	}
 
-	index := gui.State.Panels.Commits.SelectedLineIdx
-	selectedCommit := gui.State.Commits[index]
-	if selectedCommit.Status == "rebasing" {
-		if gui.State.Commits[index+1].Status != "rebasing" {
+	startIdx, endIdx := gui.State.Panels.Commits.GetSelectedRange()
+	if gui.State.Commits[startIdx].Status == "rebasing" {
+		if endIdx+1 >= len(gui.State.Commits) || gui.State.Commits[endIdx+1].Status != "rebasing" {
 			return nil
 		}
 
 		// logging directly here because MoveTodoDown doesn't have enough information
 		// to provide a useful log
 		gui.logAction(gui.Tr.Actions.MoveCommitDown)
-		gui.logCommand(fmt.Sprintf("Moving commit %s down", selectedCommit.ShortSha()), false)
+		for index := endIdx; index >= startIdx; index-- {
+			gui.logCommand(fmt.Sprintf("Moving commit %s down", gui.State.Commits[index].ShortSha()), false)
 
-		if err := gui.Git.Rebase.MoveTodoDown(index); err != nil {
-			return gui.surfaceError(err)
+			if err := gui.Git.Rebase.MoveTodoDown(index); err != nil {
+				return gui.surfaceError(err)
+			}
 		}
-		gui.State.Panels.Commits.SelectedLineIdx++
+		gui.State.Panels.Commits.ShiftSelection(1)
 		return gui.refreshRebaseCommits()
 	}
 
-	return gui.WithWaitingStatus(gui.Tr.MovingStatus, func() error {
-		gui.logAction(gui.Tr.Actions.MoveCommitDown)
-		err := gui.Git.Rebase.MoveCommitDown(gui.State.Commits, index)
-		if err == nil {
-			gui.State.Panels.Commits.SelectedLineIdx++
-		}
-		return gui.handleGenericMergeCommandResult(err)
+	return gui.withRebaseMergesChoice(endIdx+2, func(rebaseMerges bool) error {
+		return gui.WithWaitingStatus(gui.Tr.MovingStatus, func() error {
+			gui.logAction(gui.Tr.Actions.MoveCommitDown)
+			var err error
+			if rebaseMerges {
+				err = gui.Git.Rebase.MoveCommitsDownRebasingMerges(gui.State.Commits, startIdx, endIdx)
+			} else {
+				err = gui.Git.Rebase.MoveCommitsDown(gui.State.Commits, startIdx, endIdx)
+			}
+			if err == nil {
+				gui.State.Panels.Commits.ShiftSelection(1)
+			}
+			return gui.handleGenericMergeCommandResult(err)
+		})
 	})
 }
 
@@ -373,35 +551,47 @@ func (gui *Gui) handleCommitMoveUp() error {
 		return err
 	}
 
-	index := gui.State.Panels.Commits.SelectedLineIdx
-	if index == 0 {
+	startIdx, endIdx := gui.State.Panels.Commits.GetSelectedRange()
+	if startIdx == 0 {
 		return nil
 	}
 
-	selectedCommit := gui.State.Commits[index]
-	if selectedCommit.Status == "rebasing" {
+	if gui.State.Commits[startIdx].Status == "rebasing" {
+		if gui.State.Commits[endIdx].Status != "rebasing" {
+			return nil
+		}
+
 		// logging directly here because MoveTodoDown doesn't have enough information
 		// to provide a useful log
 		gui.logAction(gui.Tr.Actions.MoveCommitUp)
-		gui.logCommand(
-			fmt.Sprintf("Moving commit %s up", selectedCommit.ShortSha()),
-			false,
-		)
+		for index := startIdx; index <= endIdx; index++ {
+			gui.logCommand(
+				fmt.Sprintf("Moving commit %s up", gui.State.Commits[index].ShortSha()),
+				false,
+			)
 
-		if err := gui.Git.Rebase.MoveTodoDown(index - 1); err != nil {
-			return gui.surfaceError(err)
+			if err := gui.Git.Rebase.MoveTodoDown(index - 1); err != nil {
+				return gui.surfaceError(err)
+			}
 		}
-		gui.State.Panels.Commits.SelectedLineIdx--
+		gui.State.Panels.Commits.ShiftSelection(-1)
 		return gui.refreshRebaseCommits()
 	}
 
-	return gui.WithWaitingStatus(gui.Tr.MovingStatus, func() error {
-		gui.logAction(gui.Tr.Actions.MoveCommitUp)
-		err := gui.Git.Rebase.MoveCommitDown(gui.State.Commits, index-1)
-		if err == nil {
-			gui.State.Panels.Commits.SelectedLineIdx--
-		}
-		return gui.handleGenericMergeCommandResult(err)
+	return gui.withRebaseMergesChoice(endIdx+1, func(rebaseMerges bool) error {
+		return gui.WithWaitingStatus(gui.Tr.MovingStatus, func() error {
+			gui.logAction(gui.Tr.Actions.MoveCommitUp)
+			var err error
+			if rebaseMerges {
+				err = gui.Git.Rebase.MoveCommitsUpRebasingMerges(gui.State.Commits, startIdx, endIdx)
+			} else {
+				err = gui.Git.Rebase.MoveCommitsUp(gui.State.Commits, startIdx, endIdx)
+			}
+			if err == nil {
+				gui.State.Panels.Commits.ShiftSelection(-1)
+			}
+			return gui.handleGenericMergeCommandResult(err)
+		})
 	})
 }
 
@@ -410,7 +600,7 @@ func (gui *Gui) handleCommitEdit() error {
 		return err
 	}
 
-	applied, err := gui.handleMidRebaseCommand("edit")
+	applied, err := gui.handleMidRebaseCommand(todo.Edit)
 	if err != nil {
 		return err
 	}
@@ -418,10 +608,14 @@ func (gui *Gui) handleCommitEdit() error {
 		return nil
 	}
 
-	return gui.WithWaitingStatus(gui.Tr.RebasingStatus, func() error {
-		gui.logAction(gui.Tr.Actions.EditCommit)
-		err = gui.Git.Rebase.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "edit")
-		return gui.handleGenericMergeCommandResult(err)
+	selectedIdx := gui.State.Panels.Commits.SelectedLineIdx
+
+	return gui.withRebaseMergesChoice(selectedIdx+1, func(rebaseMerges bool) error {
+		return gui.WithWaitingStatus(gui.Tr.RebasingStatus, func() error {
+			gui.logAction(gui.Tr.Actions.EditCommit)
+			err := gui.interactiveRebaseRange(selectedIdx, selectedIdx, todo.Edit, rebaseMerges)
+			return gui.handleGenericMergeCommandResult(err)
+		})
 	})
 }
 
@@ -448,7 +642,7 @@ func (gui *Gui) handleCommitPick() error {
 		return err
 	}
 
-	applied, err := gui.handleMidRebaseCommand("pick")
+	applied, err := gui.handleMidRebaseCommand(todo.Pick)
 	if err != nil {
 		return err
 	}
@@ -461,6 +655,64 @@ func (gui *Gui) handleCommitPick() error {
 	return gui.handlePullFiles()
 }
 
+func (gui *Gui) handleInsertExecTodo() error {
+	return gui.withInsertableTodoIndex(func(index int) error {
+		return gui.prompt(promptOpts{
+			title: gui.Tr.ExecTodoPrompt,
+			handleConfirm: func(command string) error {
+				gui.logAction(gui.Tr.Actions.InsertExecTodo)
+				return gui.insertTodo(index, todo.Todo{Command: todo.Exec, ExecCommand: command})
+			},
+		})
+	})
+}
+
+func (gui *Gui) handleInsertBreakTodo() error {
+	return gui.withInsertableTodoIndex(func(index int) error {
+		gui.logAction(gui.Tr.Actions.InsertBreakTodo)
+		return gui.insertTodo(index, todo.Todo{Command: todo.Break})
+	})
+}
+
+// withInsertableTodoIndex ensures we're mid interactive rebase and that the
+// selected commit is either a rebasing commit or the commit currently being
+// rebased, in which case the new todo will be run next
+func (gui *Gui) withInsertableTodoIndex(f func(index int) error) error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	rebaseMode, err := gui.Git.Status.RebaseMode()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	rebasingCount := 0
+	for _, commit := range gui.State.Commits {
+		if commit.Status != "rebasing" {
+			break
+		}
+		rebasingCount++
+	}
+
+	index := gui.State.Panels.Commits.SelectedLineIdx
+	if rebaseMode != enums.REBASE_MODE_INTERACTIVE || index > rebasingCount {
+		return gui.createErrorPanel(gui.Tr.MustBeMidInteractiveRebase)
+	}
+
+	return f(index)
+}
+
+func (gui *Gui) insertTodo(index int, newTodo todo.Todo) error {
+	gui.logCommand(fmt.Sprintf("Adding '%s' to rebase todo", newTodo.String()), false)
+
+	if err := gui.Git.Rebase.InsertTodo(index, newTodo); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshRebaseCommits()
+}
+
 func (gui *Gui) handleCommitRevert() error {
 	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
 		return err
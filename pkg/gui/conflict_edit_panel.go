@@ -0,0 +1,182 @@
+package gui
+
+import (
+	"io/ioutil"
+	"strings"
+)
+
+// the conflict edit panel lets the user resolve the selected conflict by picking
+// lines from either side of it, in the order they want them to appear
+
+func (gui *Gui) handleEditConflict() error {
+	err := gui.withMergeConflictLock(func() error {
+		cat, err := gui.catSelectedFile()
+		if err != nil {
+			return err
+		}
+
+		gui.State.Panels.Merging.SetConflictsFromCat(cat)
+		gui.State.Panels.Merging.Edit = gui.State.Panels.Merging.NewConflictEdit(cat)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if gui.State.Panels.Merging.Edit == nil {
+		return nil
+	}
+
+	return gui.pushContext(gui.State.Contexts.ConflictEdit)
+}
+
+func (gui *Gui) refreshConflictEditWithLock() error {
+	return gui.withMergeConflictLock(gui.refreshConflictEdit)
+}
+
+func (gui *Gui) refreshConflictEdit() error {
+	edit := gui.State.Panels.Merging.Edit
+	if edit == nil {
+		return nil
+	}
+
+	gui.OnUIThread(func() error {
+		gui.centerYPos(gui.Views.Main, edit.GetSelectedLineIdx())
+		return nil
+	})
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title:  gui.Tr.ConflictEditTitle,
+			task:   NewRenderStringWithoutScrollTask(edit.Render(true)),
+			noWrap: true,
+		},
+		secondary: &viewUpdateOpts{
+			title:  gui.Tr.ConflictEditResultTitle,
+			task:   NewRenderStringWithoutScrollTask(strings.Join(edit.Result(), "\n")),
+			noWrap: true,
+		},
+	})
+}
+
+func (gui *Gui) withConflictEdit(f func() error) error {
+	return gui.withMergeConflictLock(func() error {
+		if gui.State.Panels.Merging.Edit == nil {
+			return nil
+		}
+
+		if err := f(); err != nil {
+			return err
+		}
+
+		return gui.refreshConflictEdit()
+	})
+}
+
+func (gui *Gui) handleConflictEditPrevLine() error {
+	return gui.withConflictEdit(func() error {
+		gui.State.Panels.Merging.Edit.SelectPrevLine()
+		return nil
+	})
+}
+
+func (gui *Gui) handleConflictEditNextLine() error {
+	return gui.withConflictEdit(func() error {
+		gui.State.Panels.Merging.Edit.SelectNextLine()
+		return nil
+	})
+}
+
+func (gui *Gui) handleConflictEditTogglePicked() error {
+	return gui.withConflictEdit(func() error {
+		gui.State.Panels.Merging.Edit.TogglePicked()
+		return nil
+	})
+}
+
+func (gui *Gui) handleConflictEditUnpickLast() error {
+	return gui.withConflictEdit(func() error {
+		gui.State.Panels.Merging.Edit.UnpickLast()
+		return nil
+	})
+}
+
+func (gui *Gui) handleConflictEditEscape() error {
+	gui.State.Panels.Merging.Edit = nil
+
+	return gui.returnFromContext()
+}
+
+func (gui *Gui) handleApplyConflictEdit() error {
+	nothingPicked := false
+	_ = gui.withMergeConflictLock(func() error {
+		edit := gui.State.Panels.Merging.Edit
+		nothingPicked = edit != nil && len(edit.Result()) == 0
+		return nil
+	})
+
+	if nothingPicked {
+		// more likely than not the user has hit enter before picking anything
+		return gui.ask(askOpts{
+			title:         gui.Tr.ApplyEmptyConflictEditTitle,
+			prompt:        gui.Tr.ApplyEmptyConflictEditPrompt,
+			handleConfirm: gui.applyConflictEdit,
+		})
+	}
+
+	return gui.applyConflictEdit()
+}
+
+func (gui *Gui) applyConflictEdit() error {
+	isFinalConflict := false
+	ok := false
+	err := gui.withMergeConflictLock(func() error {
+		edit := gui.State.Panels.Merging.Edit
+		gitFile := gui.getSelectedFile()
+		if edit == nil || gitFile == nil {
+			return nil
+		}
+
+		var output string
+		var err error
+		ok, output, err = gui.State.Panels.Merging.ContentAfterConflictEdit(gitFile.Name, edit.ResultContent())
+		if err != nil || !ok {
+			return err
+		}
+
+		if err := gui.pushFileSnapshot(); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		gui.logAction(gui.Tr.Actions.ResolveMergeConflict)
+		gui.logCommand("Combining lines from both sides", false)
+		if err := ioutil.WriteFile(gitFile.Name, []byte(output), 0644); err != nil {
+			return err
+		}
+
+		isFinalConflict = gui.State.Panels.Merging.IsFinalConflict()
+		gui.State.Panels.Merging.Edit = nil
+		return nil
+	})
+	if err != nil || !ok {
+		return err
+	}
+
+	if isFinalConflict {
+		return gui.handleCompleteMerge()
+	}
+
+	// returning to the merging context re-renders the remaining conflicts
+	return gui.returnFromContext()
+}
+
+func (gui *Gui) getConflictEditOptions() map[string]string {
+	keybindingConfig := gui.UserConfig.Keybinding
+
+	return map[string]string{
+		gui.getKeyDisplay(keybindingConfig.Universal.Select):  gui.Tr.LcPickLine,
+		gui.getKeyDisplay(keybindingConfig.Universal.Undo):    gui.Tr.LcUnpickLastLine,
+		gui.getKeyDisplay(keybindingConfig.Universal.Confirm): gui.Tr.LcApplyConflictEdit,
+		gui.getKeyDisplay(keybindingConfig.Universal.Return):  gui.Tr.LcCancel,
+	}
+}
@@ -381,7 +381,10 @@ func (gui *Gui) onViewFocusChange() error {
 
 	currentView := gui.g.CurrentView()
 	for _, view := range gui.g.Views() {
-		view.Highlight = view.Name() != "main" && view.Name() != "extras" && view == currentView
+		// the main view is usually just for viewing content, unless we're blaming
+		// in which case we're selecting lines like any other list
+		mainViewIsList := view.Name() == "main" && ContextKey(view.Context) == BLAME_CONTEXT_KEY
+		view.Highlight = (view.Name() != "main" || mainViewIsList) && view.Name() != "extras" && view == currentView
 	}
 	return nil
 }
@@ -413,7 +416,7 @@ func (gui *Gui) changeMainViewsContext(contextKey ContextKey) {
 	}
 
 	switch contextKey {
-	case MAIN_NORMAL_CONTEXT_KEY, MAIN_PATCH_BUILDING_CONTEXT_KEY, MAIN_STAGING_CONTEXT_KEY, MAIN_MERGING_CONTEXT_KEY:
+	case MAIN_NORMAL_CONTEXT_KEY, MAIN_PATCH_BUILDING_CONTEXT_KEY, MAIN_STAGING_CONTEXT_KEY, MAIN_MERGING_CONTEXT_KEY, MAIN_CONFLICT_EDIT_CONTEXT_KEY, BLAME_CONTEXT_KEY:
 		gui.Views.Main.Context = string(contextKey)
 		gui.Views.Secondary.Context = string(contextKey)
 	default:
@@ -9,6 +9,7 @@ const (
 	REMOTES_CONTEXT_KEY             ContextKey = "remotes"
 	REMOTE_BRANCHES_CONTEXT_KEY     ContextKey = "remoteBranches"
 	TAGS_CONTEXT_KEY                ContextKey = "tags"
+	WORKTREES_CONTEXT_KEY           ContextKey = "worktrees"
 	BRANCH_COMMITS_CONTEXT_KEY      ContextKey = "commits"
 	REFLOG_COMMITS_CONTEXT_KEY      ContextKey = "reflogCommits"
 	SUB_COMMITS_CONTEXT_KEY         ContextKey = "subCommits"
@@ -16,8 +17,10 @@ const (
 	STASH_CONTEXT_KEY               ContextKey = "stash"
 	MAIN_NORMAL_CONTEXT_KEY         ContextKey = "normal"
 	MAIN_MERGING_CONTEXT_KEY        ContextKey = "merging"
+	MAIN_CONFLICT_EDIT_CONTEXT_KEY  ContextKey = "conflictEdit"
 	MAIN_PATCH_BUILDING_CONTEXT_KEY ContextKey = "patchBuilding"
 	MAIN_STAGING_CONTEXT_KEY        ContextKey = "staging"
+	BLAME_CONTEXT_KEY               ContextKey = "blame"
 	MENU_CONTEXT_KEY                ContextKey = "menu"
 	CREDENTIALS_CONTEXT_KEY         ContextKey = "credentials"
 	CONFIRMATION_CONTEXT_KEY        ContextKey = "confirmation"
@@ -35,6 +38,7 @@ var allContextKeys = []ContextKey{
 	REMOTES_CONTEXT_KEY,
 	REMOTE_BRANCHES_CONTEXT_KEY,
 	TAGS_CONTEXT_KEY,
+	WORKTREES_CONTEXT_KEY,
 	BRANCH_COMMITS_CONTEXT_KEY,
 	REFLOG_COMMITS_CONTEXT_KEY,
 	SUB_COMMITS_CONTEXT_KEY,
@@ -42,8 +46,10 @@ var allContextKeys = []ContextKey{
 	STASH_CONTEXT_KEY,
 	MAIN_NORMAL_CONTEXT_KEY,
 	MAIN_MERGING_CONTEXT_KEY,
+	MAIN_CONFLICT_EDIT_CONTEXT_KEY,
 	MAIN_PATCH_BUILDING_CONTEXT_KEY,
 	MAIN_STAGING_CONTEXT_KEY,
+	BLAME_CONTEXT_KEY,
 	MENU_CONTEXT_KEY,
 	CREDENTIALS_CONTEXT_KEY,
 	CONFIRMATION_CONTEXT_KEY,
@@ -63,6 +69,7 @@ type ContextTree struct {
 	Remotes        IListContext
 	RemoteBranches IListContext
 	Tags           IListContext
+	Worktrees      IListContext
 	BranchCommits  IListContext
 	CommitFiles    IListContext
 	ReflogCommits  IListContext
@@ -73,6 +80,8 @@ type ContextTree struct {
 	Staging        Context
 	PatchBuilding  Context
 	Merging        Context
+	ConflictEdit   Context
+	Blame          IListContext
 	Credentials    Context
 	Confirmation   Context
 	CommitMessage  Context
@@ -89,6 +98,7 @@ func (gui *Gui) allContexts() []Context {
 		gui.State.Contexts.Remotes,
 		gui.State.Contexts.RemoteBranches,
 		gui.State.Contexts.Tags,
+		gui.State.Contexts.Worktrees,
 		gui.State.Contexts.BranchCommits,
 		gui.State.Contexts.CommitFiles,
 		gui.State.Contexts.ReflogCommits,
@@ -100,7 +110,9 @@ func (gui *Gui) allContexts() []Context {
 		gui.State.Contexts.Normal,
 		gui.State.Contexts.Staging,
 		gui.State.Contexts.Merging,
+		gui.State.Contexts.ConflictEdit,
 		gui.State.Contexts.PatchBuilding,
+		gui.State.Contexts.Blame,
 		gui.State.Contexts.SubCommits,
 		gui.State.Contexts.Suggestions,
 		gui.State.Contexts.CommandLog,
@@ -126,6 +138,7 @@ func (gui *Gui) contextTree() ContextTree {
 		SubCommits:     gui.subCommitsListContext(),
 		Branches:       gui.branchesListContext(),
 		Tags:           gui.tagsListContext(),
+		Worktrees:      gui.worktreesListContext(),
 		Stash:          gui.stashListContext(),
 		Normal: &BasicContext{
 			OnFocus: func(opts ...OnFocusOpts) error {
@@ -173,6 +186,14 @@ func (gui *Gui) contextTree() ContextTree {
 			Key:             MAIN_MERGING_CONTEXT_KEY,
 			OnGetOptionsMap: gui.getMergingOptions,
 		},
+		ConflictEdit: &BasicContext{
+			OnFocus:         OnFocusWrapper(gui.refreshConflictEditWithLock),
+			Kind:            MAIN_CONTEXT,
+			ViewName:        "main",
+			Key:             MAIN_CONFLICT_EDIT_CONTEXT_KEY,
+			OnGetOptionsMap: gui.getConflictEditOptions,
+		},
+		Blame: gui.blameListContext(),
 		Credentials: &BasicContext{
 			OnFocus:  OnFocusWrapper(gui.handleCredentialsViewFocused),
 			Kind:     PERSISTENT_POPUP,
@@ -253,6 +274,10 @@ func (tree ContextTree) initialViewTabContextMap() map[string][]tabContext {
 				tab:      "Tags",
 				contexts: []Context{tree.Tags},
 			},
+			{
+				tab:      "Worktrees",
+				contexts: []Context{tree.Worktrees},
+			},
 		},
 		"commits": {
 			{
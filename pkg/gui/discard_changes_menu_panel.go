@@ -1,6 +1,10 @@
 package gui
 
 func (gui *Gui) handleCreateDiscardMenu() error {
+	if gui.State.Panels.Files.IsSelectingRange() {
+		return gui.createDiscardRangeMenu()
+	}
+
 	node := gui.getSelectedFileNode()
 	if node == nil {
 		return nil
@@ -81,3 +85,64 @@ func (gui *Gui) handleCreateDiscardMenu() error {
 
 	return gui.createMenu(node.GetPath(), menuItems, createMenuOptions{showCancel: true})
 }
+
+func (gui *Gui) createDiscardRangeMenu() error {
+	nodes := gui.getSelectedFileNodes()
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.LcDiscardAllChanges,
+			onPress: func() error {
+				gui.logAction(gui.Tr.Actions.DiscardAllChangesInSelection)
+				for _, node := range nodes {
+					if err := gui.Git.WorkingTree.DiscardAllDirChanges(node); err != nil {
+						return gui.surfaceError(err)
+					}
+				}
+				gui.State.Panels.Files.CancelRangeSelect()
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{FILES}})
+			},
+		},
+	}
+
+	anyStagedAndUnstaged := false
+	for _, node := range nodes {
+		if node.GetHasStagedChanges() && node.GetHasUnstagedChanges() {
+			anyStagedAndUnstaged = true
+		}
+	}
+
+	if anyStagedAndUnstaged {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.LcDiscardUnstagedChanges,
+			onPress: func() error {
+				gui.logAction(gui.Tr.Actions.DiscardUnstagedChangesInSelection)
+				for _, node := range nodes {
+					if !node.GetHasUnstagedChanges() {
+						continue
+					}
+
+					var err error
+					if !node.IsLeaf() {
+						err = gui.Git.WorkingTree.DiscardUnstagedDirChanges(node)
+					} else if node.File.Tracked {
+						err = gui.Git.WorkingTree.DiscardUnstagedFileChanges(node.File)
+					} else {
+						// all of an untracked file's changes are unstaged
+						err = gui.Git.WorkingTree.DiscardAllFileChanges(node.File)
+					}
+					if err != nil {
+						return gui.surfaceError(err)
+					}
+				}
+
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{FILES}})
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.DiscardSelectedFilesTitle, menuItems, createMenuOptions{showCancel: true})
+}
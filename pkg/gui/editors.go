@@ -66,6 +66,9 @@ func (gui *Gui) commitMessageEditor(v *gocui.View, key gocui.Key, ch rune, mod g
 	}
 	v.RenderTextArea()
 	gui.RenderCommitLength()
+	if err := gui.renderCommitMessageOptions(); err != nil {
+		gui.Log.Error(err)
+	}
 
 	return matched
 }
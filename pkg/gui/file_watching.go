@@ -3,129 +3,560 @@ package gui
 import (
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
-	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/sirupsen/logrus"
 )
 
-// macs for some bizarre reason cap the number of watchable files to 256.
-// there's no obvious platform agnostic way to check the situation of the user's
-// computer so we're just arbitrarily capping at 200. This isn't so bad because
-// file watching is only really an added bonus for faster refreshing.
-const MAX_WATCHED_FILES = 50
+// we wait this long after the first event before refreshing so that a burst of
+// events (e.g. from a checkout touching hundreds of files) results in a single
+// refresh
+const FILE_WATCHER_DEBOUNCE_DURATION = 200 * time.Millisecond
 
+// We watch the working tree recursively (skipping ignored directories) as well
+// as the parts of the .git directory that tell us about HEAD, the index, refs,
+// and any in-progress rebase or merge. In a linked worktree the refs live in
+// the common git directory shared by all worktrees, so we watch those too. If
+// we can't watch everything (e.g. because we've hit the OS's limit on watches)
+// we say we're degraded and the gui falls back to polling.
 type fileWatcher struct {
-	Watcher          *fsnotify.Watcher
-	WatchedFilenames []string
-	Log              *logrus.Entry
-	Disabled         bool
+	Watcher  *fsnotify.Watcher
+	Log      *logrus.Entry
+	Disabled bool
+
+	onChange func([]RefreshableView)
+	// returns the ignored files and directories (with a trailing slash) beneath
+	// the given paths, or the whole repo if none are given, relative to the repo
+	getIgnoredPaths  func(paths ...string) ([]string, error)
+	debounceDuration time.Duration
+
+	mutex     sync.Mutex
+	repoDir   string
+	dotGitDir string
+	// the git directory shared by all worktrees. Same as dotGitDir unless we're
+	// in a linked worktree.
+	commonGitDir string
+	// ignored paths relative to the repo, with forward slashes and no trailing
+	// slash for directories
+	ignored  map[string]bool
+	watched  map[string]bool
+	degraded bool
+	// incremented whenever we start watching a repo, so that a walk of the
+	// previous repo knows to stop adding watches
+	generation int
+
+	pendingScopes map[RefreshableView]bool
+	timer         *time.Timer
 }
 
-func NewFileWatcher(log *logrus.Entry) *fileWatcher {
-	// TODO: get this going again, and ensure we don't see any crashes from it
-	return &fileWatcher{
-		Disabled: true,
+func NewFileWatcher(
+	log *logrus.Entry,
+	enabled bool,
+	onChange func([]RefreshableView),
+	getIgnoredPaths func(paths ...string) ([]string, error),
+) *fileWatcher {
+	if !enabled {
+		return &fileWatcher{Disabled: true}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Error(err)
+		return &fileWatcher{Disabled: true}
 	}
+
+	w := &fileWatcher{
+		Watcher:          watcher,
+		Log:              log,
+		onChange:         onChange,
+		getIgnoredPaths:  getIgnoredPaths,
+		debounceDuration: FILE_WATCHER_DEBOUNCE_DURATION,
+		watched:          map[string]bool{},
+		ignored:          map[string]bool{},
+		pendingScopes:    map[RefreshableView]bool{},
+	}
+
+	go utils.Safe(w.listen)
+
+	return w
 }
 
-func (w *fileWatcher) watchingFilename(filename string) bool {
-	for _, watchedFilename := range w.WatchedFilenames {
-		if watchedFilename == filename {
-			return true
+// isWatching tells us whether we can rely on the watcher to tell us about
+// changes, or whether we need to poll
+func (w *fileWatcher) isWatching() bool {
+	if w.Disabled {
+		return false
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return !w.degraded
+}
+
+// watchRepo drops any existing watches and watches the given repo instead,
+// which is expected to be the current directory. Walking a large working tree
+// can take a while so this should be called off the UI thread.
+func (w *fileWatcher) watchRepo(repoDir string, dotGitDir string, commonGitDir string) {
+	if w.Disabled {
+		return
+	}
+
+	absDotGitDir, err := filepath.Abs(dotGitDir)
+	if err != nil {
+		w.Log.Error(err)
+		return
+	}
+	absCommonGitDir, err := filepath.Abs(commonGitDir)
+	if err != nil {
+		w.Log.Error(err)
+		return
+	}
+	// we ask git once up front so that we respect all of its exclude rules
+	// (.gitignore files, .git/info/exclude and core.excludesFile)
+	ignored := map[string]bool{}
+	if ignoredPaths, err := w.getIgnoredPaths(); err != nil {
+		// we can still watch without ignoring anything, it'll just be noisier
+		w.Log.Error(err)
+	} else {
+		addIgnoredPaths(ignored, ignoredPaths)
+	}
+
+	w.mutex.Lock()
+	for path := range w.watched {
+		// swallowing errors here because the path may well have been deleted
+		_ = w.Watcher.Remove(path)
+	}
+	w.watched = map[string]bool{}
+	w.repoDir = repoDir
+	w.dotGitDir = absDotGitDir
+	w.commonGitDir = absCommonGitDir
+	w.ignored = ignored
+	w.degraded = false
+	w.generation++
+	generation := w.generation
+	w.mutex.Unlock()
+
+	if !w.add(generation, absDotGitDir) {
+		return
+	}
+	for _, dir := range []string{"refs", "rebase-merge", "rebase-apply"} {
+		if !w.addRecursive(generation, filepath.Join(absDotGitDir, dir)) {
+			return
 		}
 	}
-	return false
+	if absCommonGitDir != absDotGitDir {
+		// for the sake of packed-refs
+		if !w.add(generation, absCommonGitDir) {
+			return
+		}
+		if !w.addRecursive(generation, filepath.Join(absCommonGitDir, "refs")) {
+			return
+		}
+	}
+	w.addRecursive(generation, repoDir)
 }
 
-func (w *fileWatcher) popOldestFilename() {
-	// shift the last off the array to make way for this one
-	oldestFilename := w.WatchedFilenames[0]
-	w.WatchedFilenames = w.WatchedFilenames[1:]
-	if err := w.Watcher.Remove(oldestFilename); err != nil {
-		// swallowing errors here because it doesn't really matter if we can't unwatch a file
+// addRecursive watches the given directory and all non-ignored directories
+// beneath it. It returns false if we should stop trying to add watches.
+func (w *fileWatcher) addRecursive(generation int, root string) bool {
+	if _, err := os.Stat(root); err != nil {
+		return true
+	}
+
+	w.mutex.Lock()
+	repoDir := w.repoDir
+	dotGitDir := w.dotGitDir
+	commonGitDir := w.commonGitDir
+	w.mutex.Unlock()
+
+	inGitDir := isGitPath(dotGitDir, commonGitDir, root)
+
+	stopped := false
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// the directory may have been deleted or we may not have permission
+			// to read it: either way we just skip it
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if !inGitDir {
+			if path == dotGitDir || path == commonGitDir {
+				// we only watch select parts of the git directory and we'd
+				// otherwise end up watching every directory in .git/objects
+				return filepath.SkipDir
+			}
+			if w.isIgnored(repoDir, path) {
+				return filepath.SkipDir
+			}
+		}
+		if !w.add(generation, path) {
+			stopped = true
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
 		w.Log.Error(err)
 	}
+
+	return !stopped
 }
 
-func (w *fileWatcher) watchFilename(filename string) {
-	if err := w.Watcher.Add(filename); err != nil {
-		// swallowing errors here because it doesn't really matter if we can't watch a file
+// add watches a single directory. It returns false if we should stop trying to
+// add watches, either because we've moved on to another repo or because we've
+// hit the limit on watches.
+func (w *fileWatcher) add(generation int, path string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if generation != w.generation || w.degraded {
+		return false
+	}
+
+	if w.watched[path] {
+		return true
+	}
+
+	if err := w.Watcher.Add(path); err != nil {
+		if isWatchLimitError(err) {
+			w.Log.Warnf("unable to watch %s (%s), falling back to polling for changes", path, err.Error())
+			w.degraded = true
+			return false
+		}
+		// swallowing errors here because it doesn't really matter if we can't watch a directory
 		w.Log.Error(err)
+		return true
 	}
 
-	// assume we're watching it now to be safe
-	w.WatchedFilenames = append(w.WatchedFilenames, filename)
+	w.watched[path] = true
+	return true
 }
 
-func (w *fileWatcher) addFilesToFileWatcher(files []*models.File) error {
-	if w.Disabled {
-		return nil
+// forget stops watching the given directory and any directories beneath it, so
+// that we watch them afresh if they're recreated. Must be called with the mutex
+// held.
+func (w *fileWatcher) forget(path string) {
+	for watchedPath := range w.watched {
+		if watchedPath == path || isSubpath(path, watchedPath) {
+			// swallowing errors here because the OS will typically have already
+			// dropped the watch
+			_ = w.Watcher.Remove(watchedPath)
+			delete(w.watched, watchedPath)
+		}
+	}
+}
+
+// isIgnored tells us whether git ignores the given path or any directory it's in
+func (w *fileWatcher) isIgnored(repoDir string, path string) bool {
+	relPath, ok := relativePath(repoDir, path)
+	if !ok {
+		return false
 	}
 
-	if len(files) == 0 {
-		return nil
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for ; relPath != "."; relPath = filepath.ToSlash(filepath.Dir(relPath)) {
+		if w.ignored[relPath] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadIgnoredPaths asks git which paths beneath a newly created directory are
+// ignored, given that they weren't around when we started watching (think
+// node_modules after an npm install). It returns false if the directory itself
+// is ignored or we've moved on to another repo.
+func (w *fileWatcher) loadIgnoredPaths(generation int, repoDir string, dir string) bool {
+	relPath, ok := relativePath(repoDir, dir)
+	if !ok {
+		return true
 	}
 
-	// watch the files for changes
-	dirName, err := os.Getwd()
+	ignoredPaths, err := w.getIgnoredPaths(relPath)
 	if err != nil {
-		return err
+		w.Log.Error(err)
+		return true
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if generation != w.generation {
+		return false
+	}
+	addIgnoredPaths(w.ignored, ignoredPaths)
+
+	return !w.ignored[relPath]
+}
+
+// addIgnoredPaths adds the paths, as returned by git, to the given set
+func addIgnoredPaths(ignored map[string]bool, paths []string) {
+	for _, path := range paths {
+		ignored[strings.TrimSuffix(filepath.ToSlash(path), "/")] = true
 	}
+}
 
-	for _, file := range files[0:min(MAX_WATCHED_FILES, len(files))] {
-		if file.Deleted {
-			continue
+func isWatchLimitError(err error) bool {
+	return err == syscall.ENOSPC || err == syscall.EMFILE
+}
+
+func (w *fileWatcher) listen() {
+	for {
+		select {
+		case event, ok := <-w.Watcher.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(event)
+
+		case err, ok := <-w.Watcher.Errors:
+			if !ok {
+				return
+			}
+			if err != nil {
+				w.Log.Error(err)
+			}
+		}
+	}
+}
+
+func (w *fileWatcher) handleEvent(event fsnotify.Event) {
+	if event.Op == fsnotify.Chmod {
+		// for some reason we pick up chmod events when they don't actually happen
+		return
+	}
+
+	w.mutex.Lock()
+	repoDir := w.repoDir
+	dotGitDir := w.dotGitDir
+	commonGitDir := w.commonGitDir
+	generation := w.generation
+	if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+		w.forget(event.Name)
+	}
+	w.mutex.Unlock()
+
+	inGitDir := isGitPath(dotGitDir, commonGitDir, event.Name)
+	if !inGitDir && w.isIgnored(repoDir, event.Name) {
+		return
+	}
+
+	if event.Op&fsnotify.Create != 0 && shouldWatchDir(repoDir, dotGitDir, commonGitDir, event.Name) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			go utils.Safe(func() {
+				if !inGitDir && !w.loadIgnoredPaths(generation, repoDir, event.Name) {
+					return
+				}
+				w.addRecursive(generation, event.Name)
+			})
+		}
+	}
+
+	w.queueRefresh(refreshScopesForPath(repoDir, dotGitDir, commonGitDir, event.Name))
+}
+
+// queueRefresh adds the given scopes to those we'll refresh once the debounce
+// duration has elapsed since the first queued event
+func (w *fileWatcher) queueRefresh(scopes []RefreshableView) {
+	if len(scopes) == 0 {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, scope := range scopes {
+		w.pendingScopes[scope] = true
+	}
+
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.debounceDuration, w.flush)
+	}
+}
+
+func (w *fileWatcher) flush() {
+	w.mutex.Lock()
+	scopes := []RefreshableView{}
+	for scope := COMMITS; scope <= BISECT_INFO; scope++ {
+		if w.pendingScopes[scope] {
+			scopes = append(scopes, scope)
 		}
-		filename := filepath.Join(dirName, file.Name)
-		if w.watchingFilename(filename) {
-			continue
+	}
+	w.pendingScopes = map[RefreshableView]bool{}
+	w.timer = nil
+	w.mutex.Unlock()
+
+	if len(scopes) > 0 {
+		w.onChange(scopes)
+	}
+}
+
+func (w *fileWatcher) close() {
+	if w.Disabled {
+		return
+	}
+
+	w.mutex.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mutex.Unlock()
+
+	w.Watcher.Close()
+}
+
+// shouldWatchDir tells us whether a newly created directory needs watching
+func shouldWatchDir(repoDir string, dotGitDir string, commonGitDir string, path string) bool {
+	if relPath, ok := relativePath(dotGitDir, path); ok {
+		for _, dir := range []string{"refs", "rebase-merge", "rebase-apply"} {
+			if relPath == dir || strings.HasPrefix(relPath, dir+"/") {
+				return true
+			}
 		}
-		if len(w.WatchedFilenames) > MAX_WATCHED_FILES {
-			w.popOldestFilename()
+		return false
+	}
+
+	if relPath, ok := relativePath(commonGitDir, path); ok {
+		return relPath == "refs" || strings.HasPrefix(relPath, "refs/")
+	}
+
+	return isSubpath(repoDir, path)
+}
+
+// refreshScopesForPath tells us what needs refreshing when the given path
+// changes. Changes in the working tree only affect the files panel, whereas
+// changes in the .git directory can affect pretty much anything.
+func refreshScopesForPath(repoDir string, dotGitDir string, commonGitDir string, path string) []RefreshableView {
+	if relPath, ok := relativePath(dotGitDir, path); ok {
+		return refreshScopesForGitPath(relPath)
+	}
+
+	// the common git directory also has the main worktree's HEAD and index,
+	// which don't concern us
+	if relPath, ok := relativePath(commonGitDir, path); ok {
+		if relPath == "packed-refs" || strings.HasPrefix(relPath, "refs/") {
+			return refreshScopesForGitPath(relPath)
 		}
+		return nil
+	}
 
-		w.watchFilename(filename)
+	if isSubpath(repoDir, path) {
+		return []RefreshableView{FILES}
 	}
 
 	return nil
 }
 
-func min(a int, b int) int {
-	if a < b {
-		return a
+// relPath is relative to the .git directory and uses forward slashes
+func refreshScopesForGitPath(relPath string) []RefreshableView {
+	if strings.HasSuffix(relPath, ".lock") {
+		// git writes to a lock file and then renames it so we'll hear about the
+		// real file soon enough
+		return nil
+	}
+
+	switch {
+	case relPath == "index":
+		return []RefreshableView{FILES}
+	case relPath == "HEAD":
+		return []RefreshableView{COMMITS, BRANCHES, FILES}
+	case relPath == "MERGE_HEAD", relPath == "CHERRY_PICK_HEAD", relPath == "REVERT_HEAD",
+		strings.HasPrefix(relPath, "rebase-merge"), strings.HasPrefix(relPath, "rebase-apply"):
+		return []RefreshableView{COMMITS, FILES}
+	case relPath == "packed-refs":
+		return []RefreshableView{COMMITS, BRANCHES, TAGS, REMOTES}
+	case relPath == "refs/stash":
+		return []RefreshableView{STASH}
+	case strings.HasPrefix(relPath, "refs/heads/"), strings.HasPrefix(relPath, "refs/bisect/"):
+		return []RefreshableView{COMMITS, BRANCHES}
+	case strings.HasPrefix(relPath, "refs/remotes/"):
+		return []RefreshableView{BRANCHES, REMOTES}
+	case strings.HasPrefix(relPath, "refs/tags/"):
+		return []RefreshableView{TAGS}
+	default:
+		// e.g. objects, logs, FETCH_HEAD, COMMIT_EDITMSG
+		return nil
+	}
+}
+
+// relativePath returns the path relative to dir, with forward slashes, if the
+// path is beneath dir
+func relativePath(dir string, path string) (string, bool) {
+	if dir == "" {
+		return "", false
 	}
-	return b
+
+	relPath, err := filepath.Rel(dir, path)
+	if err != nil || relPath == "." || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return filepath.ToSlash(relPath), true
+}
+
+func isSubpath(dir string, path string) bool {
+	_, ok := relativePath(dir, path)
+	return ok
+}
+
+// isGitPath tells us whether the path is, or is beneath, either git directory
+func isGitPath(dotGitDir string, commonGitDir string, path string) bool {
+	return path == dotGitDir || isSubpath(dotGitDir, path) ||
+		path == commonGitDir || isSubpath(commonGitDir, path)
 }
 
-// NOTE: given that we often edit files ourselves, this may make us end up refreshing files too often
-// TODO: consider watching the whole directory recursively (could be more expensive)
 func (gui *Gui) watchFilesForChanges() {
-	gui.fileWatcher = NewFileWatcher(gui.Log)
-	if gui.fileWatcher.Disabled {
+	gui.fileWatcher = NewFileWatcher(
+		gui.Log,
+		gui.UserConfig.Refresher.WatchFiles,
+		func(scopes []RefreshableView) {
+			if gui.PauseBackgroundThreads {
+				return
+			}
+			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: scopes})
+		},
+		func(paths ...string) ([]string, error) {
+			return gui.Git.WorkingTree.IgnoredPaths(paths...)
+		},
+	)
+}
+
+// watchRepoForChanges points the file watcher at the current repo. Called when
+// we start the gui and whenever we switch repos.
+func (gui *Gui) watchRepoForChanges() {
+	repoDir, err := os.Getwd()
+	if err != nil {
+		gui.Log.Error(err)
 		return
 	}
+	dotGitDir := gui.Git.Status.DotGitDir()
 	go utils.Safe(func() {
-		for {
-			select {
-			// watch for events
-			case event := <-gui.fileWatcher.Watcher.Events:
-				if event.Op == fsnotify.Chmod {
-					// for some reason we pick up chmod events when they don't actually happen
-					continue
-				}
-				// only refresh if we're not already
-				if !gui.State.IsRefreshingFiles {
-					_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{FILES}})
-				}
-
-			// watch for errors
-			case err := <-gui.fileWatcher.Watcher.Errors:
-				if err != nil {
-					gui.Log.Error(err)
-				}
-			}
+		commonGitDir, err := gui.Git.Status.CommonGitDir()
+		if err != nil {
+			gui.Log.Error(err)
+			commonGitDir = dotGitDir
 		}
+		gui.fileWatcher.watchRepo(repoDir, dotGitDir, commonGitDir)
 	})
 }
+
+// pollFilesAndSubmodules is our fallback for when we can't rely on the file
+// watcher
+func (gui *Gui) pollFilesAndSubmodules() error {
+	if gui.fileWatcher.isWatching() {
+		return nil
+	}
+
+	return gui.refreshFilesAndSubmodules()
+}
@@ -0,0 +1,181 @@
+package gui
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshScopesForPath(t *testing.T) {
+	repoDir := filepath.FromSlash("/repo")
+	dotGitDir := filepath.FromSlash("/repo/.git")
+
+	cases := []struct {
+		path     string
+		expected []RefreshableView
+	}{
+		{"/repo/main.go", []RefreshableView{FILES}},
+		{"/repo/pkg/gui/gui.go", []RefreshableView{FILES}},
+		{"/repo/.git/index", []RefreshableView{FILES}},
+		{"/repo/.git/index.lock", nil},
+		{"/repo/.git/HEAD", []RefreshableView{COMMITS, BRANCHES, FILES}},
+		{"/repo/.git/rebase-merge/done", []RefreshableView{COMMITS, FILES}},
+		{"/repo/.git/MERGE_HEAD", []RefreshableView{COMMITS, FILES}},
+		{"/repo/.git/refs/heads/feature/foo", []RefreshableView{COMMITS, BRANCHES}},
+		{"/repo/.git/refs/heads/feature/foo.lock", nil},
+		{"/repo/.git/refs/remotes/origin/master", []RefreshableView{BRANCHES, REMOTES}},
+		{"/repo/.git/refs/tags/v1.0", []RefreshableView{TAGS}},
+		{"/repo/.git/refs/stash", []RefreshableView{STASH}},
+		{"/repo/.git/packed-refs", []RefreshableView{COMMITS, BRANCHES, TAGS, REMOTES}},
+		{"/repo/.git/objects/ab/cdef", nil},
+		{"/repo/.git/FETCH_HEAD", nil},
+		{"/elsewhere/main.go", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			assert.EqualValues(t, c.expected, refreshScopesForPath(repoDir, dotGitDir, dotGitDir, filepath.FromSlash(c.path)))
+		})
+	}
+}
+
+func TestRefreshScopesForPathInLinkedWorktree(t *testing.T) {
+	repoDir := filepath.FromSlash("/worktree")
+	dotGitDir := filepath.FromSlash("/repo/.git/worktrees/worktree")
+	commonGitDir := filepath.FromSlash("/repo/.git")
+
+	cases := []struct {
+		path     string
+		expected []RefreshableView
+	}{
+		{"/worktree/main.go", []RefreshableView{FILES}},
+		{"/repo/.git/worktrees/worktree/index", []RefreshableView{FILES}},
+		{"/repo/.git/worktrees/worktree/HEAD", []RefreshableView{COMMITS, BRANCHES, FILES}},
+		{"/repo/.git/refs/heads/feature/foo", []RefreshableView{COMMITS, BRANCHES}},
+		{"/repo/.git/refs/tags/v1.0", []RefreshableView{TAGS}},
+		{"/repo/.git/packed-refs", []RefreshableView{COMMITS, BRANCHES, TAGS, REMOTES}},
+		// these belong to the main worktree
+		{"/repo/.git/HEAD", nil},
+		{"/repo/.git/index", nil},
+		{"/repo/main.go", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			assert.EqualValues(t, c.expected, refreshScopesForPath(repoDir, dotGitDir, commonGitDir, filepath.FromSlash(c.path)))
+		})
+	}
+}
+
+func TestShouldWatchDir(t *testing.T) {
+	repoDir := filepath.FromSlash("/repo")
+	dotGitDir := filepath.FromSlash("/repo/.git")
+
+	cases := []struct {
+		path     string
+		expected bool
+	}{
+		{"/repo/pkg", true},
+		{"/repo/.git/refs/heads/feature", true},
+		{"/repo/.git/rebase-merge", true},
+		{"/repo/.git/objects/ab", false},
+		{"/repo/.git/logs", false},
+		{"/elsewhere", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			assert.EqualValues(t, c.expected, shouldWatchDir(repoDir, dotGitDir, dotGitDir, filepath.FromSlash(c.path)))
+		})
+	}
+}
+
+func TestShouldWatchDirInLinkedWorktree(t *testing.T) {
+	repoDir := filepath.FromSlash("/worktree")
+	dotGitDir := filepath.FromSlash("/repo/.git/worktrees/worktree")
+	commonGitDir := filepath.FromSlash("/repo/.git")
+
+	cases := []struct {
+		path     string
+		expected bool
+	}{
+		{"/worktree/pkg", true},
+		{"/repo/.git/worktrees/worktree/rebase-merge", true},
+		{"/repo/.git/refs/heads/feature", true},
+		{"/repo/.git/objects/ab", false},
+		{"/repo/pkg", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			assert.EqualValues(t, c.expected, shouldWatchDir(repoDir, dotGitDir, commonGitDir, filepath.FromSlash(c.path)))
+		})
+	}
+}
+
+func TestFileWatcherDebouncesRefreshes(t *testing.T) {
+	refreshes := make(chan []RefreshableView, 10)
+	w := &fileWatcher{
+		onChange:         func(scopes []RefreshableView) { refreshes <- scopes },
+		debounceDuration: 20 * time.Millisecond,
+		pendingScopes:    map[RefreshableView]bool{},
+	}
+
+	w.queueRefresh([]RefreshableView{FILES})
+	w.queueRefresh(nil)
+	w.queueRefresh([]RefreshableView{BRANCHES, FILES})
+	w.queueRefresh([]RefreshableView{COMMITS})
+
+	select {
+	case scopes := <-refreshes:
+		assert.EqualValues(t, []RefreshableView{COMMITS, BRANCHES, FILES}, scopes)
+	case <-time.After(time.Second):
+		t.Fatal("expected a refresh")
+	}
+
+	select {
+	case scopes := <-refreshes:
+		t.Fatalf("expected a single refresh, got another for %v", scopes)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestFileWatcherSkipsGitDirAndIgnoredDirs(t *testing.T) {
+	repoDir, err := ioutil.TempDir("", "lazygit-file-watcher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(repoDir)
+
+	for _, dir := range []string{".git/objects/ab", ".git/refs/heads", "src/nested", "node_modules/pkg", "build"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(repoDir, filepath.FromSlash(dir)), 0755))
+	}
+
+	w := NewFileWatcher(
+		utils.NewDummyLog(),
+		true,
+		func([]RefreshableView) {},
+		func(paths ...string) ([]string, error) {
+			return []string{"node_modules/", "build/", "src/debug.log"}, nil
+		},
+	)
+	defer w.close()
+
+	dotGitDir := filepath.Join(repoDir, ".git")
+	w.watchRepo(repoDir, dotGitDir, dotGitDir)
+
+	watched := []string{}
+	for path := range w.watched {
+		relPath, err := filepath.Rel(repoDir, path)
+		assert.NoError(t, err)
+		watched = append(watched, filepath.ToSlash(relPath))
+	}
+	assert.ElementsMatch(t, []string{".", ".git", ".git/refs", ".git/refs/heads", "src", "src/nested"}, watched)
+
+	assert.True(t, w.isIgnored(repoDir, filepath.Join(repoDir, "node_modules", "pkg", "index.js")))
+	assert.True(t, w.isIgnored(repoDir, filepath.Join(repoDir, "src", "debug.log")))
+	assert.False(t, w.isIgnored(repoDir, filepath.Join(repoDir, "src", "main.go")))
+}
@@ -2,15 +2,19 @@ package gui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/types/enums"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/mgutz/str"
 )
 
 // list panel functions
@@ -24,6 +28,38 @@ func (gui *Gui) getSelectedFileNode() *filetree.FileNode {
 	return gui.State.FileTreeViewModel.GetItemAtIndex(selectedLine)
 }
 
+// getSelectedFileNodes returns the nodes in the selected range. Nodes within a
+// selected directory are left out given that the directory already covers them
+func (gui *Gui) getSelectedFileNodes() []*filetree.FileNode {
+	startIdx, endIdx := gui.State.Panels.Files.GetSelectedRange()
+	if startIdx == -1 {
+		return nil
+	}
+
+	nodes := []*filetree.FileNode{}
+	dirPaths := []string{}
+outer:
+	for i := startIdx; i <= endIdx; i++ {
+		node := gui.State.FileTreeViewModel.GetItemAtIndex(i)
+		if node == nil {
+			continue
+		}
+
+		for _, dirPath := range dirPaths {
+			if strings.HasPrefix(node.GetPath(), dirPath+"/") {
+				continue outer
+			}
+		}
+
+		if !node.IsLeaf() {
+			dirPaths = append(dirPaths, node.GetPath())
+		}
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
 func (gui *Gui) getSelectedFile() *models.File {
 	node := gui.getSelectedFileNode()
 	if node == nil {
@@ -53,6 +89,23 @@ func (gui *Gui) filesRenderToMain() error {
 		})
 	}
 
+	if node.File != nil && node.File.ResolvedByRerere {
+		// the file no longer has conflict markers so rather than the merge panel
+		// (which would stage it) we show how the recorded resolution compares to
+		// each side
+		cmdObj := gui.Git.WorkingTree.WorktreeFileDiffCmdObj(node, false, false, gui.State.IgnoreWhitespaceInDiffView)
+		return gui.refreshMainViews(refreshMainOpts{
+			main: &viewUpdateOpts{
+				title: gui.Tr.ResolvedByRerereTitle,
+				task:  NewRunPtyTask(cmdObj.GetCmd()),
+			},
+		})
+	}
+
+	if node.File != nil && node.File.HasMergeConflicts && !gui.canResolveInMergePanel(node.File) {
+		return gui.renderConflictDescription(node.File)
+	}
+
 	if node.File != nil && node.File.HasInlineMergeConflicts {
 		return gui.renderConflictsFromFilesPanel()
 	}
@@ -185,13 +238,17 @@ func (gui *Gui) enterFile(opts OnFocusOpts) error {
 		return gui.switchToMerge()
 	}
 	if file.HasMergeConflicts {
-		return gui.createErrorPanel(gui.Tr.FileStagingRequirements)
+		return gui.createResolveConflictMenu(file)
 	}
 
 	return gui.pushContext(gui.State.Contexts.Staging, opts)
 }
 
 func (gui *Gui) handleFilePress() error {
+	if gui.State.Panels.Files.IsSelectingRange() {
+		return gui.handleFileRangePress()
+	}
+
 	node := gui.getSelectedFileNode()
 	if node == nil {
 		return nil
@@ -243,6 +300,60 @@ func (gui *Gui) handleFilePress() error {
 	return gui.State.Contexts.Files.HandleFocus()
 }
 
+// handleFileRangePress stages all the selected files if any of them have
+// unstaged changes, otherwise it unstages them all
+func (gui *Gui) handleFileRangePress() error {
+	nodes := gui.getSelectedFileNodes()
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	anyUnstaged := false
+	for _, node := range nodes {
+		// as with directories, we can't stage files with inline merge conflicts
+		// or it'll end up with those >>>>>> lines actually staged
+		if node.GetHasInlineMergeConflicts() {
+			return gui.createErrorPanel(gui.Tr.ErrStageRangeWithMergeConflicts)
+		}
+
+		if node.GetHasUnstagedChanges() {
+			anyUnstaged = true
+		}
+	}
+
+	if anyUnstaged {
+		gui.logAction(gui.Tr.Actions.StageFile)
+		for _, node := range nodes {
+			path := node.GetPath()
+			if node.IsLeaf() {
+				path = node.File.Name
+			}
+			if err := gui.Git.WorkingTree.StageFile(path); err != nil {
+				return gui.surfaceError(err)
+			}
+		}
+	} else {
+		gui.logAction(gui.Tr.Actions.UnstageFile)
+		for _, node := range nodes {
+			var err error
+			if node.IsLeaf() {
+				err = gui.Git.WorkingTree.UnStageFile(node.File.Names(), node.File.Tracked)
+			} else {
+				err = gui.Git.WorkingTree.UnStageFile([]string{node.GetPath()}, true)
+			}
+			if err != nil {
+				return gui.surfaceError(err)
+			}
+		}
+	}
+
+	if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{FILES}}); err != nil {
+		return err
+	}
+
+	return gui.State.Contexts.Files.HandleFocus()
+}
+
 func (gui *Gui) allFilesStaged() bool {
 	for _, file := range gui.State.FileTreeViewModel.GetAllFiles() {
 		if file.HasUnstagedChanges {
@@ -391,6 +502,7 @@ func (gui *Gui) handleCommitPress() error {
 		gui.Views.CommitMessage.TextArea.TypeString(gui.State.failedCommitMessage)
 		gui.Views.CommitMessage.RenderTextArea()
 	} else {
+		prefix := ""
 		commitPrefixConfig := gui.commitPrefixConfigForRepo()
 		if commitPrefixConfig != nil {
 			prefixPattern := commitPrefixConfig.Pattern
@@ -399,9 +511,20 @@ func (gui *Gui) handleCommitPress() error {
 			if err != nil {
 				return gui.createErrorPanel(fmt.Sprintf("%s: %s", gui.Tr.LcCommitPrefixPatternError, err.Error()))
 			}
-			prefix := rgx.ReplaceAllString(gui.getCheckedOutBranch().Name, prefixReplace)
+			prefix = rgx.ReplaceAllString(gui.getCheckedOutBranch().Name, prefixReplace)
+		}
+
+		template, err := gui.Git.Commit.GetCommitTemplate()
+		if err != nil {
+			gui.Log.Error(err)
+		}
+
+		if prefix != "" || template != "" {
 			gui.Views.CommitMessage.ClearTextArea()
-			gui.Views.CommitMessage.TextArea.TypeString(prefix)
+			gui.Views.CommitMessage.TextArea.TypeString(prefix + template)
+			// leave the cursor on the subject line, where the user will start typing
+			gui.Views.CommitMessage.TextArea.SetCursor2D(0, 0)
+			gui.Views.CommitMessage.TextArea.GoToEndOfLine()
 			gui.Views.CommitMessage.RenderTextArea()
 		}
 	}
@@ -572,10 +695,6 @@ func (gui *Gui) refreshStateFiles() error {
 	state.FileTreeViewModel.SetFiles(files)
 	state.FileTreeViewModel.RWMutex.Unlock()
 
-	if err := gui.fileWatcher.addFilesToFileWatcher(files); err != nil {
-		return err
-	}
-
 	if selectedNode != nil {
 		newIdx := gui.findNewSelectedIdx(prevNodes[prevSelectedLineIdx:], state.FileTreeViewModel.GetAllItems())
 		if newIdx != -1 && newIdx != prevSelectedLineIdx {
@@ -859,9 +978,159 @@ func (gui *Gui) switchToMerge() error {
 		return nil
 	}
 
+	if !gui.canResolveInMergePanel(file) {
+		return gui.createResolveConflictMenu(file)
+	}
+
 	return gui.pushContext(gui.State.Contexts.Merging)
 }
 
+// canResolveInMergePanel tells us if the file's conflict is marked up with
+// conflict markers that the merge panel can work with. Git doesn't add markers
+// when e.g. one side deleted the file, or the file is binary
+func (gui *Gui) canResolveInMergePanel(file *models.File) bool {
+	if !file.HasInlineMergeConflicts {
+		return false
+	}
+
+	content, err := gui.Git.File.Cat(file.Name)
+	if err != nil {
+		// we'll let the merge panel surface the error
+		return true
+	}
+
+	// this is the same check git uses to decide if a file is binary
+	return !strings.Contains(content[:utils.Min(len(content), 8000)], "\x00")
+}
+
+func (gui *Gui) conflictDescription(file *models.File) string {
+	var description string
+	switch file.ShortStatus {
+	case "DD":
+		description = gui.Tr.BothDeletedConflict
+	case "AU":
+		description = gui.Tr.AddedByUsConflict
+	case "UA":
+		description = gui.Tr.AddedByThemConflict
+	case "DU":
+		description = gui.Tr.DeletedByUsConflict
+	case "UD":
+		description = gui.Tr.DeletedByThemConflict
+	default:
+		description = gui.Tr.BinaryConflict
+	}
+
+	return utils.ResolvePlaceholderString(description, map[string]string{"file": file.Name})
+}
+
+func (gui *Gui) renderConflictDescription(file *models.File) error {
+	pressKey := utils.ResolvePlaceholderString(
+		gui.Tr.PressKeyToResolveConflict,
+		map[string]string{"key": gui.getKeyDisplay(gui.UserConfig.Keybinding.Universal.GoInto)},
+	)
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: gui.Tr.ResolveConflictTitle,
+			task:  NewRenderStringTask(gui.conflictDescription(file) + "\n\n" + pressKey),
+		},
+	})
+}
+
+// createResolveConflictMenu offers ways to resolve conflicts that the merge
+// panel can't help with. We only offer to keep a side if git has a version of
+// the file from that side
+func (gui *Gui) createResolveConflictMenu(file *models.File) error {
+	hasOurs := utils.IncludesString([]string{"UD", "AU", "AA", "UU"}, file.ShortStatus)
+	hasTheirs := utils.IncludesString([]string{"DU", "UA", "AA", "UU"}, file.ShortStatus)
+
+	menuItems := []*menuItem{}
+	if hasOurs {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.LcKeepOurVersion,
+			onPress: func() error {
+				return gui.resolveUnmarkedConflict(func() error {
+					return gui.Git.WorkingTree.ResolveConflictWithOurs(file.Name)
+				})
+			},
+		})
+	}
+	if hasTheirs {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.LcKeepTheirVersion,
+			onPress: func() error {
+				return gui.resolveUnmarkedConflict(func() error {
+					return gui.Git.WorkingTree.ResolveConflictWithTheirs(file.Name)
+				})
+			},
+		})
+	}
+	menuItems = append(menuItems, &menuItem{
+		displayString: gui.Tr.LcKeepFileDeleted,
+		onPress: func() error {
+			return gui.resolveUnmarkedConflict(func() error {
+				return gui.Git.WorkingTree.ResolveConflictAsDeleted(file.Name)
+			})
+		},
+	})
+	if hasOurs && hasTheirs {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.LcKeepBothVersions,
+			onPress: func() error {
+				return gui.handleKeepBothConflictVersions(file)
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.ResolveConflictTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleKeepBothConflictVersions(file *models.File) error {
+	return gui.prompt(promptOpts{
+		title:          gui.Tr.OurVersionFileName,
+		initialContent: suffixedFileName(file.Name, "ours"),
+		handleConfirm: func(oursName string) error {
+			return gui.prompt(promptOpts{
+				title:          gui.Tr.TheirVersionFileName,
+				initialContent: suffixedFileName(file.Name, "theirs"),
+				handleConfirm: func(theirsName string) error {
+					return gui.resolveUnmarkedConflict(func() error {
+						return gui.Git.WorkingTree.ResolveConflictKeepingBoth(file.Name, oursName, theirsName)
+					})
+				},
+			})
+		},
+	})
+}
+
+// suffixedFileName adds the suffix before the file's extension so that e.g. an
+// image can still be opened as an image
+func suffixedFileName(name string, suffix string) string {
+	ext := filepath.Ext(name)
+	if ext == filepath.Base(name) {
+		ext = ""
+	}
+
+	return strings.TrimSuffix(name, ext) + "." + suffix + ext
+}
+
+func (gui *Gui) resolveUnmarkedConflict(resolve func() error) error {
+	gui.logAction(gui.Tr.Actions.ResolveMergeConflict)
+	if err := resolve(); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if err := gui.refreshSidePanels(refreshOptions{mode: SYNC, scope: []RefreshableView{FILES}}); err != nil {
+		return err
+	}
+
+	if gui.Git.Status.WorkingTreeState() != enums.REBASE_MODE_NONE && !gui.anyFilesWithMergeConflicts() {
+		return gui.promptToContinueRebase()
+	}
+
+	return nil
+}
+
 func (gui *Gui) openFile(filename string) error {
 	gui.logAction(gui.Tr.Actions.OpenFile)
 	if err := gui.OSCommand.OpenFile(filename); err != nil {
@@ -920,11 +1189,91 @@ func (gui *Gui) handleCreateStashMenu() error {
 				return gui.handleStashSave(gui.Git.Stash.SaveStagedChanges)
 			},
 		},
+		{
+			displayString: gui.Tr.LcStashIncludingUntracked,
+			onPress: func() error {
+				if len(gui.State.FileTreeViewModel.GetAllFiles()) == 0 {
+					return gui.createErrorPanel(gui.Tr.NoFilesToStash)
+				}
+				gui.logAction(gui.Tr.Actions.StashIncludingUntrackedChanges)
+				return gui.promptForStashMessage(gui.Git.Stash.SaveIncludingUntracked)
+			},
+		},
+		{
+			displayString: gui.Tr.LcStashSelectedFiles,
+			onPress: func() error {
+				nodes := gui.getSelectedFileNodes()
+				if len(nodes) == 0 {
+					return gui.createErrorPanel(gui.Tr.NoFilesToStash)
+				}
+				paths := make([]string, len(nodes))
+				for i, node := range nodes {
+					paths[i] = node.GetPath()
+				}
+				gui.logAction(gui.Tr.Actions.StashSelectedFiles)
+				return gui.handleStashPaths(paths)
+			},
+		},
+		{
+			displayString: gui.Tr.LcStashPathspec,
+			onPress: func() error {
+				return gui.prompt(promptOpts{
+					title:               gui.Tr.StashPathspec,
+					findSuggestionsFunc: gui.getFilePathSuggestionsFunc(),
+					handleConfirm: func(pathspec string) error {
+						paths := splitPathspec(pathspec)
+						if len(paths) == 0 {
+							return nil
+						}
+						gui.logAction(gui.Tr.Actions.StashPathspec)
+						return gui.handleStashPaths(paths)
+					},
+				})
+			},
+		},
 	}
 
 	return gui.createMenu(gui.Tr.LcStashOptions, menuItems, createMenuOptions{showCancel: true})
 }
 
+// splitPathspec splits the user's input into paths. The file path suggestions
+// complete a single path, which may contain spaces, so if the whole input names
+// a file we take it as is. Otherwise paths are space-separated, and a path
+// containing spaces can be quoted
+func splitPathspec(pathspec string) []string {
+	pathspec = strings.TrimSpace(pathspec)
+	if pathspec == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(pathspec); err == nil {
+		return []string{pathspec}
+	}
+
+	return str.ToArgv(pathspec)
+}
+
+// handleStashPaths stashes the changes to the given paths. Git refuses to stash
+// a pathspec that only matches untracked files unless we ask it to include
+// them, so we do that whenever an untracked file is covered by the paths
+func (gui *Gui) handleStashPaths(paths []string) error {
+	includeUntracked := false
+	for _, file := range gui.State.FileTreeViewModel.GetAllFiles() {
+		if file.Tracked {
+			continue
+		}
+		for _, path := range paths {
+			if file.Name == path || strings.HasPrefix(file.Name, strings.TrimSuffix(path, "/")+"/") {
+				includeUntracked = true
+			}
+		}
+	}
+
+	return gui.promptForStashMessage(func(message string) error {
+		return gui.Git.Stash.SavePaths(message, paths, includeUntracked)
+	})
+}
+
 func (gui *Gui) handleStashChanges() error {
 	return gui.handleStashSave(gui.Git.Stash.Save)
 }
@@ -1,6 +1,9 @@
 package gui
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
@@ -32,3 +35,17 @@ func mkRemoteList(names ...string) []*models.Remote {
 
 	return result
 }
+
+func TestSplitPathspec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-pathspec")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	spacedPath := filepath.Join(dir, "my file.txt")
+	assert.NoError(t, ioutil.WriteFile(spacedPath, []byte("content"), 0644))
+
+	assert.Nil(t, splitPathspec("  "))
+	assert.EqualValues(t, []string{"a.txt", "dir/b.txt"}, splitPathspec("a.txt  dir/b.txt"))
+	assert.EqualValues(t, []string{"a.txt", "my other file.txt"}, splitPathspec(`a.txt "my other file.txt"`))
+	assert.EqualValues(t, []string{spacedPath}, splitPathspec(spacedPath+" "))
+}
@@ -0,0 +1,128 @@
+package filetree
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+type BranchNode struct {
+	Children         []*BranchNode
+	Branch           *models.Branch
+	Path             string // e.g. 'feature/my-branch', or 'feature' for a folder
+	CompressionLevel int    // equal to the number of forward slashes you'll see in the path when it's rendered in tree mode
+}
+
+var _ INode = &BranchNode{}
+
+// methods satisfying ListItem interface
+
+func (s *BranchNode) ID() string {
+	return s.GetPath()
+}
+
+func (s *BranchNode) Description() string {
+	return s.GetPath()
+}
+
+// methods satisfying INode interface
+
+func (s *BranchNode) IsNil() bool {
+	return s == nil
+}
+
+func (s *BranchNode) IsLeaf() bool {
+	return s.Branch != nil
+}
+
+func (s *BranchNode) GetPath() string {
+	return s.Path
+}
+
+func (s *BranchNode) GetChildren() []INode {
+	result := make([]INode, len(s.Children))
+	for i, child := range s.Children {
+		result[i] = child
+	}
+
+	return result
+}
+
+func (s *BranchNode) SetChildren(children []INode) {
+	castChildren := make([]*BranchNode, len(children))
+	for i, child := range children {
+		castChildren[i] = child.(*BranchNode)
+	}
+
+	s.Children = castChildren
+}
+
+func (s *BranchNode) GetCompressionLevel() int {
+	return s.CompressionLevel
+}
+
+func (s *BranchNode) SetCompressionLevel(level int) {
+	s.CompressionLevel = level
+}
+
+// methods utilising generic functions for INodes
+
+func (n *BranchNode) Flatten(collapsedPaths map[string]bool) []*BranchNode {
+	results := flatten(n, collapsedPaths)
+	nodes := make([]*BranchNode, len(results))
+	for i, result := range results {
+		nodes[i] = result.(*BranchNode)
+	}
+
+	return nodes
+}
+
+func (node *BranchNode) GetNodeAtIndex(index int, collapsedPaths map[string]bool) *BranchNode {
+	if node == nil {
+		return nil
+	}
+
+	result := getNodeAtIndex(node, index, collapsedPaths)
+	if result == nil {
+		return nil
+	}
+
+	return result.(*BranchNode)
+}
+
+func (node *BranchNode) GetIndexForPath(path string, collapsedPaths map[string]bool) (int, bool) {
+	return getIndexForPath(node, path, collapsedPaths)
+}
+
+func (node *BranchNode) Size(collapsedPaths map[string]bool) int {
+	if node == nil {
+		return 0
+	}
+
+	return size(node, collapsedPaths)
+}
+
+func (s *BranchNode) Compress() {
+	if s == nil {
+		return
+	}
+
+	compressAux(s)
+}
+
+func (s *BranchNode) GetLeaves() []*BranchNode {
+	leaves := getLeaves(s)
+	castLeaves := make([]*BranchNode, len(leaves))
+	for i := range leaves {
+		castLeaves[i] = leaves[i].(*BranchNode)
+	}
+
+	return castLeaves
+}
+
+// extra methods
+
+func (s *BranchNode) NameAtDepth(depth int) string {
+	splitName := split(s.Path)
+	name := join(splitName[depth:])
+
+	return name
+}
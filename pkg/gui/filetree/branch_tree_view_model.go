@@ -0,0 +1,121 @@
+package filetree
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type BranchTreeViewModel struct {
+	branches      []*models.Branch
+	tree          *BranchNode
+	groupByPrefix bool
+	// if set, we only display the branches whose names fuzzily match it
+	textFilter     string
+	log            *logrus.Entry
+	collapsedPaths CollapsedPaths
+}
+
+func NewBranchTreeViewModel(branches []*models.Branch, log *logrus.Entry, groupByPrefix bool) *BranchTreeViewModel {
+	viewModel := &BranchTreeViewModel{
+		log:            log,
+		groupByPrefix:  groupByPrefix,
+		collapsedPaths: CollapsedPaths{},
+	}
+
+	viewModel.SetBranches(branches)
+
+	return viewModel
+}
+
+func (self *BranchTreeViewModel) GroupByPrefix() bool {
+	return self.groupByPrefix
+}
+
+func (self *BranchTreeViewModel) SetGroupByPrefix(groupByPrefix bool) {
+	self.groupByPrefix = groupByPrefix
+	self.SetTree()
+}
+
+func (self *BranchTreeViewModel) GetTextFilter() string {
+	return self.textFilter
+}
+
+func (self *BranchTreeViewModel) SetTextFilter(textFilter string) {
+	self.textFilter = textFilter
+	self.SetTree()
+}
+
+func (self *BranchTreeViewModel) GetItemAtIndex(index int) *BranchNode {
+	return self.tree.GetNodeAtIndex(index+1, self.collapsedPaths) // ignoring root
+}
+
+func (self *BranchTreeViewModel) GetIndexForPath(path string) (int, bool) {
+	index, found := self.tree.GetIndexForPath(path, self.collapsedPaths)
+	return index - 1, found
+}
+
+func (self *BranchTreeViewModel) GetAllItems() []*BranchNode {
+	if self.tree == nil {
+		return nil
+	}
+
+	return self.tree.Flatten(self.collapsedPaths)[1:] // ignoring root
+}
+
+func (self *BranchTreeViewModel) GetItemsLength() int {
+	return self.tree.Size(self.collapsedPaths) - 1 // ignoring root
+}
+
+func (self *BranchTreeViewModel) GetAllBranches() []*models.Branch {
+	return self.branches
+}
+
+func (self *BranchTreeViewModel) SetBranches(branches []*models.Branch) {
+	self.branches = branches
+
+	self.SetTree()
+}
+
+func (self *BranchTreeViewModel) GetBranchesForDisplay() []*models.Branch {
+	if self.textFilter == "" {
+		return self.branches
+	}
+
+	names := make([]string, len(self.branches))
+	for i, branch := range self.branches {
+		names[i] = branch.Name
+	}
+
+	indices := utils.FuzzyFilter(self.textFilter, names)
+	result := make([]*models.Branch, len(indices))
+	for i, index := range indices {
+		result[i] = self.branches[index]
+	}
+
+	return result
+}
+
+func (self *BranchTreeViewModel) SetTree() {
+	self.tree = BuildTreeFromBranches(self.GetBranchesForDisplay(), self.groupByPrefix)
+}
+
+func (self *BranchTreeViewModel) ExpandToPath(path string) {
+	self.collapsedPaths.ExpandToPath(path)
+}
+
+func (self *BranchTreeViewModel) IsCollapsed(path string) bool {
+	return self.collapsedPaths.IsCollapsed(path)
+}
+
+func (self *BranchTreeViewModel) ToggleCollapsed(path string) {
+	self.collapsedPaths.ToggleCollapsed(path)
+}
+
+func (self *BranchTreeViewModel) Tree() INode {
+	return self.tree
+}
+
+func (self *BranchTreeViewModel) CollapsedPaths() CollapsedPaths {
+	return self.collapsedPaths
+}
@@ -0,0 +1,136 @@
+package filetree
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchTreeViewModelTextFilter(t *testing.T) {
+	master := &models.Branch{Name: "master"}
+	head := &models.Branch{Name: "feature/current", Head: true}
+	featureA := &models.Branch{Name: "feature/a"}
+	featureB := &models.Branch{Name: "feature/b"}
+	bugfix := &models.Branch{Name: "bugfix/ui"}
+
+	branches := []*models.Branch{head, featureA, master, bugfix, featureB}
+
+	scenarios := []struct {
+		name          string
+		textFilter    string
+		groupByPrefix bool
+		expected      []string
+	}{
+		{
+			name:       "no filter",
+			textFilter: "",
+			expected:   []string{"feature/current", "feature/a", "master", "bugfix/ui", "feature/b"},
+		},
+		{
+			name:       "filter keeps the original order",
+			textFilter: "feature",
+			expected:   []string{"feature/current", "feature/a", "feature/b"},
+		},
+		{
+			name:       "filter matching nothing",
+			textFilter: "zzz",
+			expected:   []string{},
+		},
+		{
+			name:          "filter with branches grouped by prefix",
+			textFilter:    "feature",
+			groupByPrefix: true,
+			expected:      []string{"feature/current", "feature", "feature/a", "feature/b"},
+		},
+		{
+			name:          "filter leaving a single branch in a group",
+			textFilter:    "bugfix",
+			groupByPrefix: true,
+			expected:      []string{"bugfix", "bugfix/ui"},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			viewModel := NewBranchTreeViewModel(branches, nil, s.groupByPrefix)
+			viewModel.SetTextFilter(s.textFilter)
+
+			paths := []string{}
+			for _, node := range viewModel.GetAllItems() {
+				paths = append(paths, node.GetPath())
+			}
+			assert.EqualValues(t, s.expected, paths)
+			assert.Equal(t, len(s.expected), viewModel.GetItemsLength())
+			// filtering only affects what's displayed
+			assert.EqualValues(t, branches, viewModel.GetAllBranches())
+		})
+	}
+}
+
+func TestBranchTreeViewModelGetIndexForPath(t *testing.T) {
+	master := &models.Branch{Name: "master"}
+	head := &models.Branch{Name: "feature/current", Head: true}
+	featureA := &models.Branch{Name: "feature/a"}
+	featureB := &models.Branch{Name: "feature/b"}
+
+	branches := []*models.Branch{head, master, featureA, featureB}
+
+	scenarios := []struct {
+		name          string
+		textFilter    string
+		groupByPrefix bool
+		branchName    string
+		expectedIndex int
+		expectedFound bool
+	}{
+		{
+			name:          "branch moved up by the filter",
+			textFilter:    "feature",
+			branchName:    "feature/b",
+			expectedIndex: 2,
+			expectedFound: true,
+		},
+		{
+			name:          "branch hidden by the filter",
+			textFilter:    "feature",
+			branchName:    "master",
+			expectedFound: false,
+		},
+		{
+			name:          "branch under a prefix group",
+			textFilter:    "feature",
+			groupByPrefix: true,
+			branchName:    "feature/b",
+			expectedIndex: 3,
+			expectedFound: true,
+		},
+		{
+			name:          "checked out branch with no filter",
+			branchName:    "feature/current",
+			expectedIndex: 0,
+			expectedFound: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			viewModel := NewBranchTreeViewModel(branches, nil, s.groupByPrefix)
+			viewModel.SetTextFilter(s.textFilter)
+
+			index, found := viewModel.GetIndexForPath(s.branchName)
+			assert.Equal(t, s.expectedFound, found)
+			if !s.expectedFound {
+				return
+			}
+
+			assert.Equal(t, s.expectedIndex, index)
+			node := viewModel.GetItemAtIndex(index)
+			if assert.NotNil(t, node) && assert.True(t, node.IsLeaf()) {
+				assert.Equal(t, s.branchName, node.Branch.Name)
+			}
+		})
+	}
+}
@@ -131,6 +131,52 @@ func BuildFlatTreeFromFiles(files []*models.File) *FileNode {
 	return &FileNode{Children: sortedFiles}
 }
 
+// BuildTreeFromBranches keeps the branches in the order they're given, with each
+// folder placed where its first branch would be. The checked out branch is kept
+// at the top level so that it's always visible
+func BuildTreeFromBranches(branches []*models.Branch, groupByPrefix bool) *BranchNode {
+	root := &BranchNode{}
+
+	var curr *BranchNode
+	for _, branch := range branches {
+		if !groupByPrefix || branch.Head {
+			root.Children = append(root.Children, &BranchNode{Path: branch.Name, Branch: branch})
+			continue
+		}
+
+		splitPath := split(branch.Name)
+		curr = root
+	outer:
+		for i := range splitPath {
+			var setBranch *models.Branch
+			isBranch := i == len(splitPath)-1
+			if isBranch {
+				setBranch = branch
+			}
+
+			path := join(splitPath[:i+1])
+			for _, existingChild := range curr.Children {
+				if existingChild.Path == path && !existingChild.IsLeaf() {
+					curr = existingChild
+					continue outer
+				}
+			}
+
+			newChild := &BranchNode{
+				Path:   path,
+				Branch: setBranch,
+			}
+			curr.Children = append(curr.Children, newChild)
+
+			curr = newChild
+		}
+	}
+
+	root.Compress()
+
+	return root
+}
+
 func split(str string) []string {
 	return strings.Split(str, "/")
 }
@@ -528,3 +528,65 @@ func TestBuildFlatTreeFromCommitFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildTreeFromBranches(t *testing.T) {
+	master := &models.Branch{Name: "master"}
+	head := &models.Branch{Name: "feature/current", Head: true}
+	featureA := &models.Branch{Name: "feature/a"}
+	featureB := &models.Branch{Name: "feature/b"}
+	bugfix := &models.Branch{Name: "bugfix/ui/c"}
+
+	branches := []*models.Branch{head, featureA, master, bugfix, featureB}
+
+	scenarios := []struct {
+		name          string
+		groupByPrefix bool
+		expected      *BranchNode
+	}{
+		{
+			name:          "not grouped",
+			groupByPrefix: false,
+			expected: &BranchNode{
+				Children: []*BranchNode{
+					{Branch: head, Path: "feature/current"},
+					{Branch: featureA, Path: "feature/a"},
+					{Branch: master, Path: "master"},
+					{Branch: bugfix, Path: "bugfix/ui/c"},
+					{Branch: featureB, Path: "feature/b"},
+				},
+			},
+		},
+		{
+			name:          "grouped by prefix",
+			groupByPrefix: true,
+			expected: &BranchNode{
+				Children: []*BranchNode{
+					{Branch: head, Path: "feature/current"},
+					{
+						Path: "feature",
+						Children: []*BranchNode{
+							{Branch: featureA, Path: "feature/a"},
+							{Branch: featureB, Path: "feature/b"},
+						},
+					},
+					{Branch: master, Path: "master"},
+					{
+						Path:             "bugfix/ui",
+						CompressionLevel: 1,
+						Children: []*BranchNode{
+							{Branch: bugfix, Path: "bugfix/ui/c"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			result := BuildTreeFromBranches(branches, s.groupByPrefix)
+			assert.EqualValues(t, s.expected, result)
+		})
+	}
+}
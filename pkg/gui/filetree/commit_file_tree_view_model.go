@@ -2,6 +2,7 @@ package filetree
 
 import (
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -11,6 +12,8 @@ type CommitFileTreeViewModel struct {
 	showTree       bool
 	log            *logrus.Entry
 	collapsedPaths CollapsedPaths
+	// if set, we only display the files whose paths fuzzily match it
+	textFilter string
 	// parent is the identifier of the parent object e.g. a commit SHA if this commit file is for a commit, or a stash entry ref like 'stash@{1}'
 	parent string
 }
@@ -39,6 +42,15 @@ func (self *CommitFileTreeViewModel) ExpandToPath(path string) {
 	self.collapsedPaths.ExpandToPath(path)
 }
 
+func (self *CommitFileTreeViewModel) GetTextFilter() string {
+	return self.textFilter
+}
+
+func (self *CommitFileTreeViewModel) SetTextFilter(textFilter string) {
+	self.textFilter = textFilter
+	self.SetTree()
+}
+
 func (self *CommitFileTreeViewModel) ToggleShowTree() {
 	self.showTree = !self.showTree
 	self.SetTree()
@@ -76,11 +88,31 @@ func (self *CommitFileTreeViewModel) SetFiles(files []*models.CommitFile) {
 	self.SetTree()
 }
 
+func (self *CommitFileTreeViewModel) GetFilesForDisplay() []*models.CommitFile {
+	if self.textFilter == "" {
+		return self.files
+	}
+
+	paths := make([]string, len(self.files))
+	for i, file := range self.files {
+		paths[i] = file.Name
+	}
+
+	indices := utils.FuzzyFilter(self.textFilter, paths)
+	result := make([]*models.CommitFile, len(indices))
+	for i, index := range indices {
+		result[i] = self.files[index]
+	}
+
+	return result
+}
+
 func (self *CommitFileTreeViewModel) SetTree() {
+	filesForDisplay := self.GetFilesForDisplay()
 	if self.showTree {
-		self.tree = BuildTreeFromCommitFiles(self.files)
+		self.tree = BuildTreeFromCommitFiles(filesForDisplay)
 	} else {
-		self.tree = BuildFlatTreeFromCommitFiles(self.files)
+		self.tree = BuildFlatTreeFromCommitFiles(filesForDisplay)
 	}
 }
 
@@ -0,0 +1,112 @@
+package filetree
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitFileTreeViewModelTextFilter(t *testing.T) {
+	files := []*models.CommitFile{
+		{Name: "dir1/file1", ChangeStatus: "M"},
+		{Name: "dir2/file2", ChangeStatus: "A"},
+		{Name: "dir2/nested/file3", ChangeStatus: "D"},
+		{Name: "README.md", ChangeStatus: "M"},
+	}
+
+	scenarios := []struct {
+		name       string
+		textFilter string
+		expected   []*models.CommitFile
+	}{
+		{
+			name:       "no filter",
+			textFilter: "",
+			expected:   files,
+		},
+		{
+			name:       "filter by directory",
+			textFilter: "dir2",
+			expected:   []*models.CommitFile{files[1], files[2]},
+		},
+		{
+			name:       "filter matching nothing",
+			textFilter: "zzz",
+			expected:   []*models.CommitFile{},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			viewModel := NewCommitFileTreeViewModel(files, nil, false)
+			viewModel.SetTextFilter(s.textFilter)
+
+			assert.EqualValues(t, s.expected, viewModel.GetFilesForDisplay())
+			assert.Equal(t, len(s.expected), viewModel.GetItemsLength())
+			// filtering only affects what's displayed
+			assert.EqualValues(t, files, viewModel.GetAllFiles())
+		})
+	}
+}
+
+func TestCommitFileTreeViewModelGetIndexForPath(t *testing.T) {
+	files := []*models.CommitFile{
+		{Name: "dir1/file1", ChangeStatus: "M"},
+		{Name: "dir2/file2", ChangeStatus: "A"},
+		{Name: "dir2/nested/file3", ChangeStatus: "D"},
+		{Name: "README.md", ChangeStatus: "M"},
+	}
+
+	scenarios := []struct {
+		name          string
+		textFilter    string
+		showTree      bool
+		path          string
+		expectedIndex int
+		expectedFound bool
+	}{
+		{
+			name:          "file moved up by the filter",
+			textFilter:    "dir2",
+			path:          "dir2/file2",
+			expectedIndex: 1,
+			expectedFound: true,
+		},
+		{
+			name:          "file hidden by the filter",
+			textFilter:    "dir2",
+			path:          "README.md",
+			expectedFound: false,
+		},
+		{
+			name:          "file in tree mode",
+			textFilter:    "dir2",
+			showTree:      true,
+			path:          "dir2/file2",
+			expectedIndex: 3,
+			expectedFound: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			viewModel := NewCommitFileTreeViewModel(files, nil, s.showTree)
+			viewModel.SetTextFilter(s.textFilter)
+
+			index, found := viewModel.GetIndexForPath(s.path)
+			assert.Equal(t, s.expectedFound, found)
+			if !s.expectedFound {
+				return
+			}
+
+			assert.Equal(t, s.expectedIndex, index)
+			node := viewModel.GetItemAtIndex(index)
+			if assert.NotNil(t, node) && assert.True(t, node.IsLeaf()) {
+				assert.Equal(t, s.path, node.File.Name)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -16,11 +17,13 @@ const (
 )
 
 type FileTreeViewModel struct {
-	files          []*models.File
-	tree           *FileNode
-	showTree       bool
-	log            *logrus.Entry
-	filter         FileTreeDisplayFilter
+	files    []*models.File
+	tree     *FileNode
+	showTree bool
+	log      *logrus.Entry
+	filter   FileTreeDisplayFilter
+	// if set, we only display the files whose paths fuzzily match it
+	textFilter     string
 	collapsedPaths CollapsedPaths
 	sync.RWMutex
 }
@@ -48,7 +51,10 @@ func (self *FileTreeViewModel) ExpandToPath(path string) {
 }
 
 func (self *FileTreeViewModel) GetFilesForDisplay() []*models.File {
-	files := self.files
+	return self.filterByText(self.filterByDisplayFilter(self.files))
+}
+
+func (self *FileTreeViewModel) filterByDisplayFilter(files []*models.File) []*models.File {
 	if self.filter == DisplayAll {
 		return files
 	}
@@ -71,11 +77,39 @@ func (self *FileTreeViewModel) GetFilesForDisplay() []*models.File {
 	return result
 }
 
+func (self *FileTreeViewModel) filterByText(files []*models.File) []*models.File {
+	if self.textFilter == "" {
+		return files
+	}
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Name
+	}
+
+	indices := utils.FuzzyFilter(self.textFilter, paths)
+	result := make([]*models.File, len(indices))
+	for i, index := range indices {
+		result[i] = files[index]
+	}
+
+	return result
+}
+
 func (self *FileTreeViewModel) SetDisplayFilter(filter FileTreeDisplayFilter) {
 	self.filter = filter
 	self.SetTree()
 }
 
+func (self *FileTreeViewModel) GetTextFilter() string {
+	return self.textFilter
+}
+
+func (self *FileTreeViewModel) SetTextFilter(textFilter string) {
+	self.textFilter = textFilter
+	self.SetTree()
+}
+
 func (self *FileTreeViewModel) ToggleShowTree() {
 	self.showTree = !self.showTree
 	self.SetTree()
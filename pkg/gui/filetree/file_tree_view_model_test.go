@@ -9,10 +9,11 @@ import (
 
 func TestFilterAction(t *testing.T) {
 	scenarios := []struct {
-		name     string
-		filter   FileTreeDisplayFilter
-		files    []*models.File
-		expected []*models.File
+		name       string
+		filter     FileTreeDisplayFilter
+		textFilter string
+		files      []*models.File
+		expected   []*models.File
 	}{
 		{
 			name:   "filter files with unstaged changes",
@@ -54,12 +55,25 @@ func TestFilterAction(t *testing.T) {
 				{Name: "file1", ShortStatus: "M ", HasUnstagedChanges: true},
 			},
 		},
+		{
+			name:       "filter unstaged files by path",
+			filter:     DisplayUnstaged,
+			textFilter: "dir2",
+			files: []*models.File{
+				{Name: "dir2/dir2/file4", ShortStatus: "M ", HasUnstagedChanges: true},
+				{Name: "dir2/file5", ShortStatus: "M ", HasStagedChanges: true},
+				{Name: "file1", ShortStatus: "M ", HasUnstagedChanges: true},
+			},
+			expected: []*models.File{
+				{Name: "dir2/dir2/file4", ShortStatus: "M ", HasUnstagedChanges: true},
+			},
+		},
 	}
 
 	for _, s := range scenarios {
 		s := s
 		t.Run(s.name, func(t *testing.T) {
-			mngr := &FileTreeViewModel{files: s.files, filter: s.filter}
+			mngr := &FileTreeViewModel{files: s.files, filter: s.filter, textFilter: s.textFilter}
 			result := mngr.GetFilesForDisplay()
 			assert.EqualValues(t, s.expected, result)
 		})
@@ -28,6 +28,17 @@ func (gui *Gui) clearFiltering() error {
 
 func (gui *Gui) setFiltering(path string) error {
 	gui.State.Modes.Filtering.SetPath(path)
+
+	return gui.enterFilterMode()
+}
+
+func (gui *Gui) setLineRangeFiltering(path string, fromLine int, toLine int) error {
+	gui.State.Modes.Filtering.SetLineRange(path, fromLine, toLine)
+
+	return gui.enterFilterMode()
+}
+
+func (gui *Gui) enterFilterMode() error {
 	if gui.State.ScreenMode == SCREEN_NORMAL {
 		gui.State.ScreenMode = SCREEN_HALF
 	}
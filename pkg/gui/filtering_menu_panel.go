@@ -31,6 +31,19 @@ func (gui *Gui) handleCreateFilteringMenuPanel() error {
 		})
 	}
 
+	// when staging we can filter by just the selected lines. We go by the
+	// line numbers on the 'before' side of the diff because `git log -L` needs
+	// them as they are in a commit rather than in the working tree.
+	if fileName != "" && gui.currentContext().GetKey() == MAIN_STAGING_CONTEXT_KEY && gui.State.Panels.LineByLine != nil {
+		fromLine, toLine := gui.State.Panels.LineByLine.SelectedOldLineNumberRange()
+		menuItems = append(menuItems, &menuItem{
+			displayString: fmt.Sprintf("%s '%s:%d-%d'", gui.Tr.LcFilterBy, fileName, fromLine, toLine),
+			onPress: func() error {
+				return gui.setLineRangeFiltering(fileName, fromLine, toLine)
+			},
+		})
+	}
+
 	menuItems = append(menuItems, &menuItem{
 		displayString: gui.Tr.LcFilterPathOption,
 		onPress: func() error {
@@ -46,6 +46,32 @@ func (gui *Gui) getRemoteSuggestionsFunc() func(string) []*types.Suggestion {
 	return fuzzySearchFunc(remoteNames)
 }
 
+// getAuthorSuggestionsFunc only lists the repo's authors once suggestions are
+// first asked for, given that it means going through the whole history, and
+// then caches them for the rest of the session
+func (gui *Gui) getAuthorSuggestionsFunc() func(string) []*types.Suggestion {
+	return func(input string) []*types.Suggestion {
+		return fuzzySearchFunc(gui.getAuthors())(input)
+	}
+}
+
+func (gui *Gui) getAuthors() []string {
+	// suggestions are found in the background, possibly several at once
+	gui.Mutexes.AuthorsMutex.Lock()
+	defer gui.Mutexes.AuthorsMutex.Unlock()
+
+	if gui.State.Authors == nil {
+		authors, err := gui.Git.Commit.GetAuthors()
+		if err != nil {
+			gui.Log.Error(err)
+			authors = []string{}
+		}
+		gui.State.Authors = authors
+	}
+
+	return gui.State.Authors
+}
+
 func (gui *Gui) getBranchNames() []string {
 	result := make([]string, len(gui.State.Branches))
 	for i, branch := range gui.State.Branches {
@@ -14,6 +14,8 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/common"
@@ -127,6 +129,11 @@ type Gui struct {
 
 type listPanelState struct {
 	SelectedLineIdx int
+
+	// when range-selecting, the selection spans from rangeStartIdx to
+	// SelectedLineIdx (in either direction)
+	rangeStartIdx  int
+	selectingRange bool
 }
 
 func (h *listPanelState) SetSelectedLineIdx(value int) {
@@ -137,6 +144,40 @@ func (h *listPanelState) GetSelectedLineIdx() int {
 	return h.SelectedLineIdx
 }
 
+func (h *listPanelState) ToggleRangeSelect() {
+	h.selectingRange = !h.selectingRange
+	h.rangeStartIdx = h.SelectedLineIdx
+}
+
+func (h *listPanelState) CancelRangeSelect() {
+	h.selectingRange = false
+}
+
+func (h *listPanelState) IsSelectingRange() bool {
+	return h.selectingRange
+}
+
+// GetSelectedRange returns the first and last selected indices (inclusive). If
+// we're not range-selecting, both are the selected line's index.
+func (h *listPanelState) GetSelectedRange() (int, int) {
+	if !h.selectingRange {
+		return h.SelectedLineIdx, h.SelectedLineIdx
+	}
+
+	if h.rangeStartIdx > h.SelectedLineIdx {
+		return h.SelectedLineIdx, h.rangeStartIdx
+	}
+
+	return h.rangeStartIdx, h.SelectedLineIdx
+}
+
+// ShiftSelection moves the whole selection (including any range) by the given
+// amount e.g. after moving the selected commits down
+func (h *listPanelState) ShiftSelection(delta int) {
+	h.SelectedLineIdx += delta
+	h.rangeStartIdx += delta
+}
+
 // for now the staging panel state, unlike the other panel states, is going to be
 // non-mutative, so that we don't accidentally end up
 // with mismatches of data. We might change this in the future
@@ -151,6 +192,14 @@ type MergingPanelState struct {
 	// UserVerticalScrolling tells us if the user has started scrolling through the file themselves
 	// in which case we won't auto-scroll to a conflict.
 	UserVerticalScrolling bool
+
+	// if true we show the base, ours and theirs versions of the file side by
+	// side, with the result in the secondary view
+	ThreeWay bool
+
+	// if we're resolving the current conflict by picking lines from either side,
+	// this holds the lines picked so far
+	Edit *mergeconflicts.ConflictEdit
 }
 
 type filePanelState struct {
@@ -168,16 +217,31 @@ type remotePanelState struct {
 
 type remoteBranchesState struct {
 	listPanelState
+
+	// only the remote branches fuzzily matching this are shown
+	filter string
 }
 
 type tagsPanelState struct {
 	listPanelState
+
+	// only the tags fuzzily matching this are shown
+	filter string
+}
+
+type worktreesPanelState struct {
+	listPanelState
 }
 
 type commitPanelState struct {
 	listPanelState
 
+	// when true we load commits a page at a time as the cursor nears the end of
+	// the loaded commits, rather than loading them all at once
 	LimitCommits bool
+	// true once we've loaded the last page of commits
+	AllCommitsLoaded   bool
+	LoadingMoreCommits bool
 }
 
 type reflogCommitPanelState struct {
@@ -189,6 +253,10 @@ type subCommitPanelState struct {
 
 	// e.g. name of branch whose commits we're looking at
 	refName string
+
+	// set when we're showing the results of a commit history search rather
+	// than the commits of a ref
+	search *loaders.CommitSearch
 }
 
 type stashPanelState struct {
@@ -209,6 +277,15 @@ type commitFilesPanelState struct {
 	canRebase bool
 }
 
+type blamePanelState struct {
+	listPanelState
+
+	// the file being blamed, and the ref we're blaming it at (empty for the
+	// working tree)
+	filename string
+	ref      string
+}
+
 type submodulePanelState struct {
 	listPanelState
 }
@@ -223,6 +300,7 @@ type panelStates struct {
 	Remotes        *remotePanelState
 	RemoteBranches *remoteBranchesState
 	Tags           *tagsPanelState
+	Worktrees      *worktreesPanelState
 	Commits        *commitPanelState
 	ReflogCommits  *reflogCommitPanelState
 	SubCommits     *subCommitPanelState
@@ -231,6 +309,7 @@ type panelStates struct {
 	LineByLine     *LblPanelState
 	Merging        *MergingPanelState
 	CommitFiles    *commitFilesPanelState
+	Blame          *blamePanelState
 	Submodules     *submodulePanelState
 	Suggestions    *suggestionsPanelState
 }
@@ -262,6 +341,9 @@ type searchingState struct {
 	view         *gocui.View
 	isSearching  bool
 	searchString string
+	// set when the search bar is being used to filter a list rather than to
+	// search within a view
+	filterContext IListContext
 }
 
 // startup stages so we don't need to load everything at once
@@ -285,6 +367,10 @@ type guiMutexes struct {
 	BranchCommitsMutex    sync.Mutex
 	LineByLinePanelMutex  sync.Mutex
 	SubprocessMutex       sync.Mutex
+	ForgeClientMutex      sync.Mutex
+	AuthorsMutex          sync.Mutex
+	// guards the branch divergence refresh flags in the gui state
+	BranchDivergencesMutex sync.Mutex
 }
 
 type guiState struct {
@@ -292,6 +378,7 @@ type guiState struct {
 	// managers for them which handle rendering a flat list of files in tree form
 	FileTreeViewModel       *filetree.FileTreeViewModel
 	CommitFileTreeViewModel *filetree.CommitFileTreeViewModel
+	BranchTreeViewModel     *filetree.BranchTreeViewModel
 	Submodules              []*models.SubmoduleConfig
 	Branches                []*models.Branch
 	Commits                 []*models.Commit
@@ -304,11 +391,24 @@ type guiState struct {
 	// ReflogCommits are the ones used by the branches panel to obtain recency values
 	// if we're not in filtering mode, CommitFiles and FilteredReflogCommits will be
 	// one and the same
-	ReflogCommits     []*models.Commit
-	SubCommits        []*models.Commit
-	Remotes           []*models.Remote
-	RemoteBranches    []*models.RemoteBranch
-	Tags              []*models.Tag
+	ReflogCommits  []*models.Commit
+	SubCommits     []*models.Commit
+	Remotes        []*models.Remote
+	RemoteBranches []*models.RemoteBranch
+	// FilteredRemoteBranches are the ones that appear in the remote branches
+	// panel, after applying the panel's filter
+	FilteredRemoteBranches []*models.RemoteBranch
+	Tags                   []*models.Tag
+	// FilteredTags are the ones that appear in the tags panel, after applying the
+	// panel's filter
+	FilteredTags []*models.Tag
+	Worktrees    []*models.Worktree
+	BlameLines   []*models.BlameLine
+	// the open pull requests of the repo, keyed by head branch name
+	PullRequests map[string]*models.PullRequest
+	// how far each branch has diverged from its base branch, keyed by branch name
+	BranchDivergences map[string]*models.BranchDivergence
+	ForgeClient       hosting_service.ForgeClient
 	MenuItems         []*menuItem
 	BisectInfo        *git_commands.BisectInfo
 	Updating          bool
@@ -344,12 +444,22 @@ type guiState struct {
 	// back in sync with the repo state
 	ViewsSetup bool
 
+	// true while branch divergences are being worked out in the background
+	IsRefreshingBranchDivergences bool
+
+	// true if the branches were refreshed again while we were working out their
+	// divergences, meaning another run is needed once the current one is done
+	BranchDivergencesOutdated bool
+
 	// flag as to whether or not the diff view should ignore whitespace
 	IgnoreWhitespaceInDiffView bool
 
 	// for displaying suggestions while typing in a file name
 	FilesTrie *patricia.Trie
 
+	// the repo's commit authors, for suggestions. Loaded the first time we need them
+	Authors []string
+
 	// this is the message of the last failed commit attempt
 	failedCommitMessage string
 }
@@ -392,6 +502,7 @@ func (gui *Gui) resetState(filterPath string, reuseState bool) {
 	gui.State = &guiState{
 		FileTreeViewModel:       filetree.NewFileTreeViewModel(make([]*models.File, 0), gui.Log, showTree),
 		CommitFileTreeViewModel: filetree.NewCommitFileTreeViewModel(make([]*models.CommitFile, 0), gui.Log, showTree),
+		BranchTreeViewModel:     filetree.NewBranchTreeViewModel(make([]*models.Branch, 0), gui.Log, gui.getBranchListState().GroupByPrefix),
 		Commits:                 make([]*models.Commit, 0),
 		FilteredReflogCommits:   make([]*models.Commit, 0),
 		ReflogCommits:           make([]*models.Commit, 0),
@@ -403,12 +514,14 @@ func (gui *Gui) resetState(filterPath string, reuseState bool) {
 			Submodules:     &submodulePanelState{listPanelState{SelectedLineIdx: -1}},
 			Branches:       &branchPanelState{listPanelState{SelectedLineIdx: 0}},
 			Remotes:        &remotePanelState{listPanelState{SelectedLineIdx: 0}},
-			RemoteBranches: &remoteBranchesState{listPanelState{SelectedLineIdx: -1}},
-			Tags:           &tagsPanelState{listPanelState{SelectedLineIdx: -1}},
+			RemoteBranches: &remoteBranchesState{listPanelState: listPanelState{SelectedLineIdx: -1}},
+			Tags:           &tagsPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}},
+			Worktrees:      &worktreesPanelState{listPanelState{SelectedLineIdx: 0}},
 			Commits:        &commitPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, LimitCommits: true},
 			ReflogCommits:  &reflogCommitPanelState{listPanelState{SelectedLineIdx: 0}},
 			SubCommits:     &subCommitPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, refName: ""},
 			CommitFiles:    &commitFilesPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}, refName: ""},
+			Blame:          &blamePanelState{listPanelState: listPanelState{SelectedLineIdx: 0}},
 			Stash:          &stashPanelState{listPanelState{SelectedLineIdx: -1}},
 			Menu:           &menuPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, OnPress: nil},
 			Suggestions:    &suggestionsPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}},
@@ -559,7 +672,8 @@ func (gui *Gui) Run() error {
 		go utils.Safe(gui.startBackgroundFetch)
 	}
 
-	gui.goEvery(time.Second*time.Duration(userConfig.Refresher.RefreshInterval), gui.stopChan, gui.refreshFilesAndSubmodules)
+	gui.watchRepoForChanges()
+	gui.goEvery(time.Second*time.Duration(userConfig.Refresher.RefreshInterval), gui.stopChan, gui.pollFilesAndSubmodules)
 
 	g.SetManager(gocui.ManagerFunc(gui.layout), gocui.ManagerFunc(gui.getFocusLayout()))
 
@@ -578,9 +692,7 @@ func (gui *Gui) RunAndHandleError() error {
 				manager.Close()
 			}
 
-			if !gui.fileWatcher.Disabled {
-				gui.fileWatcher.Watcher.Close()
-			}
+			gui.fileWatcher.close()
 
 			close(gui.stopChan)
 
@@ -537,6 +537,21 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleOpenMergeTool,
 			Description: gui.Tr.LcOpenMergeTool,
 		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.ViewBlame),
+			Handler:     gui.handleBlameFile,
+			Description: gui.Tr.LcViewBlame,
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.ViewRerereOptions),
+			Handler:     gui.handleCreateRerereOptionsMenu,
+			Description: gui.Tr.LcViewRerereOptions,
+			OpensMenu:   true,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
@@ -573,6 +588,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCheckoutByName,
 			Description: gui.Tr.LcCheckoutByName,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.CheckoutPullRequest),
+			Handler:     gui.handleCheckoutPullRequest,
+			Description: gui.Tr.LcCheckoutPullRequest,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
@@ -649,9 +671,39 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "branches",
 			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.GoInto),
-			Handler:     gui.handleSwitchToSubCommits,
+			Handler:     gui.handleEnterBranch,
 			Description: gui.Tr.LcViewCommits,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.SortOrder),
+			Handler:     gui.handleBranchSortOrderMenu,
+			Description: gui.Tr.LcSortAndGroupBranches,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.CleanupBranches),
+			Handler:     gui.handleCleanupBranches,
+			Description: gui.Tr.LcCleanupBranches,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.SetBaseBranch),
+			Handler:     gui.handleSetBaseBranch,
+			Description: gui.Tr.LcSetBaseBranch,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.RebaseOntoBaseBranch),
+			Handler:     gui.handleRebaseOntoBaseBranch,
+			Description: gui.Tr.LcRebaseOntoBaseBranch,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
@@ -673,6 +725,29 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.withSelectedTag(gui.handlePushTag),
 			Description: gui.Tr.LcPushTag,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.DeleteRemoteTag),
+			Handler:     gui.withSelectedTag(gui.handleDeleteRemoteTag),
+			Description: gui.Tr.LcDeleteRemoteTag,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.FetchRemote),
+			Handler:     gui.handleRemoteTagsMenu,
+			Description: gui.Tr.LcFetchRemoteTags,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.SortOrder),
+			Handler:     gui.handleTagSortOrderMenu,
+			Description: gui.Tr.LcChangeSortOrder,
+			OpensMenu:   true,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(TAGS_CONTEXT_KEY)},
@@ -695,6 +770,41 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleSwitchToSubCommits,
 			Description: gui.Tr.LcViewCommits,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.GoInto),
+			Handler:     gui.withSelectedWorktree(gui.handleSwitchToWorktree),
+			Description: gui.Tr.LcSwitchToWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.New),
+			Handler:     gui.handleCreateWorktree,
+			Description: gui.Tr.LcCreateWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Remove),
+			Handler:     gui.withSelectedWorktree(gui.handleRemoveWorktree),
+			Description: gui.Tr.LcRemoveWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Worktrees.Prune),
+			Handler:     gui.handlePruneWorktrees,
+			Description: gui.Tr.LcPruneWorktrees,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Worktrees.ToggleLock),
+			Handler:     gui.withSelectedWorktree(gui.handleToggleWorktreeLock),
+			Description: gui.Tr.LcToggleWorktreeLock,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(REMOTE_BRANCHES_CONTEXT_KEY)},
@@ -732,6 +842,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Description: gui.Tr.LcOpenLogMenu,
 			OpensMenu:   true,
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.SearchHistory),
+			Handler:     gui.handleCommitSearchMenu,
+			Description: gui.Tr.LcSearchCommitHistory,
+			OpensMenu:   true,
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
@@ -823,6 +941,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCommitPick,
 			Description: gui.Tr.LcPickCommit,
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.InsertExecTodo),
+			Handler:     gui.handleInsertExecTodo,
+			Description: gui.Tr.LcInsertExecTodo,
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.InsertBreakTodo),
+			Handler:     gui.handleInsertBreakTodo,
+			Description: gui.Tr.LcInsertBreakTodo,
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
@@ -970,8 +1102,8 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "branches",
 			Contexts:    []string{string(SUB_COMMITS_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.GoInto),
-			Handler:     gui.handleViewSubCommitFiles,
-			Description: gui.Tr.LcViewCommitFiles,
+			Handler:     gui.handleEnterSubCommit,
+			Description: gui.Tr.LcViewCommitFilesOrGoToCommit,
 		},
 		{
 			ViewName:    "branches",
@@ -1053,6 +1185,18 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleNewBranchOffCurrentItem,
 			Description: gui.Tr.LcNewBranch,
 		},
+		{
+			ViewName:    "stash",
+			Key:         gui.getKey(config.Stash.RenameStash),
+			Handler:     gui.handleStashRename,
+			Description: gui.Tr.LcRenameStash,
+		},
+		{
+			ViewName:    "stash",
+			Key:         gui.getKey(config.Stash.CreateBranchFromStash),
+			Handler:     gui.handleStashCreateBranch,
+			Description: gui.Tr.LcCreateBranchFromStash,
+		},
 		{
 			ViewName: "commitMessage",
 			Key:      gui.getKey(config.Universal.SubmitEditorText),
@@ -1065,6 +1209,22 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Modifier: gocui.ModNone,
 			Handler:  gui.handleCommitClose,
 		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey(config.CommitMessage.AddTrailer),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCommitMessageTrailerMenu,
+			Description: gui.Tr.LcAddTrailer,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey(config.CommitMessage.ConventionalCommit),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleConventionalCommitMenu,
+			Description: gui.Tr.LcSetConventionalCommitType,
+			OpensMenu:   true,
+		},
 		{
 			ViewName: "credentials",
 			Key:      gui.getKey(config.Universal.Confirm),
@@ -1101,6 +1261,12 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCheckoutCommitFile,
 			Description: gui.Tr.LcCheckoutCommitFile,
 		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey(config.CommitFiles.ViewBlame),
+			Handler:     gui.handleBlameCommitFile,
+			Description: gui.Tr.LcViewBlame,
+		},
 		{
 			ViewName:    "commitFiles",
 			Key:         gui.getKey(config.Universal.Remove),
@@ -1484,6 +1650,34 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCommitEditorPress,
 			Description: gui.Tr.CommitChangesWithEditor,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_STAGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.StashAllChanges),
+			Handler:     gui.handleStashSelection,
+			Description: gui.Tr.LcStashSelectedLines,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Return),
+			Handler:     gui.handleBlameEscape,
+			Description: gui.Tr.LcExitBlame,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.GoInto),
+			Handler:     gui.handleGoToBlamedCommit,
+			Description: gui.Tr.LcGoToBlamedCommit,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Blame.BlameParent),
+			Handler:     gui.handleBlameParent,
+			Description: gui.Tr.LcBlameParent,
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
@@ -1512,6 +1706,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handlePickAllHunks,
 			Description: gui.Tr.PickAllHunks,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Main.ToggleThreeWayMergeView),
+			Handler:     gui.handleToggleThreeWayMergeView,
+			Description: gui.Tr.LcToggleThreeWayMergeView,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Main.EditConflict),
+			Handler:     gui.handleEditConflict,
+			Description: gui.Tr.LcEditConflict,
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
@@ -1589,6 +1797,76 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handlePopFileSnapshot,
 			Description: gui.Tr.LcUndo,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Return),
+			Handler:     gui.handleConflictEditEscape,
+			Description: gui.Tr.LcCancel,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Confirm),
+			Handler:     gui.handleApplyConflictEdit,
+			Description: gui.Tr.LcApplyConflictEdit,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Select),
+			Handler:     gui.handleConflictEditTogglePicked,
+			Description: gui.Tr.LcPickLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Undo),
+			Handler:     gui.handleConflictEditUnpickLast,
+			Description: gui.Tr.LcUnpickLastLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.PrevItem),
+			Handler:     gui.handleConflictEditPrevLine,
+			Description: gui.Tr.PrevLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.NextItem),
+			Handler:     gui.handleConflictEditNextLine,
+			Description: gui.Tr.NextLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:      gui.getKey(config.Universal.PrevItemAlt),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleConflictEditPrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:      gui.getKey(config.Universal.NextItemAlt),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleConflictEditNextLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:      gocui.MouseWheelUp,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleConflictEditPrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_CONFLICT_EDIT_CONTEXT_KEY)},
+			Key:      gocui.MouseWheelDown,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleConflictEditNextLine,
+		},
 		{
 			ViewName: "branches",
 			Contexts: []string{string(REMOTES_CONTEXT_KEY)},
@@ -86,6 +86,7 @@ func (gui *Gui) createAllViews() error {
 	gui.Views.Search.FgColor = gocui.ColorGreen
 	gui.Views.Search.Frame = false
 	gui.Views.Search.Editable = true
+	gui.Views.Search.Editor = gocui.EditorFunc(gui.searchEditor)
 
 	gui.Views.AppStatus.BgColor = gocui.ColorDefault
 	gui.Views.AppStatus.FgColor = gocui.ColorCyan
@@ -274,7 +275,9 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		view.SetOnSelectItem(gui.onSelectItemWrapper(listContext.onSearchSelect))
 	}
 
-	gui.Views.Main.SetOnSelectItem(gui.onSelectItemWrapper(gui.handlelineByLineNavigateTo))
+	if ContextKey(gui.Views.Main.Context) != BLAME_CONTEXT_KEY {
+		gui.Views.Main.SetOnSelectItem(gui.onSelectItemWrapper(gui.handlelineByLineNavigateTo))
+	}
 
 	mainViewWidth, mainViewHeight := gui.Views.Main.Size()
 	if mainViewWidth != gui.State.PrevMainWidth || mainViewHeight != gui.State.PrevMainHeight {
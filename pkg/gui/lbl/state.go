@@ -171,6 +171,21 @@ func (s *State) CurrentLineNumber() int {
 	return s.CurrentHunk().LineNumberOfLine(s.selectedLineIdx)
 }
 
+// SelectedOldLineNumberRange returns the line numbers of the first and last
+// selected lines as they appear in the original version of the file, i.e. the
+// 'before' side of the diff
+func (s *State) SelectedOldLineNumberRange() (int, int) {
+	firstLineIdx, lastLineIdx := s.SelectedRange()
+	first := s.patchParser.GetHunkContainingLine(firstLineIdx, 0).OldLineNumberOfLine(firstLineIdx)
+	last := s.patchParser.GetHunkContainingLine(lastLineIdx, 0).OldLineNumberOfLine(lastLineIdx)
+
+	if last < first {
+		return last, first
+	}
+
+	return first, last
+}
+
 func (s *State) AdjustSelectedLineIdx(change int) {
 	s.SelectLine(s.selectedLineIdx + change)
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 type ListContext struct {
@@ -22,6 +24,12 @@ type ListContext struct {
 	// content based on the selection (e.g. for showing the selected commit)
 	RenderSelection bool
 
+	// for lists that can be narrowed down to the items fuzzily matching a filter.
+	// The selected line index is an index into the filtered list, so the
+	// SelectedItem func must also take the filter into account
+	OnGetFilter func() string
+	OnSetFilter func(string)
+
 	Gui *Gui
 
 	*BasicContext
@@ -40,7 +48,11 @@ type IListContext interface {
 	handleGotoBottom() error
 	handlePrevPage() error
 	handleClick() error
+	handleToggleRangeSelect() error
 	onSearchSelect(selectedLineIdx int) error
+	CanFilter() bool
+	GetFilter() string
+	SetFilter(filter string) error
 	FocusLine()
 	HandleRenderToMain() error
 
@@ -56,6 +68,10 @@ func (self *ListContext) GetPanelState() IListPanelState {
 type IListPanelState interface {
 	SetSelectedLineIdx(int)
 	GetSelectedLineIdx() int
+	ToggleRangeSelect()
+	CancelRangeSelect()
+	IsSelectingRange() bool
+	GetSelectedRange() (int, int)
 }
 
 type ListItem interface {
@@ -77,12 +93,37 @@ func (self *ListContext) FocusLine() {
 	view.FocusPoint(view.OriginX(), self.GetPanelState().GetSelectedLineIdx())
 	if self.RenderSelection {
 		_, originY := view.Origin()
-		displayStrings := self.GetDisplayStrings(originY, view.InnerHeight())
+		displayStrings := self.getDisplayStrings(originY, view.InnerHeight())
 		self.Gui.renderDisplayStringsAtPos(view, originY, displayStrings)
 	}
 	view.Footer = formatListFooter(self.GetPanelState().GetSelectedLineIdx(), self.GetItemsLength())
 }
 
+// getDisplayStrings wraps GetDisplayStrings, highlighting any lines which are
+// part of the selected range
+func (self *ListContext) getDisplayStrings(startIdx int, length int) [][]string {
+	displayStrings := self.GetDisplayStrings(startIdx, length)
+
+	panelState := self.GetPanelState()
+	if !panelState.IsSelectingRange() {
+		return displayStrings
+	}
+
+	firstIdx, lastIdx := panelState.GetSelectedRange()
+	for i, row := range displayStrings {
+		lineIdx := startIdx + i
+		if lineIdx < firstIdx || lineIdx > lastIdx {
+			continue
+		}
+
+		for j, cell := range row {
+			row[j] = theme.SelectedRangeBgColor.Sprint(utils.Decolorise(cell))
+		}
+	}
+
+	return displayStrings
+}
+
 func formatListFooter(selectedLineIdx int, length int) string {
 	return fmt.Sprintf("%d of %d", selectedLineIdx+1, length)
 }
@@ -108,9 +149,16 @@ func (self *ListContext) HandleRender() error {
 		return nil
 	}
 
+	// a filter outlives focus, so we show it on the view itself. Otherwise
+	// the list would be silently filtered once we've moved to another panel
+	view.Subtitle = ""
+	if filter := self.GetFilter(); filter != "" {
+		view.Subtitle = fmt.Sprintf("%s '%s'", self.Gui.Tr.LcFilteringListBy, filter)
+	}
+
 	if self.GetDisplayStrings != nil {
 		self.Gui.refreshSelectedLine(self.GetPanelState(), self.GetItemsLength())
-		self.Gui.renderDisplayStrings(view, self.GetDisplayStrings(0, self.GetItemsLength()))
+		self.Gui.renderDisplayStrings(view, self.getDisplayStrings(0, self.GetItemsLength()))
 		self.Gui.render()
 	}
 
@@ -118,6 +166,8 @@ func (self *ListContext) HandleRender() error {
 }
 
 func (self *ListContext) HandleFocusLost() error {
+	self.cancelRangeSelect()
+
 	if self.OnFocusLost != nil {
 		return self.OnFocusLost()
 	}
@@ -206,6 +256,12 @@ func (self *ListContext) handleLineChange(change int) error {
 
 	self.Gui.changeSelectedLine(self.GetPanelState(), self.GetItemsLength(), change)
 
+	if self.GetPanelState().IsSelectingRange() {
+		if err := self.HandleRender(); err != nil {
+			return err
+		}
+	}
+
 	return self.HandleFocus()
 }
 
@@ -269,6 +325,57 @@ func (self *ListContext) handleClick() error {
 	return self.HandleFocus()
 }
 
+func (self *ListContext) handleToggleRangeSelect() error {
+	if self.ignoreKeybinding() {
+		return nil
+	}
+
+	self.GetPanelState().ToggleRangeSelect()
+
+	return self.HandleRender()
+}
+
+// cancelRangeSelect stops range-selecting, returning true if we were in fact
+// selecting a range
+func (self *ListContext) cancelRangeSelect() bool {
+	if !self.GetPanelState().IsSelectingRange() {
+		return false
+	}
+
+	self.GetPanelState().CancelRangeSelect()
+	_ = self.HandleRender()
+
+	return true
+}
+
+func (self *ListContext) CanFilter() bool {
+	return self.OnSetFilter != nil
+}
+
+func (self *ListContext) GetFilter() string {
+	if self.OnGetFilter == nil {
+		return ""
+	}
+
+	return self.OnGetFilter()
+}
+
+// SetFilter narrows the list down to the items matching the filter, selecting
+// the first of them
+func (self *ListContext) SetFilter(filter string) error {
+	if !self.CanFilter() {
+		return nil
+	}
+
+	self.OnSetFilter(filter)
+
+	panelState := self.GetPanelState()
+	panelState.CancelRangeSelect()
+	panelState.SetSelectedLineIdx(0)
+
+	return self.Gui.postRefreshUpdate(self)
+}
+
 func (self *ListContext) onSearchSelect(selectedLineIdx int) error {
 	self.GetPanelState().SetSelectedLineIdx(selectedLineIdx)
 	return self.HandleFocus()
@@ -53,6 +53,14 @@ func (gui *Gui) filesListContext() IListContext {
 			item := gui.getSelectedFileNode()
 			return item, item != nil
 		},
+		OnGetFilter: func() string { return gui.State.FileTreeViewModel.GetTextFilter() },
+		OnSetFilter: func(filter string) {
+			// this rebuilds the tree, which a files refresh may be doing at the same time
+			gui.State.FileTreeViewModel.RWMutex.Lock()
+			defer gui.State.FileTreeViewModel.RWMutex.Unlock()
+
+			gui.State.FileTreeViewModel.SetTextFilter(filter)
+		},
 	}
 }
 
@@ -64,17 +72,19 @@ func (gui *Gui) branchesListContext() IListContext {
 			Key:        LOCAL_BRANCHES_CONTEXT_KEY,
 			Kind:       SIDE_CONTEXT,
 		},
-		GetItemsLength:  func() int { return len(gui.State.Branches) },
+		GetItemsLength:  func() int { return gui.State.BranchTreeViewModel.GetItemsLength() },
 		OnGetPanelState: func() IListPanelState { return gui.State.Panels.Branches },
 		OnRenderToMain:  OnFocusWrapper(gui.branchesRenderToMain),
 		Gui:             gui,
 		GetDisplayStrings: func(startIdx int, length int) [][]string {
-			return presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
+			return presentation.RenderBranchTree(gui.State.BranchTreeViewModel, gui.State.PullRequests, gui.State.BranchDivergences, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedBranch()
 			return item, item != nil
 		},
+		OnGetFilter: func() string { return gui.State.BranchTreeViewModel.GetTextFilter() },
+		OnSetFilter: func(filter string) { gui.State.BranchTreeViewModel.SetTextFilter(filter) },
 	}
 }
 
@@ -109,17 +119,22 @@ func (gui *Gui) remoteBranchesListContext() IListContext {
 			Key:        REMOTE_BRANCHES_CONTEXT_KEY,
 			Kind:       SIDE_CONTEXT,
 		},
-		GetItemsLength:  func() int { return len(gui.State.RemoteBranches) },
+		GetItemsLength:  func() int { return len(gui.State.FilteredRemoteBranches) },
 		OnGetPanelState: func() IListPanelState { return gui.State.Panels.RemoteBranches },
 		OnRenderToMain:  OnFocusWrapper(gui.remoteBranchesRenderToMain),
 		Gui:             gui,
 		GetDisplayStrings: func(startIdx int, length int) [][]string {
-			return presentation.GetRemoteBranchListDisplayStrings(gui.State.RemoteBranches, gui.State.Modes.Diffing.Ref)
+			return presentation.GetRemoteBranchListDisplayStrings(gui.State.FilteredRemoteBranches, gui.State.Modes.Diffing.Ref)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedRemoteBranch()
 			return item, item != nil
 		},
+		OnGetFilter: func() string { return gui.State.Panels.RemoteBranches.filter },
+		OnSetFilter: func(filter string) {
+			gui.State.Panels.RemoteBranches.filter = filter
+			gui.filterRemoteBranches()
+		},
 	}
 }
 
@@ -131,17 +146,45 @@ func (gui *Gui) tagsListContext() IListContext {
 			Key:        TAGS_CONTEXT_KEY,
 			Kind:       SIDE_CONTEXT,
 		},
-		GetItemsLength:  func() int { return len(gui.State.Tags) },
+		GetItemsLength:  func() int { return len(gui.State.FilteredTags) },
 		OnGetPanelState: func() IListPanelState { return gui.State.Panels.Tags },
 		OnRenderToMain:  OnFocusWrapper(gui.tagsRenderToMain),
 		Gui:             gui,
 		GetDisplayStrings: func(startIdx int, length int) [][]string {
-			return presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.Modes.Diffing.Ref)
+			return presentation.GetTagListDisplayStrings(gui.State.FilteredTags, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedTag()
 			return item, item != nil
 		},
+		OnGetFilter: func() string { return gui.State.Panels.Tags.filter },
+		OnSetFilter: func(filter string) {
+			gui.State.Panels.Tags.filter = filter
+			gui.filterTags()
+		},
+	}
+}
+
+func (gui *Gui) worktreesListContext() IListContext {
+	return &ListContext{
+		BasicContext: &BasicContext{
+			ViewName:   "branches",
+			WindowName: "branches",
+			Key:        WORKTREES_CONTEXT_KEY,
+			Kind:       SIDE_CONTEXT,
+		},
+		GetItemsLength:      func() int { return len(gui.State.Worktrees) },
+		OnGetPanelState:     func() IListPanelState { return gui.State.Panels.Worktrees },
+		OnRenderToMain:      OnFocusWrapper(gui.worktreesRenderToMain),
+		OnClickSelectedItem: gui.withSelectedWorktree(gui.handleSwitchToWorktree),
+		Gui:                 gui,
+		GetDisplayStrings: func(startIdx int, length int) [][]string {
+			return presentation.GetWorktreeListDisplayStrings(gui.State.Worktrees)
+		},
+		SelectedItem: func() (ListItem, bool) {
+			item := gui.getSelectedWorktree()
+			return item, item != nil
+		},
 	}
 }
 
@@ -219,7 +262,8 @@ func (gui *Gui) subCommitsListContext() IListContext {
 				selectedCommitSha,
 				startIdx,
 				length,
-				gui.shouldShowGraph(),
+				// search results aren't contiguous, so a graph of them would be nonsense
+				gui.shouldShowGraph() && gui.State.Panels.SubCommits.search == nil,
 				git_commands.NewNullBisectInfo(),
 			)
 		},
@@ -331,6 +375,8 @@ func (gui *Gui) commitFilesListContext() IListContext {
 			item := gui.getSelectedCommitFileNode()
 			return item, item != nil
 		},
+		OnGetFilter: func() string { return gui.State.CommitFileTreeViewModel.GetTextFilter() },
+		OnSetFilter: func(filter string) { gui.State.CommitFileTreeViewModel.SetTextFilter(filter) },
 	}
 }
 
@@ -373,6 +419,27 @@ func (gui *Gui) suggestionsListContext() IListContext {
 	}
 }
 
+func (gui *Gui) blameListContext() IListContext {
+	return &ListContext{
+		BasicContext: &BasicContext{
+			ViewName: "main",
+			Key:      BLAME_CONTEXT_KEY,
+			Kind:     MAIN_CONTEXT,
+		},
+		GetItemsLength:      func() int { return len(gui.State.BlameLines) },
+		OnGetPanelState:     func() IListPanelState { return gui.State.Panels.Blame },
+		OnFocusLost:         gui.onBlameFocusLost,
+		OnClickSelectedItem: gui.handleGoToBlamedCommit,
+		Gui:                 gui,
+		SelectedItem: func() (ListItem, bool) {
+			item := gui.getSelectedBlameLine()
+			return item, item != nil
+		},
+
+		// no GetDisplayStrings field because the blame is rendered as a task on the main view
+	}
+}
+
 func (gui *Gui) getListContexts() []IListContext {
 	return []IListContext{
 		gui.State.Contexts.Menu,
@@ -381,6 +448,7 @@ func (gui *Gui) getListContexts() []IListContext {
 		gui.State.Contexts.Remotes,
 		gui.State.Contexts.RemoteBranches,
 		gui.State.Contexts.Tags,
+		gui.State.Contexts.Worktrees,
 		gui.State.Contexts.BranchCommits,
 		gui.State.Contexts.ReflogCommits,
 		gui.State.Contexts.SubCommits,
@@ -388,6 +456,7 @@ func (gui *Gui) getListContexts() []IListContext {
 		gui.State.Contexts.CommitFiles,
 		gui.State.Contexts.Submodules,
 		gui.State.Contexts.Suggestions,
+		gui.State.Contexts.Blame,
 	}
 }
 
@@ -441,6 +510,28 @@ func (gui *Gui) getListContextKeyBindings() []*Binding {
 				Tag:         "navigation",
 			},
 		}...)
+
+		if listContext.CanFilter() {
+			bindings = append(bindings, &Binding{
+				ViewName:    listContext.GetViewName(),
+				Contexts:    []string{string(listContext.GetKey())},
+				Key:         gui.getKey(keybindingConfig.Universal.StartFilter),
+				Handler:     func() error { return gui.handleOpenFilter(listContext) },
+				Description: gui.Tr.LcStartFilter,
+				Tag:         "navigation",
+			})
+		}
+
+		if listContext.GetKind() == SIDE_CONTEXT {
+			bindings = append(bindings, &Binding{
+				ViewName:    listContext.GetViewName(),
+				Contexts:    []string{string(listContext.GetKey())},
+				Key:         gui.getKey(keybindingConfig.Universal.ToggleRangeSelect),
+				Handler:     listContext.handleToggleRangeSelect,
+				Description: gui.Tr.LcToggleRangeSelect,
+				Tag:         "navigation",
+			})
+		}
 	}
 
 	return bindings
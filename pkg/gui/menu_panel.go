@@ -44,6 +44,8 @@ func (gui *Gui) handleMenuClose() error {
 
 type createMenuOptions struct {
 	showCancel bool
+	// the line the cursor starts on
+	selectedLineIdx int
 }
 
 func (gui *Gui) createMenu(title string, items []*menuItem, createMenuOptions createMenuOptions) error {
@@ -86,7 +88,7 @@ func (gui *Gui) createMenu(title string, items []*menuItem, createMenuOptions cr
 		return nil
 	}))
 	menuView.SetContent(list)
-	gui.State.Panels.Menu.SelectedLineIdx = 0
+	gui.State.Panels.Menu.SelectedLineIdx = createMenuOptions.selectedLineIdx
 
 	return gui.pushContext(gui.State.Contexts.Menu)
 }
@@ -9,8 +9,11 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/types/enums"
 	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/mattn/go-runewidth"
 )
 
 func (gui *Gui) handleSelectPrevConflictHunk() error {
@@ -45,6 +48,14 @@ func (gui *Gui) handleSelectPrevConflict() error {
 	})
 }
 
+func (gui *Gui) handleToggleThreeWayMergeView() error {
+	return gui.withMergeConflictLock(func() error {
+		gui.takeOverMergeConflictScrolling()
+		gui.State.Panels.Merging.ThreeWay = !gui.State.Panels.Merging.ThreeWay
+		return gui.refreshMergePanel()
+	})
+}
+
 func (gui *Gui) pushFileSnapshot() error {
 	content, err := gui.catSelectedFile()
 	if err != nil {
@@ -143,7 +154,7 @@ func (gui *Gui) resolveConflict(selection mergeconflicts.Selection) (bool, error
 	case mergeconflicts.ALL:
 		logStr = "Picking all hunks"
 	}
-	gui.logAction("Resolve merge conflict")
+	gui.logAction(gui.Tr.Actions.ResolveMergeConflict)
 	gui.logCommand(logStr, false)
 	return true, ioutil.WriteFile(gitFile.Name, []byte(output), 0644)
 }
@@ -178,6 +189,10 @@ func (gui *Gui) renderConflicts(state *mergeconflicts.State, hasFocus bool) (boo
 		return false, gui.handleCompleteMerge()
 	}
 
+	if gui.State.Panels.Merging.ThreeWay {
+		return true, gui.renderThreeWayConflicts(cat, state, hasFocus)
+	}
+
 	content := mergeconflicts.ColoredConflictFile(cat, state, hasFocus)
 
 	if !gui.State.Panels.Merging.UserVerticalScrolling {
@@ -198,6 +213,70 @@ func (gui *Gui) renderConflicts(state *mergeconflicts.State, hasFocus bool) (boo
 	})
 }
 
+// renderThreeWayConflicts shows the base, ours and theirs versions of the file
+// side by side in the main view, with the file as it currently stands (i.e. the
+// result of the hunks we've picked so far) in the secondary view
+func (gui *Gui) renderThreeWayConflicts(cat string, state *mergeconflicts.State, hasFocus bool) error {
+	stages, err := gui.conflictStages()
+	if err != nil {
+		return err
+	}
+
+	content, conflictLine := mergeconflicts.RenderThreeWay(cat, stages, state, hasFocus, gui.threeWayColumnWidth())
+	result := mergeconflicts.ColoredConflictFile(cat, state, hasFocus)
+
+	if !gui.State.Panels.Merging.UserVerticalScrolling {
+		gui.OnUIThread(func() error {
+			gui.centerYPos(gui.Views.Main, conflictLine)
+			gui.centerYPos(gui.Views.Secondary, state.GetConflictMiddle())
+			return nil
+		})
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title:  gui.Tr.ThreeWayMergeTitle,
+			task:   NewRenderStringWithoutScrollTask(content),
+			noWrap: true,
+		},
+		secondary: &viewUpdateOpts{
+			title:  gui.Tr.MergeResultTitle,
+			task:   NewRenderStringWithoutScrollTask(result),
+			noWrap: true,
+		},
+	})
+}
+
+func (gui *Gui) conflictStages() (mergeconflicts.Stages, error) {
+	file := gui.getSelectedFile()
+	if file == nil {
+		return mergeconflicts.Stages{}, errors.New(gui.Tr.NoFilesDisplay)
+	}
+
+	// a stage is missing when e.g. both sides added the file (so there's no
+	// base) in which case we just show that side as empty
+	base, _ := gui.Git.File.ShowStage(file.Name, git_commands.STAGE_BASE)
+	ours, _ := gui.Git.File.ShowStage(file.Name, git_commands.STAGE_OURS)
+	theirs, _ := gui.Git.File.ShowStage(file.Name, git_commands.STAGE_THEIRS)
+
+	return mergeconflicts.Stages{Base: base, Ours: ours, Theirs: theirs}, nil
+}
+
+// threeWayColumnWidth returns how wide each of the base, ours and theirs columns
+// can be given we're about to split the main panel to show the result pane
+func (gui *Gui) threeWayColumnWidth() int {
+	width, _ := gui.Views.Main.Size()
+	if !gui.isMainPanelSplit() {
+		gui.splitMainPanel(true)
+		if gui.splitMainPanelSideBySide() {
+			width = width / 2
+		}
+	}
+
+	separatorWidth := runewidth.StringWidth(mergeconflicts.THREE_WAY_SEPARATOR)
+	return utils.Max(1, (width-2*separatorWidth)/3)
+}
+
 func (gui *Gui) refreshMergePanel() error {
 	conflictsFound, err := gui.renderConflicts(gui.State.Panels.Merging.State, true)
 	if err != nil {
@@ -242,9 +321,11 @@ func (gui *Gui) getMergingOptions() map[string]string {
 	return map[string]string{
 		fmt.Sprintf("%s %s", gui.getKeyDisplay(keybindingConfig.Universal.PrevItem), gui.getKeyDisplay(keybindingConfig.Universal.NextItem)):   gui.Tr.LcSelectHunk,
 		fmt.Sprintf("%s %s", gui.getKeyDisplay(keybindingConfig.Universal.PrevBlock), gui.getKeyDisplay(keybindingConfig.Universal.NextBlock)): gui.Tr.LcNavigateConflicts,
-		gui.getKeyDisplay(keybindingConfig.Universal.Select):   gui.Tr.LcPickHunk,
-		gui.getKeyDisplay(keybindingConfig.Main.PickBothHunks): gui.Tr.LcPickAllHunks,
-		gui.getKeyDisplay(keybindingConfig.Universal.Undo):     gui.Tr.LcUndo,
+		gui.getKeyDisplay(keybindingConfig.Universal.Select):             gui.Tr.LcPickHunk,
+		gui.getKeyDisplay(keybindingConfig.Main.PickBothHunks):           gui.Tr.LcPickAllHunks,
+		gui.getKeyDisplay(keybindingConfig.Universal.Undo):               gui.Tr.LcUndo,
+		gui.getKeyDisplay(keybindingConfig.Main.ToggleThreeWayMergeView): gui.Tr.LcToggleThreeWayMergeView,
+		gui.getKeyDisplay(keybindingConfig.Main.EditConflict):            gui.Tr.LcEditConflict,
 	}
 }
 
@@ -276,6 +357,7 @@ func (gui *Gui) escapeMerge() error {
 	gui.takeOverMergeConflictScrolling()
 
 	gui.State.Panels.Merging.Reset()
+	gui.State.Panels.Merging.Edit = nil
 
 	// it's possible this method won't be called from the merging view so we need to
 	// ensure we only 'return' focus if we already have it
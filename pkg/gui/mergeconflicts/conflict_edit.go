@@ -0,0 +1,201 @@
+package mergeconflicts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// ConflictEditLine is a line from one side of a conflict
+type ConflictEditLine struct {
+	// the side of the conflict the line comes from: TOP for ours, MIDDLE for the
+	// base (when using diff3) and BOTTOM for theirs
+	Side    Selection
+	Content string
+	// the line as it appears in the file, including its line ending, so that
+	// we don't turn e.g. CRLF line endings into LF ones
+	raw string
+}
+
+// ConflictEdit lets the user resolve a conflict by picking lines from either side
+// of it, in whatever order they like, rather than taking a whole side at once
+type ConflictEdit struct {
+	lines           []ConflictEditLine
+	selectedLineIdx int
+	// indices into lines, in the order the lines were picked
+	picked []int
+}
+
+// NewConflictEdit returns an edit of the currently selected conflict, given the
+// content of the file (with conflict markers). It returns nil if there is no
+// conflict to edit
+func (s *State) NewConflictEdit(content string) *ConflictEdit {
+	conflict := s.currentConflict()
+	if conflict == nil {
+		return nil
+	}
+
+	lines := []ConflictEditLine{}
+	for i, line := range strings.SplitAfter(content, "\n") {
+		if i <= conflict.start || i >= conflict.end || conflict.isMarkerLine(i) {
+			continue
+		}
+
+		for _, side := range availableSelections(conflict) {
+			if side.selected(conflict, i) {
+				lines = append(lines, ConflictEditLine{
+					Side:    side,
+					Content: strings.TrimRight(line, "\r\n"),
+					raw:     line,
+				})
+				break
+			}
+		}
+	}
+
+	return &ConflictEdit{lines: lines, picked: []int{}}
+}
+
+func (e *ConflictEdit) SelectNextLine() {
+	e.selectedLineIdx = clamp(e.selectedLineIdx+1, 0, utils.Max(0, len(e.lines)-1))
+}
+
+func (e *ConflictEdit) SelectPrevLine() {
+	e.selectedLineIdx = clamp(e.selectedLineIdx-1, 0, utils.Max(0, len(e.lines)-1))
+}
+
+func (e *ConflictEdit) GetSelectedLineIdx() int {
+	return e.selectedLineIdx
+}
+
+// TogglePicked adds the selected line to the end of the result, or removes it
+// from the result if it's already been picked
+func (e *ConflictEdit) TogglePicked() {
+	if len(e.lines) == 0 {
+		return
+	}
+
+	if position := e.pickedPosition(e.selectedLineIdx); position != -1 {
+		e.picked = append(e.picked[:position], e.picked[position+1:]...)
+		return
+	}
+
+	e.picked = append(e.picked, e.selectedLineIdx)
+}
+
+// UnpickLast removes the most recently picked line from the result, returning
+// false if nothing has been picked
+func (e *ConflictEdit) UnpickLast() bool {
+	if len(e.picked) == 0 {
+		return false
+	}
+
+	e.picked = e.picked[:len(e.picked)-1]
+	return true
+}
+
+func (e *ConflictEdit) pickedPosition(lineIdx int) int {
+	for position, idx := range e.picked {
+		if idx == lineIdx {
+			return position
+		}
+	}
+
+	return -1
+}
+
+// Result returns the picked lines in the order they were picked
+func (e *ConflictEdit) Result() []string {
+	result := make([]string, len(e.picked))
+	for i, idx := range e.picked {
+		result[i] = e.lines[idx].Content
+	}
+
+	return result
+}
+
+// ResultContent returns the picked lines as they should be written to the file
+func (e *ConflictEdit) ResultContent() string {
+	result := ""
+	for _, idx := range e.picked {
+		result += e.lines[idx].raw
+	}
+
+	return result
+}
+
+// Render shows each line of the conflict alongside its side, and its position in
+// the result if it's been picked
+func (e *ConflictEdit) Render(hasFocus bool) string {
+	positionWidth := len(fmt.Sprintf("%d", len(e.lines)))
+
+	rows := make([]string, len(e.lines))
+	for i, line := range e.lines {
+		position := strings.Repeat(" ", positionWidth)
+		textStyle := theme.DefaultTextColor
+		if pickedPosition := e.pickedPosition(i); pickedPosition != -1 {
+			position = fmt.Sprintf("%*d", positionWidth, pickedPosition+1)
+			textStyle = sideTextStyle(line.Side)
+		}
+
+		if hasFocus && i == e.selectedLineIdx {
+			textStyle = textStyle.MergeStyle(theme.SelectedRangeBgColor).SetBold()
+		}
+
+		rows[i] = textStyle.Sprint(fmt.Sprintf("[%s] %-6s %s", position, sideLabel(line.Side), line.Content))
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+func sideLabel(side Selection) string {
+	switch side {
+	case TOP:
+		return "ours"
+	case MIDDLE:
+		return "base"
+	default:
+		return "theirs"
+	}
+}
+
+// these match the colours of each side in the three-way view
+func sideTextStyle(side Selection) style.TextStyle {
+	switch side {
+	case TOP:
+		return style.FgGreen
+	case MIDDLE:
+		return style.FgCyan
+	default:
+		return style.FgBlue
+	}
+}
+
+// ContentAfterConflictEdit returns the content of the file at the given path with
+// the current conflict replaced by the given content
+func (s *State) ContentAfterConflictEdit(path string, result string) (bool, string, error) {
+	conflict := s.currentConflict()
+	if conflict == nil {
+		return false, "", nil
+	}
+
+	content := ""
+	err := utils.ForEachLineInFile(path, func(line string, i int) {
+		if i == conflict.start {
+			content += result
+		}
+
+		if i < conflict.start || conflict.end < i {
+			content += line
+		}
+	})
+
+	if err != nil {
+		return false, "", err
+	}
+
+	return true, content, nil
+}
@@ -0,0 +1,166 @@
+package mergeconflicts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflictEdit(t *testing.T) {
+	type scenario struct {
+		name     string
+		content  string
+		actions  func(edit *ConflictEdit)
+		expected []string
+	}
+
+	content := `before
+<<<<<<< HEAD
+ours 1
+ours 2
+||||||| fffffff
+base 1
+=======
+theirs 1
+theirs 2
+>>>>>>> branch
+after
+`
+
+	scenarios := []scenario{
+		{
+			name:     "nothing picked",
+			content:  content,
+			actions:  func(edit *ConflictEdit) {},
+			expected: []string{},
+		},
+		{
+			name:    "lines picked from both sides in order",
+			content: content,
+			actions: func(edit *ConflictEdit) {
+				// theirs 1
+				for i := 0; i < 3; i++ {
+					edit.SelectNextLine()
+				}
+				edit.TogglePicked()
+				// ours 1
+				for i := 0; i < 3; i++ {
+					edit.SelectPrevLine()
+				}
+				edit.TogglePicked()
+				// theirs 2
+				for i := 0; i < 10; i++ {
+					edit.SelectNextLine()
+				}
+				edit.TogglePicked()
+			},
+			expected: []string{"theirs 1", "ours 1", "theirs 2"},
+		},
+		{
+			name:    "toggling a picked line removes it",
+			content: content,
+			actions: func(edit *ConflictEdit) {
+				edit.TogglePicked()
+				edit.SelectNextLine()
+				edit.TogglePicked()
+				edit.SelectPrevLine()
+				edit.TogglePicked()
+			},
+			expected: []string{"ours 2"},
+		},
+		{
+			name:    "unpicking the last line",
+			content: content,
+			actions: func(edit *ConflictEdit) {
+				edit.SelectNextLine()
+				edit.TogglePicked()
+				edit.SelectNextLine()
+				edit.TogglePicked()
+				edit.UnpickLast()
+			},
+			expected: []string{"ours 2"},
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			state := NewState()
+			state.SetConflictsFromCat(s.content)
+			edit := state.NewConflictEdit(s.content)
+			s.actions(edit)
+			assert.EqualValues(t, s.expected, edit.Result())
+		})
+	}
+}
+
+func TestContentAfterConflictEdit(t *testing.T) {
+	content := `before
+<<<<<<< HEAD
+ours
+=======
+theirs
+>>>>>>> branch
+middle
+<<<<<<< HEAD
+ours again
+=======
+theirs again
+>>>>>>> branch
+after
+`
+
+	dir, err := ioutil.TempDir("", "lazygit-conflict-edit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "file.txt")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	state := NewState()
+	state.SetConflictsFromCat(content)
+
+	ok, output, err := state.ContentAfterConflictEdit(path, "theirs\nours\n")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, `before
+theirs
+ours
+middle
+<<<<<<< HEAD
+ours again
+=======
+theirs again
+>>>>>>> branch
+after
+`, output)
+}
+
+func TestConflictEditKeepsLineEndings(t *testing.T) {
+	content := "before\r\n<<<<<<< HEAD\r\nours\r\n=======\r\ntheirs\r\n>>>>>>> branch\r\nafter\r\n"
+
+	dir, err := ioutil.TempDir("", "lazygit-conflict-edit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "file.txt")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+
+	state := NewState()
+	state.SetConflictsFromCat(content)
+	edit := state.NewConflictEdit(content)
+	edit.SelectNextLine()
+	edit.TogglePicked()
+	edit.SelectPrevLine()
+	edit.TogglePicked()
+
+	assert.EqualValues(t, []string{"theirs", "ours"}, edit.Result())
+
+	ok, output, err := state.ContentAfterConflictEdit(path, edit.ResultContent())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.EqualValues(t, "before\r\ntheirs\r\nours\r\nafter\r\n", output)
+}
@@ -0,0 +1,235 @@
+package mergeconflicts
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/mattn/go-runewidth"
+)
+
+// Stages holds the versions of a conflicted file at each index stage i.e. the
+// output of `git show :1:<path>`, `git show :2:<path>` and `git show :3:<path>`.
+// Base is empty if there is no common ancestor e.g. when both sides added the file
+type Stages struct {
+	Base   string
+	Ours   string
+	Theirs string
+}
+
+// THREE_WAY_SEPARATOR goes between the base, ours and theirs columns
+const THREE_WAY_SEPARATOR = " │ "
+
+// when we can't find a conflict's own lines in a stage (e.g. because the
+// conflict markers don't include the base, or one side deleted the lines) we
+// look for this many lines of context either side of the conflict instead
+const contextLineCount = 3
+
+type threeWayColumn struct {
+	lines []string
+	// the lines of the current conflict within this column, if we found them
+	hunkStart int
+	hunkEnd   int
+	found     bool
+	// the selections which pick this column's side of the conflict
+	selections []Selection
+	textStyle  style.TextStyle
+}
+
+func (c *threeWayColumn) inHunk(i int) bool {
+	return c.found && i >= c.hunkStart && i < c.hunkEnd
+}
+
+func (c *threeWayColumn) isSelected(selection Selection) bool {
+	for _, s := range c.selections {
+		if s == selection {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderThreeWay renders the base, ours and theirs versions of a conflicted file
+// side by side in columns of the given width, lined up so that the selected
+// conflict appears on the same line in each column. Content is the working tree
+// version of the file, with conflict markers. It returns the line of the output
+// that the selected conflict starts on
+func RenderThreeWay(content string, stages Stages, state *State, hasFocus bool, columnWidth int) (string, int) {
+	columns := []*threeWayColumn{
+		{lines: utils.SplitLines(stages.Base), selections: []Selection{MIDDLE}, textStyle: style.FgCyan},
+		{lines: utils.SplitLines(stages.Ours), selections: []Selection{TOP, ALL}, textStyle: style.FgGreen},
+		{lines: utils.SplitLines(stages.Theirs), selections: []Selection{BOTTOM, ALL}, textStyle: style.FgBlue},
+	}
+	base, ours, theirs := columns[0], columns[1], columns[2]
+
+	conflict := state.currentConflict()
+	if conflict != nil {
+		contentLines := utils.SplitLines(content)
+		before, after := conflictContext(contentLines, state.conflicts, state.conflictIndex)
+
+		oursEnd := conflict.target
+		if conflict.hasAncestor() {
+			oursEnd = conflict.ancestor
+			base.hunkStart, base.hunkEnd, base.found = locateHunk(base.lines, contentLines[conflict.ancestor+1:conflict.target], before, after)
+		} else {
+			// without diff3 markers we don't know what the base looked like so we
+			// rely on the surrounding context to find it
+			base.hunkStart, base.hunkEnd, base.found = locateHunk(base.lines, nil, before, after)
+		}
+		ours.hunkStart, ours.hunkEnd, ours.found = locateHunk(ours.lines, contentLines[conflict.start+1:oursEnd], before, after)
+		theirs.hunkStart, theirs.hunkEnd, theirs.found = locateHunk(theirs.lines, contentLines[conflict.target+1:conflict.end], before, after)
+	}
+
+	// we line the columns up on the conflict, padding out the shorter hunks so
+	// that the lines after the conflict line up too
+	maxHunkLength := 0
+	for _, column := range columns {
+		if column.found {
+			maxHunkLength = utils.Max(maxHunkLength, column.hunkEnd-column.hunkStart)
+		}
+	}
+
+	anchor := 0
+	for _, column := range []*threeWayColumn{ours, theirs, base} {
+		if column.found {
+			anchor = column.hunkStart
+			break
+		}
+	}
+
+	selection := state.Selection()
+	cellsByColumn := make([][]string, len(columns))
+	offsets := make([]int, len(columns))
+	firstRow, lastRow := 0, 0
+	for i, column := range columns {
+		cells := []string{}
+		for lineIdx, line := range column.lines {
+			if column.found && lineIdx == column.hunkEnd {
+				cells = append(cells, hunkPadding(column, maxHunkLength, columnWidth)...)
+			}
+
+			textStyle := theme.DefaultTextColor
+			if column.inHunk(lineIdx) {
+				textStyle = column.textStyle
+				if hasFocus && column.isSelected(selection) {
+					textStyle = textStyle.MergeStyle(theme.SelectedRangeBgColor).SetBold()
+				}
+			}
+			cells = append(cells, textStyle.Sprint(formatCell(line, columnWidth)))
+		}
+		if column.found && column.hunkEnd == len(column.lines) {
+			cells = append(cells, hunkPadding(column, maxHunkLength, columnWidth)...)
+		}
+
+		cellsByColumn[i] = cells
+		if column.found {
+			offsets[i] = column.hunkStart - anchor
+		}
+		firstRow = utils.Min(firstRow, -offsets[i])
+		lastRow = utils.Max(lastRow, len(cells)-offsets[i])
+	}
+
+	rows := make([]string, 0, lastRow-firstRow)
+	for row := firstRow; row < lastRow; row++ {
+		rowCells := make([]string, len(columns))
+		for i, cells := range cellsByColumn {
+			cellIdx := row + offsets[i]
+			if cellIdx >= 0 && cellIdx < len(cells) {
+				rowCells[i] = cells[cellIdx]
+			} else {
+				rowCells[i] = formatCell("", columnWidth)
+			}
+		}
+		rows = append(rows, strings.Join(rowCells, THREE_WAY_SEPARATOR))
+	}
+
+	return strings.Join(rows, "\n"), anchor - firstRow
+}
+
+func formatCell(line string, width int) string {
+	line = strings.ReplaceAll(line, "\t", "    ")
+	return utils.WithPadding(runewidth.Truncate(line, width, ""), width)
+}
+
+// hunkPadding returns the blank cells we need after a column's hunk for it to be
+// as long as the longest hunk
+func hunkPadding(column *threeWayColumn, maxHunkLength int, width int) []string {
+	padding := make([]string, maxHunkLength-(column.hunkEnd-column.hunkStart))
+	for i := range padding {
+		padding[i] = formatCell("", width)
+	}
+	return padding
+}
+
+// conflictContext returns the lines either side of the given conflict, stopping
+// at any neighbouring conflict
+func conflictContext(lines []string, conflicts []*mergeConflict, index int) ([]string, []string) {
+	conflict := conflicts[index]
+
+	beforeStart := utils.Max(0, conflict.start-contextLineCount)
+	if index > 0 {
+		beforeStart = utils.Max(beforeStart, conflicts[index-1].end+1)
+	}
+
+	afterEnd := utils.Min(len(lines), conflict.end+1+contextLineCount)
+	if index < len(conflicts)-1 {
+		afterEnd = utils.Min(afterEnd, conflicts[index+1].start)
+	}
+
+	return lines[beforeStart:conflict.start], lines[conflict.end+1 : afterEnd]
+}
+
+// locateHunk finds where the given hunk lives within the lines of a stage. If the
+// hunk is empty, or we can't find it, we instead look for the context either side
+// of it
+func locateHunk(lines []string, hunk []string, before []string, after []string) (int, int, bool) {
+	if len(hunk) > 0 {
+		// a short hunk like a lone '}' could well appear earlier in the file, so
+		// we look for it after the preceding context first
+		if len(before) > 0 {
+			if beforeIdx := indexOfLines(lines, before, 0); beforeIdx != -1 {
+				if start := indexOfLines(lines, hunk, beforeIdx+len(before)); start != -1 {
+					return start, start + len(hunk), true
+				}
+			}
+		}
+
+		if start := indexOfLines(lines, hunk, 0); start != -1 {
+			return start, start + len(hunk), true
+		}
+	}
+
+	start := 0
+	if len(before) > 0 {
+		idx := indexOfLines(lines, before, 0)
+		if idx == -1 {
+			return 0, 0, false
+		}
+		start = idx + len(before)
+	}
+
+	end := len(lines)
+	if len(after) > 0 {
+		end = indexOfLines(lines, after, start)
+		if end == -1 {
+			return 0, 0, false
+		}
+	}
+
+	return start, end, true
+}
+
+func indexOfLines(lines []string, needle []string, from int) int {
+outer:
+	for i := from; i+len(needle) <= len(lines); i++ {
+		for j, line := range needle {
+			if lines[i+j] != line {
+				continue outer
+			}
+		}
+		return i
+	}
+
+	return -1
+}
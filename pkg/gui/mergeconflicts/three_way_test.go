@@ -0,0 +1,125 @@
+package mergeconflicts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderThreeWay(t *testing.T) {
+	type scenario struct {
+		name            string
+		content         string
+		stages          Stages
+		expected        string
+		expectedLineIdx int
+	}
+
+	scenarios := []scenario{
+		{
+			name: "without base markers",
+			content: `a
+b
+<<<<<<< HEAD
+ours
+=======
+theirs1
+theirs2
+>>>>>>> branch
+c
+`,
+			stages: Stages{
+				Base:   "a\nb\nbase\nc\n",
+				Ours:   "a\nb\nours\nc\n",
+				Theirs: "x\na\nb\ntheirs1\ntheirs2\nc\n",
+			},
+			expected: `         │          │ x
+a        │ a        │ a
+b        │ b        │ b
+base     │ ours     │ theirs1
+         │          │ theirs2
+c        │ c        │ c`,
+			expectedLineIdx: 3,
+		},
+		{
+			name: "with base markers",
+			content: `<<<<<<< HEAD
+ours
+||||||| base
+base
+=======
+>>>>>>> branch
+c
+`,
+			stages: Stages{
+				Base:   "z\nbase\nc\n",
+				Ours:   "ours\nc\n",
+				Theirs: "c\n",
+			},
+			expected: `z        │          │
+base     │ ours     │
+c        │ c        │ c`,
+			expectedLineIdx: 1,
+		},
+		{
+			name: "hunk line appearing earlier in the file",
+			content: `}
+x
+<<<<<<< HEAD
+}
+=======
+y
+>>>>>>> branch
+z
+`,
+			stages: Stages{
+				Base:   "}\nx\nbase\nz\n",
+				Ours:   "}\nx\n}\nz\n",
+				Theirs: "}\nx\ny\nz\n",
+			},
+			expected: `}        │ }        │ }
+x        │ x        │ x
+base     │ }        │ y
+z        │ z        │ z`,
+			expectedLineIdx: 2,
+		},
+		{
+			name: "added on both sides",
+			content: `<<<<<<< HEAD
+ours
+=======
+theirs
+>>>>>>> branch
+`,
+			stages: Stages{
+				Ours:   "ours\n",
+				Theirs: "theirs\n",
+			},
+			expected:        `         │ ours     │ theirs`,
+			expectedLineIdx: 0,
+		},
+	}
+
+	for _, s := range scenarios {
+		s := s
+		t.Run(s.name, func(t *testing.T) {
+			state := NewState()
+			state.SetConflictsFromCat(s.content)
+
+			content, lineIdx := RenderThreeWay(s.content, s.stages, state, true, 8)
+
+			lines := strings.Split(utils.Decolorise(content), "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimRight(line, " ")
+			}
+			expectedLines := strings.Split(s.expected, "\n")
+			for i, line := range expectedLines {
+				expectedLines[i] = strings.TrimRight(line, " ")
+			}
+			assert.EqualValues(t, expectedLines, lines)
+			assert.EqualValues(t, s.expectedLineIdx, lineIdx)
+		})
+	}
+}
@@ -15,6 +15,24 @@ type modeStatus struct {
 
 func (gui *Gui) modeStatuses() []modeStatus {
 	return []modeStatus{
+		{
+			isActive: func() bool {
+				_, ok := gui.currentFilteredListContext()
+				return ok
+			},
+			description: func() string {
+				listContext, _ := gui.currentFilteredListContext()
+				return gui.withResetButton(
+					fmt.Sprintf(
+						"%s '%s'",
+						gui.Tr.LcFilteringListBy,
+						listContext.GetFilter(),
+					),
+					style.FgBlue,
+				)
+			},
+			reset: gui.clearCurrentListFilter,
+		},
 		{
 			isActive: gui.State.Modes.Diffing.Active,
 			description: func() string {
@@ -43,7 +61,7 @@ func (gui *Gui) modeStatuses() []modeStatus {
 					fmt.Sprintf(
 						"%s '%s'",
 						gui.Tr.LcFilteringBy,
-						gui.State.Modes.Filtering.GetPath(),
+						gui.State.Modes.Filtering.GetDescription(),
 					),
 					style.FgRed,
 				)
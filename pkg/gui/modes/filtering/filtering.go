@@ -1,7 +1,14 @@
 package filtering
 
+import "fmt"
+
 type Filtering struct {
 	path string // the filename that gets passed to git log
+
+	// if set, we only show commits that touched these lines of the file (via
+	// `git log -L`). Line numbers start at 1 so zero means no line range
+	fromLine int
+	toLine   int
 }
 
 func New(path string) Filtering {
@@ -14,12 +21,45 @@ func (m *Filtering) Active() bool {
 
 func (m *Filtering) Reset() {
 	m.path = ""
+	m.fromLine = 0
+	m.toLine = 0
 }
 
 func (m *Filtering) SetPath(path string) {
 	m.path = path
+	m.fromLine = 0
+	m.toLine = 0
 }
 
 func (m *Filtering) GetPath() string {
 	return m.path
 }
+
+func (m *Filtering) SetLineRange(path string, fromLine int, toLine int) {
+	m.path = path
+	m.fromLine = fromLine
+	m.toLine = toLine
+}
+
+func (m *Filtering) HasLineRange() bool {
+	return m.Active() && m.fromLine > 0
+}
+
+// GetLineRange returns the line range in the format expected by `git log -L`
+// e.g. '10,20:path/to/file', or an empty string if we're not filtering by lines
+func (m *Filtering) GetLineRange() string {
+	if !m.HasLineRange() {
+		return ""
+	}
+
+	return fmt.Sprintf("%d,%d:%s", m.fromLine, m.toLine, m.path)
+}
+
+// GetDescription returns what we're filtering by, for display purposes
+func (m *Filtering) GetDescription() string {
+	if !m.HasLineRange() {
+		return m.path
+	}
+
+	return fmt.Sprintf("%s:%d-%d", m.path, m.fromLine, m.toLine)
+}
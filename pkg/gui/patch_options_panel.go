@@ -8,7 +8,7 @@ import (
 
 func (gui *Gui) handleCreatePatchOptionsMenu() error {
 	if !gui.Git.Patch.PatchManager.Active() {
-		return gui.createErrorPanel(gui.Tr.NoPatchError)
+		return gui.createMenu(gui.Tr.PatchOptionsTitle, gui.patchFileMenuItems(), createMenuOptions{showCancel: true})
 	}
 
 	menuItems := []*menuItem{
@@ -61,6 +61,18 @@ func (gui *Gui) handleCreatePatchOptionsMenu() error {
 		}
 	}
 
+	menuItems = append(menuItems, []*menuItem{
+		{
+			displayString: gui.Tr.LcExportPatch,
+			onPress:       gui.handleExportPatch,
+		},
+		{
+			displayString: gui.Tr.LcSavePatch,
+			onPress:       gui.handleSavePatch,
+		},
+	}...)
+	menuItems = append(menuItems, gui.patchFileMenuItems()...)
+
 	return gui.createMenu(gui.Tr.PatchOptionsTitle, menuItems, createMenuOptions{showCancel: true})
 }
 
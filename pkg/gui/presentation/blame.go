@@ -0,0 +1,38 @@
+package presentation
+
+import (
+	"strconv"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation/authors"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetBlameDisplayStrings(blameLines []*models.BlameLine) [][]string {
+	lines := make([][]string, len(blameLines))
+
+	for i := range blameLines {
+		lines[i] = getBlameDisplayStrings(blameLines[i])
+	}
+
+	return lines
+}
+
+func getBlameDisplayStrings(b *models.BlameLine) []string {
+	authorStyle := authors.AuthorStyle(b.Author)
+
+	date := ""
+	if b.IsCommitted() {
+		date = utils.UnixToDate(b.UnixTimestamp)
+	}
+
+	return []string{
+		authorStyle.Sprint(b.ShortSha()),
+		authors.LongAuthor(b.Author),
+		style.FgBlue.Sprint(date),
+		style.FgCyan.Sprint(strconv.Itoa(b.LineNumber)),
+		theme.DefaultTextColor.Sprint(b.Content),
+	}
+}
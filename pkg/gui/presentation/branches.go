@@ -5,28 +5,47 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 )
 
-func GetBranchListDisplayStrings(branches []*models.Branch, fullDescription bool, diffName string) [][]string {
-	lines := make([][]string, len(branches))
+// RenderBranchTree returns the display strings of the branches panel. When
+// branches are grouped by prefix, the folders are shown with the same tree
+// structure as files
+func RenderBranchTree(branchMgr *filetree.BranchTreeViewModel, pullRequests map[string]*models.PullRequest, divergences map[string]*models.BranchDivergence, fullDescription bool, diffName string) [][]string {
+	names := renderAux(branchMgr.Tree(), branchMgr.CollapsedPaths(), "", -1, func(n filetree.INode, depth int) string {
+		castN := n.(*filetree.BranchNode)
+		if !castN.IsLeaf() {
+			return GetBranchTextStyle(castN.Path).Sprint(castN.NameAtDepth(depth))
+		}
 
-	for i := range branches {
-		diffed := branches[i].Name == diffName
-		lines[i] = getBranchDisplayStrings(branches[i], fullDescription, diffed)
+		displayName := castN.NameAtDepth(depth)
+		if castN.Branch.DisplayName != "" {
+			displayName = castN.Branch.DisplayName
+		}
+
+		return getColoredBranchName(castN.Branch, displayName, pullRequests[castN.Branch.Name], divergences[castN.Branch.Name], castN.Branch.Name == diffName)
+	})
+
+	nodes := branchMgr.GetAllItems()
+	lines := make([][]string, len(nodes))
+	for i, node := range nodes {
+		if !node.IsLeaf() {
+			lines[i] = []string{"", names[i]}
+			if fullDescription {
+				lines[i] = append(lines[i], "")
+			}
+			continue
+		}
+
+		lines[i] = getBranchDisplayStrings(node.Branch, names[i], fullDescription)
 	}
 
 	return lines
 }
 
-// getBranchDisplayStrings returns the display string of branch
-func getBranchDisplayStrings(b *models.Branch, fullDescription bool, diffed bool) []string {
-	displayName := b.Name
-	if b.DisplayName != "" {
-		displayName = b.DisplayName
-	}
-
+func getColoredBranchName(b *models.Branch, displayName string, pullRequest *models.PullRequest, divergence *models.BranchDivergence, diffed bool) string {
 	nameTextStyle := GetBranchTextStyle(b.Name)
 	if diffed {
 		nameTextStyle = theme.DiffTerminalColor
@@ -35,7 +54,18 @@ func getBranchDisplayStrings(b *models.Branch, fullDescription bool, diffed bool
 	if b.IsTrackingRemote() {
 		coloredName = fmt.Sprintf("%s %s", coloredName, ColoredBranchStatus(b))
 	}
+	if divergence != nil && (divergence.Ahead != "0" || divergence.Behind != "0") {
+		coloredName = fmt.Sprintf("%s %s", coloredName, ColoredBranchDivergence(divergence))
+	}
+	if pullRequest != nil {
+		coloredName = fmt.Sprintf("%s %s", coloredName, ColoredPullRequest(pullRequest))
+	}
+
+	return coloredName
+}
 
+// getBranchDisplayStrings returns the display string of branch
+func getBranchDisplayStrings(b *models.Branch, coloredName string, fullDescription bool) []string {
 	recencyColor := style.FgCyan
 	if b.Recency == "  *" {
 		recencyColor = style.FgGreen
@@ -84,3 +114,29 @@ func ColoredBranchStatus(branch *models.Branch) string {
 func BranchStatus(branch *models.Branch) string {
 	return fmt.Sprintf("↑%s↓%s", branch.Pushables, branch.Pullables)
 }
+
+// ColoredBranchDivergence shows how far a branch is ahead/behind its base
+// branch e.g. '↑3↓1 origin/main'
+func ColoredBranchDivergence(divergence *models.BranchDivergence) string {
+	return style.FgBlue.Sprint(fmt.Sprintf("↑%s↓%s %s", divergence.Ahead, divergence.Behind, divergence.Base))
+}
+
+// ColoredPullRequest shows the number of the pull request along with its CI
+// status e.g. '#12 ✓'
+func ColoredPullRequest(pullRequest *models.PullRequest) string {
+	str := fmt.Sprintf("#%d", pullRequest.Number)
+	if pullRequest.IsDraft() {
+		str += " draft"
+	}
+
+	switch pullRequest.CIStatus {
+	case "success":
+		return style.FgGreen.Sprint(str + " ✓")
+	case "failure":
+		return style.FgRed.Sprint(str + " ✗")
+	case "pending":
+		return style.FgYellow.Sprint(str + " ●")
+	default:
+		return style.FgMagenta.Sprint(str)
+	}
+}
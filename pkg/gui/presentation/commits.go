@@ -6,6 +6,7 @@ import (
 
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/todo"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation/authors"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation/graph"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
@@ -14,14 +15,20 @@ import (
 	"github.com/kyokomi/emoji/v2"
 )
 
-type pipeSetCacheKey struct {
-	commitSha   string
-	commitCount int
+type pipeSetCacheEntry struct {
+	pipeSets [][]*graph.Pipe
+	// the sha of the last commit we have pipe sets for
+	lastSha string
 }
 
-var pipeSetCache = make(map[pipeSetCacheKey][][]*graph.Pipe)
+// keyed by the sha of the first commit
+var pipeSetCache = make(map[string]*pipeSetCacheEntry)
 var mutex sync.Mutex
 
+// we only keep the pipe sets of this many graphs around, so that memory doesn't
+// keep growing as we switch between branches
+const MAX_CACHED_PIPE_SETS = 10
+
 type BisectProgress int
 
 const (
@@ -62,7 +69,7 @@ func GetCommitListDisplayStrings(
 
 	// function expects to be passed the index of the commit in terms of the `commits` slice
 	var getGraphLine func(int) string
-	if showGraph {
+	if showGraph && rebaseOffset < len(commits) {
 		// this is where the graph begins (may be beyond the TODO commits depending on startIdx,
 		// but we'll never include TODO commits as part of the graph because it'll be messy)
 		graphOffset := utils.Max(startIdx, rebaseOffset)
@@ -106,7 +113,8 @@ func GetCommitListDisplayStrings(
 	return lines
 }
 
-// precondition: slice is not empty
+// returns len(commits) if every commit is a TODO, in which case there's no
+// graph to render
 func indexOfFirstNonTODOCommit(commits []*models.Commit) int {
 	for i, commit := range commits {
 		if !commit.IsTODO() {
@@ -114,29 +122,42 @@ func indexOfFirstNonTODOCommit(commits []*models.Commit) int {
 		}
 	}
 
-	// shouldn't land here
-	return 0
+	return len(commits)
 }
 
 func loadPipesets(commits []*models.Commit) [][]*graph.Pipe {
-	// given that our cache key is a commit sha and a commit count, it's very important that we don't actually try to render pipes
-	// when dealing with things like filtered commits.
-	cacheKey := pipeSetCacheKey{
-		commitSha:   commits[0].Sha,
-		commitCount: len(commits),
+	// given that our cache key is the first commit's sha, it's very important that
+	// we check the last commit matches too, so that we don't render the pipes of
+	// e.g. the unfiltered commits when dealing with filtered commits.
+	headSha := commits[0].Sha
+	lastSha := commits[len(commits)-1].Sha
+
+	getStyle := func(commit *models.Commit) style.TextStyle {
+		return authors.AuthorStyle(commit.Author)
 	}
 
-	pipeSets, ok := pipeSetCache[cacheKey]
-	if !ok {
-		// pipe sets are unique to a commit head. and a commit count. Sometimes we haven't loaded everything for that.
-		// so let's just cache it based on that.
-		getStyle := func(commit *models.Commit) style.TextStyle {
-			return authors.AuthorStyle(commit.Author)
+	entry, ok := pipeSetCache[headSha]
+	if ok {
+		cachedCount := len(entry.pipeSets)
+		if cachedCount == len(commits) && entry.lastSha == lastSha {
+			return entry.pipeSets
+		}
+
+		// we've loaded more commits since, so we can carry on from where we were
+		if cachedCount < len(commits) && commits[cachedCount-1].Sha == entry.lastSha {
+			entry.pipeSets = graph.ContinuePipeSets(entry.pipeSets, commits[cachedCount:], getStyle)
+			entry.lastSha = lastSha
+			return entry.pipeSets
 		}
-		pipeSets = graph.GetPipeSets(commits, getStyle)
-		pipeSetCache[cacheKey] = pipeSets
 	}
 
+	if len(pipeSetCache) >= MAX_CACHED_PIPE_SETS {
+		pipeSetCache = make(map[string]*pipeSetCacheEntry)
+	}
+
+	pipeSets := graph.GetPipeSets(commits, getStyle)
+	pipeSetCache[headSha] = &pipeSetCacheEntry{pipeSets: pipeSets, lastSha: lastSha}
+
 	return pipeSets
 }
 
@@ -224,8 +245,8 @@ func displayCommit(
 	bisectString := getBisectStatusText(bisectStatus, bisectInfo)
 
 	actionString := ""
-	if commit.Action != "" {
-		actionString = actionColorMap(commit.Action).Sprint(commit.Action) + " "
+	if commit.Action != todo.NoCommand {
+		actionString = actionColorMap(commit.Action).Sprint(commit.Action.String()) + " "
 	}
 
 	tagString := ""
@@ -320,16 +341,18 @@ func getShaColor(
 	return shaColor
 }
 
-func actionColorMap(str string) style.TextStyle {
-	switch str {
-	case "pick":
+func actionColorMap(action todo.TodoCommand) style.TextStyle {
+	switch action {
+	case todo.Pick:
 		return style.FgCyan
-	case "drop":
+	case todo.Drop:
 		return style.FgRed
-	case "edit":
+	case todo.Edit:
 		return style.FgGreen
-	case "fixup":
+	case todo.Fixup:
 		return style.FgMagenta
+	case todo.Exec, todo.Break, todo.Label, todo.Reset, todo.Merge, todo.UpdateRef:
+		return style.FgBlue
 	default:
 		return style.FgYellow
 	}
@@ -41,6 +41,13 @@ func TestRenderFileTree(t *testing.T) {
 			},
 			expected: []string{" M test"},
 		},
+		{
+			name: "file resolved by rerere",
+			files: []*models.File{
+				{Name: "test", ShortStatus: "UU", HasUnstagedChanges: true, HasMergeConflicts: true, ResolvedByRerere: true},
+			},
+			expected: []string{"UU test (rerere)"},
+		},
 		{
 			name: "big example",
 			files: []*models.File{
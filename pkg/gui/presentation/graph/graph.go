@@ -63,15 +63,32 @@ func GetPipeSets(commits []*models.Commit, getStyle func(c *models.Commit) style
 		return nil
 	}
 
-	pipes := []*Pipe{{fromPos: 0, toPos: 0, fromSha: "START", toSha: commits[0].Sha, kind: STARTS, style: style.FgDefault}}
+	return ContinuePipeSets(nil, commits, getStyle)
+}
+
+// ContinuePipeSets appends the pipe sets of the given commits to the pipe sets
+// of the commits that came before them, so that when we load more commits we
+// don't need to work out the graph from the top again
+func ContinuePipeSets(pipeSets [][]*Pipe, commits []*models.Commit, getStyle func(c *models.Commit) style.TextStyle) [][]*Pipe {
+	if len(commits) == 0 {
+		return pipeSets
+	}
+
+	var pipes []*Pipe
+	if len(pipeSets) == 0 {
+		pipes = []*Pipe{{fromPos: 0, toPos: 0, fromSha: "START", toSha: commits[0].Sha, kind: STARTS, style: style.FgDefault}}
+	} else {
+		pipes = pipeSets[len(pipeSets)-1]
+	}
 
-	pipeSets := [][]*Pipe{}
+	result := make([][]*Pipe, len(pipeSets), len(pipeSets)+len(commits))
+	copy(result, pipeSets)
 	for _, commit := range commits {
 		pipes = getNextPipes(pipes, commit, getStyle)
-		pipeSets = append(pipeSets, pipes)
+		result = append(result, pipes)
 	}
 
-	return pipeSets
+	return result
 }
 
 func RenderAux(pipeSets [][]*Pipe, commits []*models.Commit, selectedCommitSha string) []string {
@@ -245,6 +245,25 @@ func TestRenderCommitGraph(t *testing.T) {
 	}
 }
 
+func TestContinuePipeSets(t *testing.T) {
+	commits := []*models.Commit{
+		{Sha: "1", Parents: []string{"2"}},
+		{Sha: "2", Parents: []string{"3", "4"}},
+		{Sha: "4", Parents: []string{"3", "5"}},
+		{Sha: "3", Parents: []string{"5"}},
+		{Sha: "5", Parents: []string{"6"}},
+		{Sha: "6", Parents: []string{"7"}},
+	}
+
+	getStyle := func(c *models.Commit) style.TextStyle { return style.FgDefault }
+
+	expected := GetPipeSets(commits, getStyle)
+	for split := 0; split <= len(commits); split++ {
+		pipeSets := ContinuePipeSets(GetPipeSets(commits[:split], getStyle), commits[split:], getStyle)
+		assert.EqualValues(t, expected, pipeSets, fmt.Sprintf("split at %d", split))
+	}
+}
+
 func TestRenderPipeSet(t *testing.T) {
 	cyan := style.FgCyan
 	red := style.FgRed
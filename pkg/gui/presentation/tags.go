@@ -2,25 +2,42 @@ package presentation
 
 import (
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetTagListDisplayStrings(tags []*models.Tag, diffName string) [][]string {
+func GetTagListDisplayStrings(tags []*models.Tag, fullDescription bool, diffName string) [][]string {
 	lines := make([][]string, len(tags))
 
 	for i := range tags {
 		diffed := tags[i].Name == diffName
-		lines[i] = getTagDisplayStrings(tags[i], diffed)
+		lines[i] = getTagDisplayStrings(tags[i], fullDescription, diffed)
 	}
 
 	return lines
 }
 
 // getTagDisplayStrings returns the display string of branch
-func getTagDisplayStrings(t *models.Tag, diffed bool) []string {
+func getTagDisplayStrings(t *models.Tag, fullDescription bool, diffed bool) []string {
 	textStyle := theme.DefaultTextColor
 	if diffed {
 		textStyle = theme.DiffTerminalColor
 	}
-	return []string{textStyle.Sprint(t.Name)}
+
+	age := ""
+	if t.Date != 0 {
+		age = utils.UnixToTimeAgo(t.Date)
+	}
+
+	res := []string{style.FgCyan.Sprint(age), textStyle.Sprint(t.Name)}
+	if fullDescription {
+		res = append(
+			res,
+			style.FgYellow.Sprint(utils.ShortSha(t.TargetSha)),
+			style.FgMagenta.Sprint(t.Tagger),
+		)
+	}
+
+	return append(res, theme.DefaultTextColor.Sprint(t.Message))
 }
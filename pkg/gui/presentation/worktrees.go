@@ -0,0 +1,49 @@
+package presentation
+
+import (
+	"path/filepath"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+)
+
+func GetWorktreeListDisplayStrings(worktrees []*models.Worktree) [][]string {
+	lines := make([][]string, len(worktrees))
+
+	for i := range worktrees {
+		lines[i] = getWorktreeDisplayStrings(worktrees[i])
+	}
+
+	return lines
+}
+
+// getWorktreeDisplayStrings returns the display string of a worktree
+func getWorktreeDisplayStrings(w *models.Worktree) []string {
+	current := ""
+	if w.Current {
+		current = style.FgGreen.Sprint("*")
+	}
+
+	headStyle := GetBranchTextStyle(w.Branch)
+	if w.Branch == "" {
+		headStyle = style.FgYellow
+	}
+
+	status := ""
+	switch {
+	case w.Prunable:
+		status = style.FgRed.Sprint("(prunable)")
+	case w.Locked:
+		status = style.FgMagenta.Sprint("(locked)")
+	case w.Main:
+		status = style.FgCyan.Sprint("(main)")
+	}
+
+	return []string{
+		current,
+		theme.DefaultTextColor.Sprint(filepath.Base(w.Path)),
+		headStyle.Sprint(w.HeadDescription()),
+		status,
+	}
+}
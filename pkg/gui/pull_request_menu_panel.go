@@ -2,6 +2,9 @@ package gui
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
@@ -70,8 +73,103 @@ func (gui *Gui) createPullRequest(from string, to string) error {
 	return nil
 }
 
+func (gui *Gui) handleCheckoutPullRequest() error {
+	return gui.prompt(promptOpts{
+		title: gui.Tr.PullRequestNumber,
+		handleConfirm: func(response string) error {
+			number, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(response), "#"))
+			if err != nil || number <= 0 {
+				return gui.createErrorPanel(gui.Tr.InvalidPullRequestNumber)
+			}
+
+			remoteName, remoteURL := gui.getPullRequestRemote()
+			ref, err := gui.getHostingServiceMgrForURL(remoteURL).GetPullRequestRef(number)
+			if err != nil {
+				return gui.surfaceError(err)
+			}
+
+			branchName := fmt.Sprintf("pr/%d", number)
+
+			return gui.WithWaitingStatus(gui.Tr.FetchingPullRequestStatus, func() error {
+				gui.logAction(gui.Tr.Actions.CheckoutPullRequest)
+
+				// git won't fetch into the checked out branch, so we fetch the pull
+				// request on its own and fast-forward to it instead
+				if checkedOutBranch := gui.getCheckedOutBranch(); checkedOutBranch != nil && checkedOutBranch.Name == branchName {
+					err := gui.Git.Sync.FetchPullRequestHead(remoteName, ref)
+					gui.handleCredentialsPopup(err)
+					if err != nil {
+						return nil
+					}
+
+					if err := gui.Git.Sync.FastForwardToFetchHead(); err != nil {
+						return err
+					}
+
+					return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+				}
+
+				err := gui.Git.Sync.FetchPullRequest(remoteName, ref, branchName)
+				gui.handleCredentialsPopup(err)
+				if err != nil {
+					return nil
+				}
+
+				return gui.handleCheckoutRef(branchName, handleCheckoutRefOptions{})
+			})
+		},
+	})
+}
+
+// getPullRequestRemote returns the name and URL of the remote that pull requests
+// are opened against. In a fork workflow that's 'upstream', otherwise 'origin'
+func (gui *Gui) getPullRequestRemote() (string, string) {
+	if url := gui.Git.Config.GetRemoteURLFor("upstream"); url != "" {
+		return "upstream", url
+	}
+
+	return "origin", gui.Git.Config.GetRemoteURL()
+}
+
 func (gui *Gui) getHostingServiceMgr() *hosting_service.HostingServiceMgr {
-	remoteUrl := gui.Git.Config.GetRemoteURL()
+	return gui.getHostingServiceMgrForURL(gui.Git.Config.GetRemoteURL())
+}
+
+func (gui *Gui) getHostingServiceMgrForURL(remoteUrl string) *hosting_service.HostingServiceMgr {
 	configServices := gui.UserConfig.Services
 	return hosting_service.NewHostingServiceMgr(gui.Log, gui.Tr, remoteUrl, configServices)
 }
+
+// getForgeClient returns a client for the API of the repo's hosting service, or
+// nil if we can't talk to it (e.g. because we have no token). We're called from
+// the background refresh, hence the lock
+func (gui *Gui) getForgeClient() hosting_service.ForgeClient {
+	gui.Mutexes.ForgeClientMutex.Lock()
+	defer gui.Mutexes.ForgeClientMutex.Unlock()
+
+	if gui.State.ForgeClient != nil {
+		return gui.State.ForgeClient
+	}
+
+	forgeConfig := gui.UserConfig.Forge
+	config := hosting_service.ForgeClientConfig{
+		GithubToken: forgeConfig.GithubToken,
+		GitlabToken: forgeConfig.GitlabToken,
+		ApiBaseURL:  forgeConfig.ApiBaseURL,
+	}
+
+	hostingServiceMgr := gui.getHostingServiceMgr()
+	if !hostingServiceMgr.HasForgeToken(config) {
+		return nil
+	}
+
+	client, err := hostingServiceMgr.GetForgeClient(config)
+	if err != nil {
+		gui.Log.Error(err)
+		return nil
+	}
+
+	gui.State.ForgeClient = hosting_service.NewCachedForgeClient(client, time.Duration(forgeConfig.CacheDuration)*time.Second)
+
+	return gui.State.ForgeClient
+}
@@ -86,6 +86,8 @@ func (gui *Gui) dispatchSwitchToRepo(path string, reuse bool) error {
 
 	gui.resetState("", reuse)
 
+	gui.watchRepoForChanges()
+
 	return nil
 }
 
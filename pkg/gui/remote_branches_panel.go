@@ -11,11 +11,26 @@ import (
 
 func (gui *Gui) getSelectedRemoteBranch() *models.RemoteBranch {
 	selectedLine := gui.State.Panels.RemoteBranches.SelectedLineIdx
-	if selectedLine == -1 || len(gui.State.RemoteBranches) == 0 {
+	if selectedLine == -1 || len(gui.State.FilteredRemoteBranches) == 0 {
 		return nil
 	}
 
-	return gui.State.RemoteBranches[selectedLine]
+	return gui.State.FilteredRemoteBranches[selectedLine]
+}
+
+// filterRemoteBranches narrows the branches shown in the remote branches panel
+// down to the ones matching the panel's filter
+func (gui *Gui) filterRemoteBranches() {
+	names := make([]string, len(gui.State.RemoteBranches))
+	for i, branch := range gui.State.RemoteBranches {
+		names[i] = branch.Name
+	}
+
+	indices := utils.FuzzyFilter(gui.State.Panels.RemoteBranches.filter, names)
+	gui.State.FilteredRemoteBranches = make([]*models.RemoteBranch, len(indices))
+	for i, index := range indices {
+		gui.State.FilteredRemoteBranches[i] = gui.State.RemoteBranches[index]
+	}
 }
 
 func (gui *Gui) remoteBranchesRenderToMain() error {
@@ -55,6 +55,7 @@ func (gui *Gui) refreshRemotes() error {
 				gui.State.RemoteBranches = remote.Branches
 			}
 		}
+		gui.filterRemoteBranches()
 	}
 
 	return gui.postRefreshUpdate(gui.mustContextForContextKey(ContextKey(gui.Views.Branches.Context)))
@@ -68,6 +69,8 @@ func (gui *Gui) handleRemoteEnter() error {
 	}
 
 	gui.State.RemoteBranches = remote.Branches
+	gui.State.Panels.RemoteBranches.filter = ""
+	gui.filterRemoteBranches()
 
 	newSelectedLine := 0
 	if len(remote.Branches) == 0 {
@@ -0,0 +1,140 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) handleCreateRerereOptionsMenu() error {
+	menuItems := []*menuItem{}
+
+	file := gui.getSelectedFile()
+	if file != nil && file.HasMergeConflicts {
+		menuItems = append(menuItems, &menuItem{
+			displayString: utils.ResolvePlaceholderString(gui.Tr.LcForgetRerereResolution, map[string]string{"file": file.Name}),
+			onPress: func() error {
+				return gui.handleForgetRerereResolution(file)
+			},
+		})
+	}
+
+	menuItems = append(menuItems,
+		&menuItem{
+			displayString: gui.Tr.LcShowRerereDiff,
+			onPress:       gui.handleShowRerereDiff,
+		},
+		&menuItem{
+			displayString: gui.Tr.LcBrowseRerereCache,
+			onPress:       gui.handleBrowseRerereCache,
+			opensMenu:     true,
+		},
+	)
+
+	return gui.createMenu(gui.Tr.RerereOptionsTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleForgetRerereResolution(file *models.File) error {
+	return gui.ask(askOpts{
+		title:  gui.Tr.ForgetRerereResolutionTitle,
+		prompt: utils.ResolvePlaceholderString(gui.Tr.ForgetRerereResolutionPrompt, map[string]string{"file": file.Name}),
+		handleConfirm: func() error {
+			gui.logAction(gui.Tr.Actions.ForgetRerereResolution)
+			if err := gui.Git.Rerere.Forget(file.Name); err != nil {
+				return gui.surfaceError(err)
+			}
+
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{FILES}})
+		},
+	})
+}
+
+func (gui *Gui) handleShowRerereDiff() error {
+	cmdObj := gui.Git.Rerere.DiffCmdObj()
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: gui.Tr.RerereDiffTitle,
+			task:  NewRunPtyTask(cmdObj.GetCmd()),
+		},
+	})
+}
+
+func (gui *Gui) handleBrowseRerereCache() error {
+	entries, err := gui.Git.Rerere.GetEntries()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if len(entries) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoRerereEntries)
+	}
+
+	menuItems := make([]*menuItem, len(entries))
+	for i, entry := range entries {
+		entry := entry
+
+		status := style.FgYellow.Sprint(gui.Tr.RerereUnresolved)
+		if entry.Resolved {
+			status = style.FgGreen.Sprint(gui.Tr.RerereResolved)
+		}
+
+		menuItems[i] = &menuItem{
+			displayStrings: []string{
+				utils.ShortSha(entry.Hash),
+				style.FgBlue.Sprint(utils.UnixToTimeAgo(entry.UnixTimestamp)),
+				status,
+				entry.Preview,
+			},
+			onPress: func() error {
+				return gui.createRerereEntryMenu(entry)
+			},
+			opensMenu: true,
+		}
+	}
+
+	return gui.createMenu(gui.Tr.RerereCacheTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) createRerereEntryMenu(entry *models.RerereEntry) error {
+	menuItems := []*menuItem{}
+
+	if entry.Resolved {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.LcViewRerereResolution,
+			onPress: func() error {
+				cmdObj, err := gui.Git.Rerere.ShowEntryCmdObj(entry.Hash)
+				if err != nil {
+					return gui.surfaceError(err)
+				}
+
+				return gui.refreshMainViews(refreshMainOpts{
+					main: &viewUpdateOpts{
+						title: gui.Tr.RerereCacheTitle,
+						task:  NewRunPtyTask(cmdObj.GetCmd()),
+					},
+				})
+			},
+		})
+	}
+
+	menuItems = append(menuItems, &menuItem{
+		displayString: gui.Tr.LcDeleteRerereEntry,
+		onPress: func() error {
+			return gui.ask(askOpts{
+				title:  gui.Tr.DeleteRerereEntryTitle,
+				prompt: utils.ResolvePlaceholderString(gui.Tr.DeleteRerereEntryPrompt, map[string]string{"hash": utils.ShortSha(entry.Hash)}),
+				handleConfirm: func() error {
+					gui.logAction(gui.Tr.Actions.DeleteRerereEntry)
+					if err := gui.Git.Rerere.DeleteEntry(entry.Hash); err != nil {
+						return gui.surfaceError(err)
+					}
+
+					return nil
+				},
+			})
+		},
+	})
+
+	return gui.createMenu(utils.ShortSha(entry.Hash), menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,242 @@
+package gui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// these menu items don't need a patch to be in progress, so we show them in the
+// patch options menu either way
+func (gui *Gui) patchFileMenuItems() []*menuItem {
+	return []*menuItem{
+		{
+			displayString: gui.Tr.LcListSavedPatches,
+			onPress:       gui.handleListSavedPatches,
+			opensMenu:     true,
+		},
+		{
+			displayString: gui.Tr.LcApplyPatchFile,
+			onPress:       gui.handleApplyPatchFile,
+		},
+	}
+}
+
+func (gui *Gui) handleExportPatch() error {
+	if gui.Git.Patch.PatchManager.IsEmpty() {
+		return gui.createErrorPanel(gui.Tr.EmptyPatchError)
+	}
+
+	return gui.prompt(promptOpts{
+		title:               gui.Tr.ExportPatchPrompt,
+		findSuggestionsFunc: gui.getFilePathSuggestionsFunc(),
+		handleConfirm: func(path string) error {
+			export := func() error {
+				gui.logAction(gui.Tr.Actions.ExportPatch)
+				return gui.Git.Patch.ExportPatch(path)
+			}
+
+			exists, err := gui.OSCommand.FileExists(path)
+			if err != nil {
+				return gui.surfaceError(err)
+			}
+
+			if exists {
+				return gui.ask(askOpts{
+					title:         gui.Tr.ExportPatchOverwriteTitle,
+					prompt:        utils.ResolvePlaceholderString(gui.Tr.ExportPatchOverwritePrompt, map[string]string{"path": path}),
+					handleConfirm: export,
+				})
+			}
+
+			return export()
+		},
+	})
+}
+
+func (gui *Gui) handleSavePatch() error {
+	if gui.Git.Patch.PatchManager.IsEmpty() {
+		return gui.createErrorPanel(gui.Tr.EmptyPatchError)
+	}
+
+	return gui.prompt(promptOpts{
+		title: gui.Tr.SavePatchPrompt,
+		handleConfirm: func(name string) error {
+			gui.logAction(gui.Tr.Actions.SavePatch)
+			return gui.Git.Patch.SavePatch(strings.TrimSpace(name))
+		},
+	})
+}
+
+func (gui *Gui) handleListSavedPatches() error {
+	savedPatches, err := gui.Git.Patch.GetSavedPatches()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if len(savedPatches) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoSavedPatches)
+	}
+
+	menuItems := make([]*menuItem, len(savedPatches))
+	for i, savedPatch := range savedPatches {
+		savedPatch := savedPatch
+
+		menuItems[i] = &menuItem{
+			displayStrings: []string{
+				savedPatch.Name,
+				style.FgBlue.Sprint(utils.UnixToTimeAgo(savedPatch.UnixTimestamp)),
+				style.FgYellow.Sprint(savedPatch.State.To),
+				utils.ResolvePlaceholderString(gui.Tr.SavedPatchFileCount, map[string]string{"count": fmt.Sprintf("%d", len(savedPatch.State.Files))}),
+			},
+			onPress: func() error {
+				return gui.createSavedPatchMenu(savedPatch)
+			},
+			opensMenu: true,
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SavedPatchesTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) createSavedPatchMenu(savedPatch *patch.SavedPatch) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.LcOpenSavedPatch,
+			onPress: func() error {
+				return gui.handleOpenSavedPatch(savedPatch)
+			},
+		},
+		{
+			displayString: gui.Tr.LcApplySavedPatch,
+			onPress: func() error {
+				return gui.createApplyPatchMenu(savedPatch.Name, savedPatch.Patch)
+			},
+			opensMenu: true,
+		},
+		{
+			displayString: gui.Tr.LcDeleteSavedPatch,
+			onPress: func() error {
+				return gui.ask(askOpts{
+					title:  gui.Tr.DeleteSavedPatchTitle,
+					prompt: utils.ResolvePlaceholderString(gui.Tr.DeleteSavedPatchPrompt, map[string]string{"name": savedPatch.Name}),
+					handleConfirm: func() error {
+						gui.logAction(gui.Tr.Actions.DeleteSavedPatch)
+						return gui.Git.Patch.DeleteSavedPatch(savedPatch.Name)
+					},
+				})
+			},
+		},
+	}
+
+	return gui.createMenu(savedPatch.Name, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleOpenSavedPatch(savedPatch *patch.SavedPatch) error {
+	open := func() error {
+		state := *savedPatch.State
+		// the patch may have been saved from a commit which has since been
+		// rebased away, in which case we mustn't try to rebase it
+		state.CanRebase = state.CanRebase && gui.isCommitOnCurrentBranch(state.To)
+
+		if err := gui.Git.Patch.PatchManager.Restore(&state); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		if savedPatch.Stash {
+			return gui.switchToCommitFilesContext(state.To, false, gui.State.Contexts.Stash, "stash")
+		}
+
+		return gui.switchToCommitFilesContext(state.To, state.CanRebase, gui.State.Contexts.BranchCommits, "commits")
+	}
+
+	if gui.Git.Patch.PatchManager.Active() && !gui.Git.Patch.PatchManager.IsEmpty() {
+		return gui.ask(askOpts{
+			title:         gui.Tr.DiscardPatch,
+			prompt:        gui.Tr.ReplacePatchConfirm,
+			handleConfirm: open,
+		})
+	}
+
+	return open()
+}
+
+func (gui *Gui) isCommitOnCurrentBranch(sha string) bool {
+	for _, commit := range gui.State.Commits {
+		if commit.Sha == sha {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (gui *Gui) handleApplyPatchFile() error {
+	return gui.prompt(promptOpts{
+		title:               gui.Tr.ApplyPatchFilePrompt,
+		findSuggestionsFunc: gui.getFilePathSuggestionsFunc(),
+		handleConfirm: func(path string) error {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			return gui.createApplyPatchMenu(filepath.Base(path), string(content))
+		},
+	})
+}
+
+// createApplyPatchMenu lets the user apply the patch to either the working tree
+// or the index, showing in the main view whether it applies cleanly to each
+func (gui *Gui) createApplyPatchMenu(name string, patch string) error {
+	targets := []struct {
+		description string
+		flags       []string
+	}{
+		{description: gui.Tr.LcApplyPatchToWorkingTree},
+		{description: gui.Tr.LcApplyPatchToIndex, flags: []string{"cached"}},
+	}
+
+	menuItems := make([]*menuItem, len(targets))
+	previews := make([]string, len(targets))
+	for i, target := range targets {
+		target := target
+
+		status := style.FgGreen.Sprint(gui.Tr.PatchAppliesCleanly)
+		output, err := gui.Git.WorkingTree.CheckPatch(patch, target.flags...)
+		if err != nil {
+			status = style.FgRed.Sprint(gui.Tr.PatchDoesNotApplyCleanly)
+			output = err.Error()
+		}
+
+		previews[i] = fmt.Sprintf("%s: %s\n\n%s", target.description, status, strings.TrimSpace(output))
+		menuItems[i] = &menuItem{
+			displayStrings: []string{target.description, status},
+			onPress: func() error {
+				gui.logAction(gui.Tr.Actions.ApplyPatchFile)
+				if err := gui.Git.WorkingTree.ApplyPatch(patch, target.flags...); err != nil {
+					return gui.surfaceError(err)
+				}
+
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{FILES}})
+			},
+		}
+	}
+
+	err := gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: gui.Tr.ApplyPatchPreviewTitle,
+			task:  NewRenderStringTask(strings.Join(previews, "\n\n")),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return gui.createMenu(utils.ResolvePlaceholderString(gui.Tr.ApplyPatchFileTitle, map[string]string{"name": name}), menuItems, createMenuOptions{showCancel: true})
+}
@@ -3,6 +3,7 @@ package gui
 import (
 	"fmt"
 
+	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 )
 
@@ -24,7 +25,65 @@ func (gui *Gui) handleOpenSearch(viewName string) error {
 	return nil
 }
 
+// handleOpenFilter reuses the search bar to narrow the given list down to the
+// items matching what the user types
+func (gui *Gui) handleOpenFilter(listContext IListContext) error {
+	gui.State.Searching.isSearching = true
+	gui.State.Searching.filterContext = listContext
+
+	gui.Views.Search.ClearTextArea()
+	gui.Views.Search.TextArea.TypeString(listContext.GetFilter())
+	gui.Views.Search.RenderTextArea()
+
+	return gui.pushContext(gui.State.Contexts.Search)
+}
+
+// searchEditor re-applies the filter on each keypress when we're filtering a list
+func (gui *Gui) searchEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) bool {
+	matched := gui.handleEditorKeypress(v.TextArea, key, ch, mod, false)
+
+	v.RenderTextArea()
+
+	if matched && gui.State.Searching.filterContext != nil {
+		if err := gui.State.Searching.filterContext.SetFilter(v.TextArea.GetContent()); err != nil {
+			gui.Log.Error(err)
+		}
+	}
+
+	return matched
+}
+
+// currentFilteredListContext returns the focused list context if it has a filter
+// applied
+func (gui *Gui) currentFilteredListContext() (IListContext, bool) {
+	listContext, ok := gui.currentContext().(IListContext)
+	if !ok || listContext.GetFilter() == "" {
+		return nil, false
+	}
+
+	return listContext, true
+}
+
+func (gui *Gui) clearCurrentListFilter() error {
+	listContext, ok := gui.currentFilteredListContext()
+	if !ok {
+		return nil
+	}
+
+	return listContext.SetFilter("")
+}
+
 func (gui *Gui) handleSearch() error {
+	if filterContext := gui.State.Searching.filterContext; filterContext != nil {
+		gui.State.Searching.isSearching = false
+		gui.State.Searching.filterContext = nil
+		if err := filterContext.SetFilter(gui.Views.Search.TextArea.GetContent()); err != nil {
+			return err
+		}
+
+		return gui.returnFromContext()
+	}
+
 	gui.State.Searching.searchString = gui.Views.Search.TextArea.GetContent()
 	if err := gui.returnFromContext(); err != nil {
 		return err
@@ -80,6 +139,12 @@ func (gui *Gui) onSelectItemWrapper(innerFunc func(int) error) func(int, int, in
 
 func (gui *Gui) onSearchEscape() error {
 	gui.State.Searching.isSearching = false
+	if filterContext := gui.State.Searching.filterContext; filterContext != nil {
+		gui.State.Searching.filterContext = nil
+		if err := filterContext.SetFilter(""); err != nil {
+			return err
+		}
+	}
 	if gui.State.Searching.view != nil {
 		gui.State.Searching.view.ClearSearch()
 		gui.State.Searching.view = nil
@@ -164,3 +164,46 @@ func (gui *Gui) applySelection(reverse bool, state *LblPanelState) error {
 	}
 	return nil
 }
+
+// handleStashSelection stashes the selected lines of the unstaged changes,
+// leaving everything else in the working tree
+func (gui *Gui) handleStashSelection() error {
+	return gui.withLBLActiveCheck(func(state *LblPanelState) error {
+		if state.SecondaryFocused {
+			return gui.createErrorPanel(gui.Tr.CantStashStagedLines)
+		}
+
+		file := gui.getSelectedFile()
+		if file == nil {
+			return nil
+		}
+
+		if !file.Tracked {
+			return gui.createErrorPanel(gui.Tr.CantStashUntrackedFileLines)
+		}
+
+		firstLineIdx, lastLineIdx := state.SelectedRange()
+		patch := patch.ModifiedPatchForRange(gui.Log, file.Name, state.GetDiff(), firstLineIdx, lastLineIdx, false, false)
+		if patch == "" {
+			return nil
+		}
+
+		if state.SelectingRange() {
+			state.SetLineSelectMode()
+		}
+
+		return gui.prompt(promptOpts{
+			title: gui.Tr.StashChanges,
+			handleConfirm: func(message string) error {
+				gui.logAction(gui.Tr.Actions.StashSelectedLines)
+				if err := gui.Git.Stash.SavePatch(message, patch); err != nil {
+					return gui.surfaceError(err)
+				}
+				if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH, FILES}}); err != nil {
+					return err
+				}
+				return gui.refreshStagingPanel(false, -1)
+			},
+		})
+	})
+}
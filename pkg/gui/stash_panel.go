@@ -2,6 +2,7 @@ package gui
 
 import (
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // list panel functions
@@ -100,24 +101,87 @@ func (gui *Gui) handleStashPop() error {
 }
 
 func (gui *Gui) handleStashDrop() error {
-	stashEntry := gui.getSelectedStashEntry()
-	if stashEntry == nil {
+	if gui.getSelectedStashEntry() == nil {
 		return nil
 	}
 
+	prompt := gui.Tr.SureDropStashEntry
+	if gui.State.Panels.Stash.IsSelectingRange() {
+		prompt = gui.Tr.SureDropSelectedStashEntries
+	}
+
+	startIdx, endIdx := gui.State.Panels.Stash.GetSelectedRange()
+	stashEntries := gui.State.StashEntries[startIdx : endIdx+1]
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.StashDrop,
-		prompt: gui.Tr.SureDropStashEntry,
+		prompt: prompt,
 		handleConfirm: func() error {
 			gui.logAction(gui.Tr.Actions.Stash)
-			if err := gui.Git.Stash.Drop(stashEntry.Index); err != nil {
-				return gui.surfaceError(err)
+			// dropping an entry shifts the indices of the entries after it, so we
+			// drop from the oldest entry to the newest
+			for i := len(stashEntries) - 1; i >= 0; i-- {
+				if err := gui.Git.Stash.Drop(stashEntries[i].Index); err != nil {
+					_ = gui.postStashRefresh()
+					return gui.surfaceError(err)
+				}
 			}
+			gui.State.Panels.Stash.CancelRangeSelect()
 			return gui.postStashRefresh()
 		},
 	})
 }
 
+func (gui *Gui) handleStashRename() error {
+	stashEntry := gui.getSelectedStashEntry()
+	if stashEntry == nil {
+		return nil
+	}
+
+	title := utils.ResolvePlaceholderString(
+		gui.Tr.RenameStash,
+		map[string]string{
+			"stashName": stashEntry.RefName(),
+		},
+	)
+
+	return gui.prompt(promptOpts{
+		title:          title,
+		initialContent: stashEntry.Name,
+		handleConfirm: func(message string) error {
+			gui.logAction(gui.Tr.Actions.RenameStash)
+			err := gui.Git.Stash.Rename(stashEntry.Index, message)
+			_ = gui.postStashRefresh()
+			if err != nil {
+				return gui.surfaceError(err)
+			}
+			// the renamed entry is now at the top of the stash list
+			gui.State.Panels.Stash.SelectedLineIdx = 0
+			return gui.State.Contexts.Stash.HandleRender()
+		},
+	})
+}
+
+func (gui *Gui) handleStashCreateBranch() error {
+	stashEntry := gui.getSelectedStashEntry()
+	if stashEntry == nil {
+		return nil
+	}
+
+	return gui.prompt(promptOpts{
+		title: gui.Tr.NewBranchFromStash,
+		handleConfirm: func(branchName string) error {
+			gui.logAction(gui.Tr.Actions.CreateBranchFromStash)
+			err := gui.Git.Stash.CreateBranch(stashEntry.Index, sanitizedBranchName(branchName))
+			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+			if err != nil {
+				return gui.surfaceError(err)
+			}
+			return nil
+		},
+	})
+}
+
 func (gui *Gui) postStashRefresh() error {
 	return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH, FILES}})
 }
@@ -127,6 +191,10 @@ func (gui *Gui) handleStashSave(stashFunc func(message string) error) error {
 		return gui.createErrorPanel(gui.Tr.NoTrackedStagedFilesStash)
 	}
 
+	return gui.promptForStashMessage(stashFunc)
+}
+
+func (gui *Gui) promptForStashMessage(stashFunc func(message string) error) error {
 	return gui.prompt(promptOpts{
 		title: gui.Tr.StashChanges,
 		handleConfirm: func(stashComment string) error {
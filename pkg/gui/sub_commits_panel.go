@@ -3,6 +3,7 @@ package gui
 import (
 	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 )
 
 // list panel functions
@@ -23,7 +24,18 @@ func (gui *Gui) subCommitsRenderToMain() error {
 	if commit == nil {
 		task = NewRenderStringTask("No commits")
 	} else {
-		cmdObj := gui.Git.Commit.ShowCmdObj(commit.Sha, gui.State.Modes.Filtering.GetPath())
+		var cmdObj oscommands.ICmdObj
+		// search results aren't filtered by line range so we can't rely on
+		// their position in the log
+		if gui.State.Modes.Filtering.HasLineRange() && gui.State.Panels.SubCommits.search == nil {
+			cmdObj = gui.Git.Commit.ShowLineRangeCmdObj(
+				gui.State.Panels.SubCommits.refName,
+				gui.State.Modes.Filtering.GetLineRange(),
+				gui.State.Panels.SubCommits.SelectedLineIdx,
+			)
+		} else {
+			cmdObj = gui.Git.Commit.ShowCmdObj(commit.Sha, gui.State.Modes.Filtering.GetPath())
+		}
 
 		task = NewRunPtyTask(cmdObj.GetCmd())
 	}
@@ -75,11 +87,17 @@ func (gui *Gui) handleViewSubCommitFiles() error {
 }
 
 func (gui *Gui) switchToSubCommitsContext(refName string) error {
+	limit := 0
+	if gui.State.Panels.Commits.LimitCommits {
+		limit = COMMIT_PAGE_SIZE
+	}
+
 	// need to populate my sub commits
 	commits, err := gui.Git.Loaders.Commits.GetCommits(
 		loaders.GetCommitsOptions{
-			Limit:                gui.State.Panels.Commits.LimitCommits,
+			Limit:                limit,
 			FilterPath:           gui.State.Modes.Filtering.GetPath(),
+			FilterLineRange:      gui.State.Modes.Filtering.GetLineRange(),
 			IncludeRebaseCommits: false,
 			RefName:              refName,
 		},
@@ -90,6 +108,7 @@ func (gui *Gui) switchToSubCommitsContext(refName string) error {
 
 	gui.State.SubCommits = commits
 	gui.State.Panels.SubCommits.refName = refName
+	gui.State.Panels.SubCommits.search = nil
 	gui.State.Panels.SubCommits.SelectedLineIdx = 0
 	gui.State.Contexts.SubCommits.SetParentContext(gui.currentSideListContext())
 
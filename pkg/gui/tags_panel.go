@@ -1,17 +1,33 @@
 package gui
 
 import (
+	"github.com/jesseduffield/lazygit/pkg/commands/loaders"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 func (gui *Gui) getSelectedTag() *models.Tag {
 	selectedLine := gui.State.Panels.Tags.SelectedLineIdx
-	if selectedLine == -1 || len(gui.State.Tags) == 0 {
+	if selectedLine == -1 || len(gui.State.FilteredTags) == 0 {
 		return nil
 	}
 
-	return gui.State.Tags[selectedLine]
+	return gui.State.FilteredTags[selectedLine]
+}
+
+// filterTags narrows the tags shown in the tags panel down to the ones matching
+// the panel's filter
+func (gui *Gui) filterTags() {
+	names := make([]string, len(gui.State.Tags))
+	for i, tag := range gui.State.Tags {
+		names[i] = tag.Name
+	}
+
+	indices := utils.FuzzyFilter(gui.State.Panels.Tags.filter, names)
+	gui.State.FilteredTags = make([]*models.Tag, len(indices))
+	for i, index := range indices {
+		gui.State.FilteredTags[i] = gui.State.Tags[index]
+	}
 }
 
 func (gui *Gui) handleCreateTag() error {
@@ -39,12 +55,13 @@ func (gui *Gui) tagsRenderToMain() error {
 
 // this is a controller: it can't access tags directly. Or can it? It should be able to get but not set. But that's exactly what I'm doing here, setting it. but through a mutator which encapsulates the event.
 func (gui *Gui) refreshTags() error {
-	tags, err := gui.Git.Loaders.Tags.GetTags()
+	tags, err := gui.Git.Loaders.Tags.GetTags(gui.Config.GetAppState().TagSortOrder)
 	if err != nil {
 		return gui.surfaceError(err)
 	}
 
 	gui.State.Tags = tags
+	gui.filterTags()
 
 	return gui.postRefreshUpdate(gui.State.Contexts.Tags)
 }
@@ -71,6 +88,10 @@ func (gui *Gui) handleCheckoutTag(tag *models.Tag) error {
 }
 
 func (gui *Gui) handleDeleteTag(tag *models.Tag) error {
+	if gui.State.Panels.Tags.IsSelectingRange() {
+		return gui.deleteSelectedTags()
+	}
+
 	prompt := utils.ResolvePlaceholderString(
 		gui.Tr.DeleteTagPrompt,
 		map[string]string{
@@ -91,6 +112,27 @@ func (gui *Gui) handleDeleteTag(tag *models.Tag) error {
 	})
 }
 
+func (gui *Gui) deleteSelectedTags() error {
+	startIdx, endIdx := gui.State.Panels.Tags.GetSelectedRange()
+	tags := gui.State.FilteredTags[startIdx : endIdx+1]
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.DeleteTagTitle,
+		prompt: gui.Tr.DeleteSelectedTagsPrompt,
+		handleConfirm: func() error {
+			gui.logAction(gui.Tr.Actions.DeleteTag)
+			for _, tag := range tags {
+				if err := gui.Git.Tag.Delete(tag.Name); err != nil {
+					_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{COMMITS, TAGS}})
+					return gui.surfaceError(err)
+				}
+			}
+			gui.State.Panels.Tags.CancelRangeSelect()
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{COMMITS, TAGS}})
+		},
+	})
+}
+
 func (gui *Gui) handlePushTag(tag *models.Tag) error {
 	title := utils.ResolvePlaceholderString(
 		gui.Tr.PushTagTitle,
@@ -115,6 +157,110 @@ func (gui *Gui) handlePushTag(tag *models.Tag) error {
 	})
 }
 
+func (gui *Gui) handleDeleteRemoteTag(tag *models.Tag) error {
+	title := utils.ResolvePlaceholderString(
+		gui.Tr.DeleteRemoteTagTitle,
+		map[string]string{
+			"tagName": tag.Name,
+		},
+	)
+
+	return gui.prompt(promptOpts{
+		title:               title,
+		initialContent:      "origin",
+		findSuggestionsFunc: gui.getRemoteSuggestionsFunc(),
+		handleConfirm: func(remoteName string) error {
+			prompt := utils.ResolvePlaceholderString(
+				gui.Tr.DeleteRemoteTagPrompt,
+				map[string]string{
+					"tagName":    tag.Name,
+					"remoteName": remoteName,
+				},
+			)
+
+			return gui.ask(askOpts{
+				title:  gui.Tr.DeleteTagTitle,
+				prompt: prompt,
+				handleConfirm: func() error {
+					return gui.WithWaitingStatus(gui.Tr.DeletingRemoteTagStatus, func() error {
+						gui.logAction(gui.Tr.Actions.DeleteRemoteTag)
+						err := gui.Git.Tag.DeleteRemote(remoteName, tag.Name)
+						gui.handleCredentialsPopup(err)
+
+						return nil
+					})
+				},
+			})
+		},
+	})
+}
+
+func (gui *Gui) handleRemoteTagsMenu() error {
+	fetchTags := func(prune bool) error {
+		return gui.prompt(promptOpts{
+			title:               gui.Tr.FetchTagsTitle,
+			initialContent:      "origin",
+			findSuggestionsFunc: gui.getRemoteSuggestionsFunc(),
+			handleConfirm: func(remoteName string) error {
+				return gui.WithWaitingStatus(gui.Tr.FetchingTagsStatus, func() error {
+					if prune {
+						gui.logAction(gui.Tr.Actions.PruneTags)
+					} else {
+						gui.logAction(gui.Tr.Actions.FetchTags)
+					}
+					err := gui.Git.Tag.FetchTags(remoteName, prune)
+					gui.handleCredentialsPopup(err)
+
+					return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{COMMITS, TAGS}})
+				})
+			},
+		})
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.LcFetchTagsFromRemote,
+			onPress:       func() error { return fetchTags(false) },
+		},
+		{
+			displayString: gui.Tr.LcPruneTagsFromRemote,
+			onPress:       func() error { return fetchTags(true) },
+		},
+	}
+
+	return gui.createMenu(gui.Tr.RemoteTagsMenuTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleTagSortOrderMenu() error {
+	setSortOrder := func(sortOrder string) func() error {
+		return func() error {
+			gui.Config.GetAppState().TagSortOrder = sortOrder
+			if err := gui.Config.SaveAppState(); err != nil {
+				gui.Log.Error(err)
+			}
+			gui.State.Panels.Tags.SetSelectedLineIdx(0)
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{TAGS}})
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.LcSortByDate,
+			onPress:       setSortOrder(loaders.TAG_SORT_ORDER_DATE),
+		},
+		{
+			displayString: gui.Tr.LcSortByVersion,
+			onPress:       setSortOrder(loaders.TAG_SORT_ORDER_VERSION),
+		},
+		{
+			displayString: gui.Tr.LcSortByName,
+			onPress:       setSortOrder(loaders.TAG_SORT_ORDER_NAME),
+		},
+	}
+
+	return gui.createMenu(gui.Tr.TagSortOrderMenuTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
 func (gui *Gui) handleCreateResetToTagMenu(tag *models.Tag) error {
 	return gui.createResetMenu(tag.Name)
 }
@@ -28,6 +28,7 @@ const (
 	REMOTES
 	STATUS
 	SUBMODULES
+	WORKTREES
 	// not actually a view. Will refactor this later
 	BISECT_INFO
 )
@@ -43,6 +44,7 @@ func getScopeNames(scopes []RefreshableView) []string {
 		TAGS:       "tags",
 		REMOTES:    "remotes",
 		STATUS:     "status",
+		WORKTREES:  "worktrees",
 	}
 
 	scopeNames := make([]string, len(scopes))
@@ -107,7 +109,7 @@ func (gui *Gui) refreshSidePanels(options refreshOptions) error {
 	f := func() {
 		var scopeMap map[RefreshableView]bool
 		if len(options.scope) == 0 {
-			scopeMap = arrToMap([]RefreshableView{COMMITS, BRANCHES, FILES, STASH, REFLOG, TAGS, REMOTES, STATUS, BISECT_INFO})
+			scopeMap = arrToMap([]RefreshableView{COMMITS, BRANCHES, FILES, STASH, REFLOG, TAGS, REMOTES, WORKTREES, STATUS, BISECT_INFO})
 		} else {
 			scopeMap = arrToMap(options.scope)
 		}
@@ -172,6 +174,18 @@ func (gui *Gui) refreshSidePanels(options refreshOptions) error {
 			}()
 		}
 
+		if scopeMap[WORKTREES] {
+			wg.Add(1)
+			func() {
+				if options.mode == ASYNC {
+					go utils.Safe(func() { _ = gui.refreshWorktrees() })
+				} else {
+					_ = gui.refreshWorktrees()
+				}
+				wg.Done()
+			}()
+		}
+
 		wg.Wait()
 
 		gui.refreshStatus()
@@ -290,6 +304,12 @@ func (gui *Gui) refreshSelectedLine(panelState IListPanelState, total int) {
 	} else if total-1 < line {
 		panelState.SetSelectedLineIdx(total - 1)
 	}
+
+	// if the list has shrunk beneath the start of our range, we can no longer
+	// make sense of the range so we'll drop it
+	if _, lastIdx := panelState.GetSelectedRange(); lastIdx > total-1 {
+		panelState.CancelRangeSelect()
+	}
 }
 
 func (gui *Gui) renderDisplayStrings(v *gocui.View, displayStrings [][]string) {
@@ -0,0 +1,228 @@
+package gui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// list panel functions
+
+func (gui *Gui) getSelectedWorktree() *models.Worktree {
+	selectedLine := gui.State.Panels.Worktrees.SelectedLineIdx
+	if selectedLine == -1 || len(gui.State.Worktrees) == 0 {
+		return nil
+	}
+
+	return gui.State.Worktrees[selectedLine]
+}
+
+func (gui *Gui) worktreesRenderToMain() error {
+	var task updateTask
+	worktree := gui.getSelectedWorktree()
+	if worktree == nil {
+		task = NewRenderStringTask(gui.Tr.NoWorktrees)
+	} else {
+		prefix := fmt.Sprintf(
+			"Path: %s\nHead: %s\n",
+			style.FgMagenta.Sprint(worktree.Path),
+			style.FgYellow.Sprint(worktree.HeadDescription()),
+		)
+		if worktree.Locked {
+			prefix += fmt.Sprintf("Locked: %s\n", style.FgRed.Sprint(worktree.LockReason))
+		}
+		prefix += "\n"
+
+		if worktree.Bare || worktree.Head == "" {
+			task = NewRenderStringTask(prefix)
+		} else {
+			cmdObj := gui.Git.Branch.GetGraphCmdObj(worktree.Head)
+			task = NewRunCommandTaskWithPrefix(cmdObj.GetCmd(), prefix)
+		}
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: "Worktree",
+			task:  task,
+		},
+	})
+}
+
+func (gui *Gui) refreshWorktrees() error {
+	worktrees, err := gui.Git.Loaders.Worktrees.GetWorktrees()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.Worktrees = worktrees
+
+	return gui.postRefreshUpdate(gui.State.Contexts.Worktrees)
+}
+
+func (gui *Gui) withSelectedWorktree(f func(worktree *models.Worktree) error) func() error {
+	return func() error {
+		worktree := gui.getSelectedWorktree()
+		if worktree == nil {
+			return nil
+		}
+
+		return f(worktree)
+	}
+}
+
+// worktree-specific handlers
+
+func (gui *Gui) handleSwitchToWorktree(worktree *models.Worktree) error {
+	if worktree.Current {
+		return gui.createErrorPanel(gui.Tr.AlreadyInWorktree)
+	}
+
+	if worktree.Bare {
+		return gui.createErrorPanel(gui.Tr.CantSwitchToBareWorktree)
+	}
+
+	// same as when switching to a recent repo: we don't want hitting escape in
+	// the worktree to take us back to some submodule's superproject
+	gui.RepoPathStack = []string{}
+	return gui.dispatchSwitchToRepo(worktree.Path, false)
+}
+
+func (gui *Gui) handleCreateWorktree() error {
+	initialBase := ""
+	if branch := gui.getCheckedOutBranch(); branch != nil {
+		initialBase = branch.Name
+	}
+
+	return gui.prompt(promptOpts{
+		title:               gui.Tr.NewWorktreeBase,
+		initialContent:      initialBase,
+		findSuggestionsFunc: gui.getRefsSuggestionsFunc(),
+		handleConfirm: func(base string) error {
+			return gui.createWorktreeFromRef(base)
+		},
+	})
+}
+
+func (gui *Gui) createWorktreeFromRef(base string) error {
+	branchPrompt := utils.ResolvePlaceholderString(
+		gui.Tr.NewWorktreeBranch,
+		map[string]string{
+			"ref": base,
+		},
+	)
+
+	return gui.prompt(promptOpts{
+		title: branchPrompt,
+		handleConfirm: func(newBranch string) error {
+			newBranch = sanitizedBranchName(strings.TrimSpace(newBranch))
+
+			pathSuggestion := newBranch
+			if pathSuggestion == "" {
+				pathSuggestion = base
+			}
+			pathSuggestion = filepath.Join("..", strings.ReplaceAll(pathSuggestion, "/", "-"))
+
+			return gui.prompt(promptOpts{
+				title:          gui.Tr.NewWorktreePath,
+				initialContent: pathSuggestion,
+				handleConfirm: func(path string) error {
+					return gui.WithWaitingStatus(gui.Tr.LcCreatingWorktreeStatus, func() error {
+						gui.logAction(gui.Tr.Actions.CreateWorktree)
+						err := gui.Git.Worktree.New(git_commands.NewWorktreeOpts{
+							Path:   path,
+							Base:   base,
+							Branch: newBranch,
+						})
+						if err != nil {
+							return gui.surfaceError(err)
+						}
+
+						return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{WORKTREES, BRANCHES}})
+					})
+				},
+			})
+		},
+	})
+}
+
+func (gui *Gui) handleRemoveWorktree(worktree *models.Worktree) error {
+	if worktree.Main {
+		return gui.createErrorPanel(gui.Tr.CantRemoveMainWorktree)
+	}
+
+	if worktree.Current {
+		return gui.createErrorPanel(gui.Tr.CantRemoveCurrentWorktree)
+	}
+
+	return gui.removeWorktree(worktree, false)
+}
+
+func (gui *Gui) removeWorktree(worktree *models.Worktree, force bool) error {
+	templateStr := gui.Tr.RemoveWorktreePrompt
+	if force {
+		templateStr = gui.Tr.ForceRemoveWorktreePrompt
+	}
+	prompt := utils.ResolvePlaceholderString(
+		templateStr,
+		map[string]string{
+			"path": worktree.Path,
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.RemoveWorktreeTitle,
+		prompt: prompt,
+		handleConfirm: func() error {
+			gui.logAction(gui.Tr.Actions.RemoveWorktree)
+			if err := gui.Git.Worktree.Delete(worktree.Path, force); err != nil {
+				errMessage := err.Error()
+				if !force && strings.Contains(errMessage, "use --force") {
+					return gui.removeWorktree(worktree, true)
+				}
+				return gui.createErrorPanel(errMessage)
+			}
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{WORKTREES, BRANCHES}})
+		},
+	})
+}
+
+func (gui *Gui) handlePruneWorktrees() error {
+	return gui.ask(askOpts{
+		title:  gui.Tr.PruneWorktreesTitle,
+		prompt: gui.Tr.PruneWorktreesPrompt,
+		handleConfirm: func() error {
+			gui.logAction(gui.Tr.Actions.PruneWorktrees)
+			if err := gui.Git.Worktree.Prune(); err != nil {
+				return gui.surfaceError(err)
+			}
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{WORKTREES}})
+		},
+	})
+}
+
+func (gui *Gui) handleToggleWorktreeLock(worktree *models.Worktree) error {
+	if worktree.Locked {
+		gui.logAction(gui.Tr.Actions.UnlockWorktree)
+		if err := gui.Git.Worktree.Unlock(worktree.Path); err != nil {
+			return gui.surfaceError(err)
+		}
+		return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{WORKTREES}})
+	}
+
+	return gui.prompt(promptOpts{
+		title: gui.Tr.WorktreeLockReason,
+		handleConfirm: func(reason string) error {
+			gui.logAction(gui.Tr.Actions.LockWorktree)
+			if err := gui.Git.Worktree.Lock(worktree.Path, strings.TrimSpace(reason)); err != nil {
+				return gui.surfaceError(err)
+			}
+			return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{WORKTREES}})
+		},
+	})
+}
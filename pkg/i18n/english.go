@@ -454,6 +454,206 @@ type TranslationSet struct {
 	CantChangeContextSizeError          string
 	LcOpenCommitInBrowser               string
 	LcViewBisectOptions                 string
+	WorktreesTitle                      string
+	LcCreateWorktree                    string
+	LcRemoveWorktree                    string
+	LcSwitchToWorktree                  string
+	LcPruneWorktrees                    string
+	LcToggleWorktreeLock                string
+	NewWorktreeBase                     string
+	NewWorktreePath                     string
+	NewWorktreeBranch                   string
+	LcCreatingWorktreeStatus            string
+	RemoveWorktreeTitle                 string
+	RemoveWorktreePrompt                string
+	ForceRemoveWorktreePrompt           string
+	PruneWorktreesTitle                 string
+	PruneWorktreesPrompt                string
+	WorktreeLockReason                  string
+	CantRemoveMainWorktree              string
+	CantRemoveCurrentWorktree           string
+	AlreadyInWorktree                   string
+	CantSwitchToBareWorktree            string
+	NoWorktrees                         string
+	BlameTitle                          string
+	LcViewBlame                         string
+	LcGoToBlamedCommit                  string
+	LcBlameParent                       string
+	BlameLineNotCommitted               string
+	BlameNoParent                       string
+	LcExitBlame                         string
+	LcToggleRangeSelect                 string
+	SureSquashSelectedCommits           string
+	SureFixupSelectedCommits            string
+	DeleteSelectedCommitsPrompt         string
+	SelectionIncludesNonRebasingCommits string
+	ErrStageRangeWithMergeConflicts     string
+	DiscardSelectedFilesTitle           string
+	DeleteSelectedBranchesMessage       string
+	ForceDeleteBranchesMessage          string
+	DeleteSelectedTagsPrompt            string
+	SureDropSelectedStashEntries        string
+	CannotChangeTodoCommand             string
+	LcInsertExecTodo                    string
+	LcInsertBreakTodo                   string
+	ExecTodoPrompt                      string
+	MustBeMidInteractiveRebase          string
+	RebaseMergesMenuTitle               string
+	LcPreserveMergeCommits              string
+	LcFlattenMergeCommits               string
+	CannotChangeMergeCommitsInRange     string
+	UnsupportedForgeApi                 string
+	PullRequestNumber                   string
+	InvalidPullRequestNumber            string
+	UnsupportedPullRequestCheckout      string
+	FetchingPullRequestStatus           string
+	LcCheckoutPullRequest               string
+	AddTrailer                          string
+	LcAddTrailer                        string
+	ConventionalCommitType              string
+	ConventionalCommitScope             string
+	LcSetConventionalCommitType         string
+	LintSubjectTooLong                  string
+	LintSecondLineNotBlank              string
+	LintBodyLineTooLong                 string
+	LintNotConventionalCommit           string
+	CommitMessageLintFailed             string
+	LcRenameStash                       string
+	RenameStash                         string
+	LcCreateBranchFromStash             string
+	NewBranchFromStash                  string
+	LcStashIncludingUntracked           string
+	LcStashSelectedFiles                string
+	LcStashPathspec                     string
+	StashPathspec                       string
+	NoFilesToStash                      string
+	LcStashSelectedLines                string
+	CantStashStagedLines                string
+	CantStashUntrackedFileLines         string
+	TagSortOrderMenuTitle               string
+	LcSortByDate                        string
+	LcSortByVersion                     string
+	LcSortByName                        string
+	LcChangeSortOrder                   string
+	LcDeleteRemoteTag                   string
+	DeleteRemoteTagTitle                string
+	DeleteRemoteTagPrompt               string
+	DeletingRemoteTagStatus             string
+	LcFetchRemoteTags                   string
+	RemoteTagsMenuTitle                 string
+	LcFetchTagsFromRemote               string
+	LcPruneTagsFromRemote               string
+	FetchTagsTitle                      string
+	FetchingTagsStatus                  string
+	BranchSortOrderMenuTitle            string
+	LcSortByRecency                     string
+	LcSortAlphabetically                string
+	LcSortByCommitDate                  string
+	LcSortByDivergence                  string
+	LcGroupBranchesByPrefix             string
+	LcUngroupBranches                   string
+	LcSortAndGroupBranches              string
+	CleanupBranchesMenuTitle            string
+	LcCleanupBranches                   string
+	LcDeleteSelectedBranches            string
+	LcDeleteSelectedBranchesAndRemotes  string
+	LcSelectedCount                     string
+	LcMergedIntoBranch                  string
+	LcUpstreamGone                      string
+	LcNoCommitsForDays                  string
+	CouldNotDetermineMainBranch         string
+	NoBranchesToCleanUp                 string
+	NoBranchesSelected                  string
+	CleanupBranchesPrompt               string
+	SetBaseBranchPrompt                 string
+	LcSetBaseBranch                     string
+	LcRebaseOntoBaseBranch              string
+	NoBaseBranch                        string
+	LcSearchCommitHistory               string
+	SearchCommitHistory                 string
+	SearchCommitMessages                string
+	SearchCommitAuthors                 string
+	SearchAddedOrRemovedText            string
+	SearchChangedLines                  string
+	SearchingCommitsStatus              string
+	NoCommitsMatchSearch                string
+	LcViewCommitFilesOrGoToCommit       string
+	LcStartFilter                       string
+	LcFilteringListBy                   string
+	LcToggleThreeWayMergeView           string
+	ThreeWayMergeTitle                  string
+	MergeResultTitle                    string
+	ConflictEditTitle                   string
+	LcEditConflict                      string
+	LcPickLine                          string
+	LcApplyConflictEdit                 string
+	LcUnpickLastLine                    string
+	ConflictEditResultTitle             string
+	ApplyEmptyConflictEditTitle         string
+	ApplyEmptyConflictEditPrompt        string
+	ResolveConflictTitle                string
+	LcKeepOurVersion                    string
+	LcKeepTheirVersion                  string
+	LcKeepFileDeleted                   string
+	LcKeepBothVersions                  string
+	OurVersionFileName                  string
+	TheirVersionFileName                string
+	ErrConflictVersionNamesMatch        string
+	ErrConflictVersionNameIsOriginal    string
+	ErrConflictVersionFileExists        string
+	BothDeletedConflict                 string
+	AddedByUsConflict                   string
+	AddedByThemConflict                 string
+	DeletedByUsConflict                 string
+	DeletedByThemConflict               string
+	BinaryConflict                      string
+	PressKeyToResolveConflict           string
+	RerereOptionsTitle                  string
+	LcViewRerereOptions                 string
+	LcForgetRerereResolution            string
+	ForgetRerereResolutionTitle         string
+	ForgetRerereResolutionPrompt        string
+	LcShowRerereDiff                    string
+	RerereDiffTitle                     string
+	LcBrowseRerereCache                 string
+	RerereCacheTitle                    string
+	NoRerereEntries                     string
+	LcViewRerereResolution              string
+	LcDeleteRerereEntry                 string
+	DeleteRerereEntryTitle              string
+	DeleteRerereEntryPrompt             string
+	RerereResolved                      string
+	RerereUnresolved                    string
+	ResolvedByRerereTitle               string
+	InvalidPatchName                    string
+	LcExportPatch                       string
+	LcSavePatch                         string
+	LcListSavedPatches                  string
+	LcApplyPatchFile                    string
+	EmptyPatchError                     string
+	ExportPatchPrompt                   string
+	ExportPatchOverwriteTitle           string
+	ExportPatchOverwritePrompt          string
+	SavePatchPrompt                     string
+	NoSavedPatches                      string
+	SavedPatchesTitle                   string
+	SavedPatchFileCount                 string
+	LcOpenSavedPatch                    string
+	LcApplySavedPatch                   string
+	LcDeleteSavedPatch                  string
+	DeleteSavedPatchTitle               string
+	DeleteSavedPatchPrompt              string
+	ReplacePatchConfirm                 string
+	ApplyPatchFilePrompt                string
+	ApplyPatchFileTitle                 string
+	ApplyPatchPreviewTitle              string
+	LcApplyPatchToWorkingTree           string
+	LcApplyPatchToIndex                 string
+	PatchAppliesCleanly                 string
+	PatchDoesNotApplyCleanly            string
+	CleanupUnmergedBranchesTitle        string
+	CleanupUnmergedBranchesPrompt       string
+	CleanupKeptUpstreamsNote            string
 	Actions                             Actions
 	Bisect                              Bisect
 }
@@ -562,6 +762,33 @@ type Actions struct {
 	ResetBisect                       string
 	BisectSkip                        string
 	BisectMark                        string
+	CreateWorktree                    string
+	RemoveWorktree                    string
+	PruneWorktrees                    string
+	LockWorktree                      string
+	UnlockWorktree                    string
+	DiscardAllChangesInSelection      string
+	DiscardUnstagedChangesInSelection string
+	InsertExecTodo                    string
+	InsertBreakTodo                   string
+	CheckoutPullRequest               string
+	RenameStash                       string
+	CreateBranchFromStash             string
+	StashIncludingUntrackedChanges    string
+	StashSelectedFiles                string
+	StashPathspec                     string
+	StashSelectedLines                string
+	DeleteRemoteTag                   string
+	FetchTags                         string
+	PruneTags                         string
+	CleanupBranches                   string
+	ResolveMergeConflict              string
+	ForgetRerereResolution            string
+	DeleteRerereEntry                 string
+	ExportPatch                       string
+	SavePatch                         string
+	DeleteSavedPatch                  string
+	ApplyPatchFile                    string
 }
 
 const englishIntroPopupMessage = `
@@ -1027,6 +1254,205 @@ func EnglishTranslationSet() TranslationSet {
 		CantChangeContextSizeError:          "Cannot change context while in patch building mode because we were too lazy to support it when releasing the feature. If you really want it, please let us know!",
 		LcOpenCommitInBrowser:               "open commit in browser",
 		LcViewBisectOptions:                 "view bisect options",
+		WorktreesTitle:                      "Worktrees",
+		LcCreateWorktree:                    "create worktree",
+		LcRemoveWorktree:                    "remove worktree",
+		LcSwitchToWorktree:                  "switch to worktree",
+		LcPruneWorktrees:                    "prune worktrees",
+		LcToggleWorktreeLock:                "lock/unlock worktree",
+		NewWorktreeBase:                     "Create worktree from (branch or commit):",
+		NewWorktreePath:                     "Path of new worktree:",
+		NewWorktreeBranch:                   "New branch name (leave blank to check out {{ref}}):",
+		LcCreatingWorktreeStatus:            "creating worktree",
+		RemoveWorktreeTitle:                 "Remove worktree",
+		RemoveWorktreePrompt:                "Are you sure you want to remove worktree '{{path}}'?",
+		ForceRemoveWorktreePrompt:           "Worktree '{{path}}' contains modified or untracked files. Are you sure you want to force remove it?",
+		PruneWorktreesTitle:                 "Prune worktrees",
+		PruneWorktreesPrompt:                "Are you sure you want to prune the administrative files of worktrees whose directories no longer exist?",
+		WorktreeLockReason:                  "Lock reason (optional):",
+		CantRemoveMainWorktree:              "Cannot remove the main worktree",
+		CantRemoveCurrentWorktree:           "Cannot remove the worktree that lazygit is currently in",
+		AlreadyInWorktree:                   "You are already in this worktree",
+		CantSwitchToBareWorktree:            "Cannot switch to a bare repository",
+		NoWorktrees:                         "No worktrees",
+		BlameTitle:                          "Blame",
+		LcViewBlame:                         "view blame for file",
+		LcGoToBlamedCommit:                  "go to the commit that last changed this line",
+		LcBlameParent:                       "blame parent of this commit",
+		BlameLineNotCommitted:               "This line has not been committed yet",
+		BlameNoParent:                       "Commit {{sha}} has no parent to blame: the line was added in the first commit",
+		LcExitBlame:                         "exit blame",
+		LcToggleRangeSelect:                 "toggle range select",
+		SureSquashSelectedCommits:           "Are you sure you want to squash the selected commits into the commit below?",
+		SureFixupSelectedCommits:            "Are you sure you want to 'fixup' the selected commits? They will be merged into the commit below",
+		DeleteSelectedCommitsPrompt:         "Are you sure you want to delete the selected commits?",
+		SelectionIncludesNonRebasingCommits: "Cannot update the rebase todo: the selection includes commits which are not part of the rebase",
+		ErrStageRangeWithMergeConflicts:     "Cannot stage/unstage a selection containing files with inline merge conflicts. Please fix up the merge conflicts first",
+		DiscardSelectedFilesTitle:           "Discard selected files",
+		DeleteSelectedBranchesMessage:       "Are you sure you want to delete the selected branches?",
+		ForceDeleteBranchesMessage:          "The following branches are not fully merged: {{.branchNames}}. Are you sure you want to delete them?",
+		DeleteSelectedTagsPrompt:            "Are you sure you want to delete the selected tags?",
+		SureDropSelectedStashEntries:        "Are you sure you want to drop the selected stash entries?",
+		CannotChangeTodoCommand:             "Cannot change the action of a '{{.command}}' line in the rebase todo",
+		LcInsertExecTodo:                    "insert exec line above selected commit (when mid-rebase)",
+		LcInsertBreakTodo:                   "insert break line above selected commit (when mid-rebase)",
+		ExecTodoPrompt:                      "Command to execute:",
+		MustBeMidInteractiveRebase:          "You can only add to the rebase todo during an interactive rebase, above the commit currently being rebased",
+		RebaseMergesMenuTitle:               "Rebase over merge commits",
+		LcPreserveMergeCommits:              "preserve merge commits (--rebase-merges)",
+		LcFlattenMergeCommits:               "flatten history (drop merge commits)",
+		UnsupportedForgeApi:                 "Fetching pull requests is only supported for GitHub and GitLab",
+		PullRequestNumber:                   "Pull request number:",
+		InvalidPullRequestNumber:            "Please enter the number of a pull request",
+		UnsupportedPullRequestCheckout:      "Checking out pull requests is not supported for this git service",
+		FetchingPullRequestStatus:           "fetching pull request",
+		LcCheckoutPullRequest:               "checkout pull request by number",
+		AddTrailer:                          "Add trailer",
+		LcAddTrailer:                        "add trailer (e.g. Co-authored-by)",
+		ConventionalCommitType:              "Conventional commit type",
+		ConventionalCommitScope:             "Scope (optional):",
+		LcSetConventionalCommitType:         "set conventional commit type",
+		LintSubjectTooLong:                  "subject is longer than {{.max}} characters",
+		LintSecondLineNotBlank:              "second line should be blank",
+		LintBodyLineTooLong:                 "line {{.line}} is longer than {{.max}} characters",
+		LintNotConventionalCommit:           "subject should be like 'type(scope): description'",
+		CommitMessageLintFailed:             "Commit message breaks the configured lint rules:",
+		LcRenameStash:                       "rename stash",
+		RenameStash:                         "Rename stash: {{.stashName}}",
+		LcCreateBranchFromStash:             "create new branch from stash",
+		NewBranchFromStash:                  "New branch name (stash is applied on top of the commit it was based on)",
+		LcStashIncludingUntracked:           "stash all changes including untracked files",
+		LcStashSelectedFiles:                "stash selected files",
+		LcStashPathspec:                     "stash pathspec",
+		StashPathspec:                       "Paths to stash (space-separated, quote paths containing spaces)",
+		NoFilesToStash:                      "You have no files to stash",
+		LcStashSelectedLines:                "stash selected lines",
+		CantStashStagedLines:                "Only unstaged changes can be stashed line by line. Switch to the unstaged changes view to stash a selection",
+		CantStashUntrackedFileLines:         "Untracked files can't be stashed line by line. Use 'stash selected files' from the stash options menu in the files panel instead",
+		TagSortOrderMenuTitle:               "Sort tags by",
+		LcSortByDate:                        "date (newest first)",
+		LcSortByVersion:                     "version (highest first)",
+		LcSortByName:                        "name",
+		LcChangeSortOrder:                   "change sort order",
+		LcDeleteRemoteTag:                   "delete tag on remote",
+		DeleteRemoteTagTitle:                "remote to delete tag '{{.tagName}}' from:",
+		DeleteRemoteTagPrompt:               "Are you sure you want to delete tag '{{.tagName}}' from '{{.remoteName}}'?",
+		DeletingRemoteTagStatus:             "deleting remote tag",
+		LcFetchRemoteTags:                   "fetch remote tags",
+		RemoteTagsMenuTitle:                 "Remote tags",
+		LcFetchTagsFromRemote:               "fetch tags from remote",
+		LcPruneTagsFromRemote:               "fetch tags and delete local tags that aren't on the remote",
+		FetchTagsTitle:                      "remote to fetch tags from:",
+		FetchingTagsStatus:                  "fetching tags",
+		BranchSortOrderMenuTitle:            "Sort/group branches",
+		LcSortByRecency:                     "sort by recency (last checked out first)",
+		LcSortAlphabetically:                "sort alphabetically",
+		LcSortByCommitDate:                  "sort by last commit date (newest first)",
+		LcSortByDivergence:                  "sort by commits ahead/behind upstream (most first)",
+		LcGroupBranchesByPrefix:             "group by prefix (e.g. 'feature/')",
+		LcUngroupBranches:                   "stop grouping by prefix",
+		LcSortAndGroupBranches:              "sort/group branches",
+		CleanupBranchesMenuTitle:            "Clean up branches",
+		LcCleanupBranches:                   "clean up merged/gone/stale branches",
+		LcDeleteSelectedBranches:            "delete selected branches",
+		LcDeleteSelectedBranchesAndRemotes:  "delete selected branches and their remote branches",
+		LcSelectedCount:                     "({{count}} selected)",
+		LcMergedIntoBranch:                  "merged into {{branchName}}",
+		LcUpstreamGone:                      "upstream gone",
+		LcNoCommitsForDays:                  "no commits in {{days}} days",
+		CouldNotDetermineMainBranch:         "Could not find a 'main' or 'master' branch. Set git.mainBranch in your config to clean up branches",
+		NoBranchesToCleanUp:                 "No branches are merged, missing their upstream or stale",
+		NoBranchesSelected:                  "No branches selected",
+		CleanupBranchesPrompt:               "Are you sure you want to delete {{count}} branches?",
+		SetBaseBranchPrompt:                 "Base branch to compare branches against (leave empty to stop comparing):",
+		LcSetBaseBranch:                     "set base branch (e.g. origin/main) to show ahead/behind counts against",
+		LcRebaseOntoBaseBranch:              "rebase checked-out branch onto its base branch",
+		NoBaseBranch:                        "No base branch set. Set one for this repo from the branches panel, or for this branch with `git config branch.<name>.base <base>`",
+		LcSearchCommitHistory:               "search commit history",
+		SearchCommitHistory:                 "Search commit history",
+		SearchCommitMessages:                "commit message (git log --grep)",
+		SearchCommitAuthors:                 "author (git log --author)",
+		SearchAddedOrRemovedText:            "added or removed text (git log -S)",
+		SearchChangedLines:                  "changed lines matching regex (git log -G)",
+		SearchingCommitsStatus:              "searching",
+		NoCommitsMatchSearch:                "No commits match the search",
+		LcViewCommitFilesOrGoToCommit:       "view commit's files, or go to the commit when showing search results",
+		LcStartFilter:                       "filter the list",
+		LcFilteringListBy:                   "filtering list by",
+		LcToggleThreeWayMergeView:           "toggle three-way view",
+		ThreeWayMergeTitle:                  "Base │ Ours │ Theirs",
+		MergeResultTitle:                    "Result",
+		ConflictEditTitle:                   "Edit conflict",
+		LcEditConflict:                      "edit conflict line by line",
+		LcPickLine:                          "pick/unpick line",
+		LcApplyConflictEdit:                 "apply picked lines",
+		LcUnpickLastLine:                    "unpick last line",
+		ConflictEditResultTitle:             "Resolved conflict",
+		ApplyEmptyConflictEditTitle:         "Remove conflict",
+		ApplyEmptyConflictEditPrompt:        "You haven't picked any lines, so this will remove both sides of the conflict. Are you sure?",
+		ResolveConflictTitle:                "Resolve conflict",
+		LcKeepOurVersion:                    "keep our version",
+		LcKeepTheirVersion:                  "keep their version",
+		LcKeepFileDeleted:                   "delete the file",
+		LcKeepBothVersions:                  "keep both under different names",
+		OurVersionFileName:                  "Name for our version",
+		TheirVersionFileName:                "Name for their version",
+		ErrConflictVersionNamesMatch:        "Our version and their version need different names",
+		ErrConflictVersionNameIsOriginal:    "Neither version can keep the conflicted file's name, given that the conflicted file will be removed",
+		ErrConflictVersionFileExists:        "'%s' already exists, please choose another name",
+		BothDeletedConflict:                 "Both sides deleted '{{.file}}'. This usually means it was renamed differently on each side, in which case each new name shows up as a separate conflict.",
+		AddedByUsConflict:                   "'{{.file}}' was added on our side only. This usually means we renamed a file that was deleted or renamed differently on their side.",
+		AddedByThemConflict:                 "'{{.file}}' was added on their side only. This usually means they renamed a file that was deleted or renamed differently on our side.",
+		DeletedByUsConflict:                 "We deleted '{{.file}}' but they modified it.",
+		DeletedByThemConflict:               "We modified '{{.file}}' but they deleted it.",
+		BinaryConflict:                      "Both sides changed '{{.file}}', which is a binary file, so git can't merge the changes.",
+		PressKeyToResolveConflict:           "Press {{.key}} to choose how to resolve the conflict.",
+		RerereOptionsTitle:                  "Rerere options",
+		LcViewRerereOptions:                 "view rerere options",
+		LcForgetRerereResolution:            "forget recorded resolution for '{{.file}}'",
+		ForgetRerereResolutionTitle:         "Forget recorded resolution",
+		ForgetRerereResolutionPrompt:        "Are you sure you want rerere to forget how it resolved '{{.file}}'? The conflict markers will be restored so that you can resolve the file again.",
+		LcShowRerereDiff:                    "show the resolutions rerere will record",
+		RerereDiffTitle:                     "Rerere diff",
+		LcBrowseRerereCache:                 "browse recorded resolutions",
+		RerereCacheTitle:                    "Recorded resolutions",
+		NoRerereEntries:                     "Rerere hasn't recorded any conflicts",
+		LcViewRerereResolution:              "view resolution",
+		LcDeleteRerereEntry:                 "delete recorded resolution",
+		DeleteRerereEntryTitle:              "Delete recorded resolution",
+		DeleteRerereEntryPrompt:             "Are you sure you want to delete recorded resolution {{.hash}}? Rerere will no longer reuse it.",
+		RerereResolved:                      "resolved",
+		RerereUnresolved:                    "unresolved",
+		ResolvedByRerereTitle:               "Resolved by rerere",
+		InvalidPatchName:                    "Patch names can't be empty, start with a '.' or contain slashes",
+		LcExportPatch:                       "export patch to file",
+		LcSavePatch:                         "save patch as",
+		LcListSavedPatches:                  "list saved patches",
+		LcApplyPatchFile:                    "apply patch file",
+		EmptyPatchError:                     "The patch is empty. Add some files or lines to it first",
+		ExportPatchPrompt:                   "Export patch to file:",
+		ExportPatchOverwriteTitle:           "Overwrite file",
+		ExportPatchOverwritePrompt:          "'{{.path}}' already exists. Are you sure you want to overwrite it?",
+		SavePatchPrompt:                     "Save patch as:",
+		NoSavedPatches:                      "No patches have been saved for this repo",
+		SavedPatchesTitle:                   "Saved patches",
+		SavedPatchFileCount:                 "{{.count}} file(s)",
+		LcOpenSavedPatch:                    "open in patch building mode",
+		LcApplySavedPatch:                   "apply",
+		LcDeleteSavedPatch:                  "delete",
+		DeleteSavedPatchTitle:               "Delete saved patch",
+		DeleteSavedPatchPrompt:              "Are you sure you want to delete the saved patch '{{.name}}'?",
+		ReplacePatchConfirm:                 "Opening a saved patch will replace the patch you're currently building. Continue?",
+		ApplyPatchFilePrompt:                "Path of patch file to apply:",
+		ApplyPatchFileTitle:                 "Apply {{.name}}",
+		ApplyPatchPreviewTitle:              "Patch preview",
+		LcApplyPatchToWorkingTree:           "apply to working tree",
+		LcApplyPatchToIndex:                 "apply to index",
+		PatchAppliesCleanly:                 "applies cleanly",
+		PatchDoesNotApplyCleanly:            "does not apply cleanly",
+		CleanupUnmergedBranchesTitle:        "Delete unmerged branches",
+		CleanupUnmergedBranchesPrompt:       "These branches aren't merged into {{mainBranch}}, so any commits that are only on them will be lost:\n\n{{branches}}\n\nAre you sure you want to delete {{count}} branches?",
+		CleanupKeptUpstreamsNote:            "\n\nThese remote branches have commits that their local branches don't, so they'll be kept:\n\n{{branches}}",
 		Actions: Actions{
 			// TODO: combine this with the original keybinding descriptions (those are all in lowercase atm)
 			CheckoutCommit:                    "Checkout commit",
@@ -1117,6 +1543,33 @@ func EnglishTranslationSet() TranslationSet {
 			ResetBisect:                       "Reset bisect",
 			BisectSkip:                        "Bisect skip",
 			BisectMark:                        "Bisect mark",
+			CreateWorktree:                    "Create worktree",
+			RemoveWorktree:                    "Remove worktree",
+			PruneWorktrees:                    "Prune worktrees",
+			LockWorktree:                      "Lock worktree",
+			UnlockWorktree:                    "Unlock worktree",
+			DiscardAllChangesInSelection:      "Discard all changes in selection",
+			DiscardUnstagedChangesInSelection: "Discard unstaged changes in selection",
+			InsertExecTodo:                    "Insert exec line into rebase todo",
+			InsertBreakTodo:                   "Insert break line into rebase todo",
+			CheckoutPullRequest:               "Checkout pull request",
+			RenameStash:                       "Rename stash",
+			CreateBranchFromStash:             "Create branch from stash",
+			StashIncludingUntrackedChanges:    "Stash all changes including untracked files",
+			StashSelectedFiles:                "Stash selected files",
+			StashPathspec:                     "Stash pathspec",
+			StashSelectedLines:                "Stash selected lines",
+			DeleteRemoteTag:                   "Delete remote tag",
+			FetchTags:                         "Fetch tags",
+			PruneTags:                         "Prune tags",
+			CleanupBranches:                   "Clean up branches",
+			ResolveMergeConflict:              "Resolve merge conflict",
+			ForgetRerereResolution:            "Forget rerere resolution",
+			DeleteRerereEntry:                 "Delete rerere cache entry",
+			ExportPatch:                       "Export patch",
+			SavePatch:                         "Save patch",
+			DeleteSavedPatch:                  "Delete saved patch",
+			ApplyPatchFile:                    "Apply patch file",
 		},
 		Bisect: Bisect{
 			Mark:                        "mark %s as %s",
@@ -21,3 +21,29 @@ func FuzzySearch(needle string, haystack []string) []string {
 
 	return result
 }
+
+// FuzzyFilter returns the indices of the items in the haystack which fuzzily
+// match the needle, in their original order. An empty needle matches everything
+func FuzzyFilter(needle string, haystack []string) []int {
+	if needle == "" {
+		result := make([]int, len(haystack))
+		for i := range haystack {
+			result[i] = i
+		}
+		return result
+	}
+
+	matched := map[string]bool{}
+	for _, str := range FuzzySearch(needle, haystack) {
+		matched[str] = true
+	}
+
+	result := []int{}
+	for i, str := range haystack {
+		if matched[str] {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
@@ -51,3 +51,33 @@ func TestFuzzySearch(t *testing.T) {
 		assert.EqualValues(t, s.expected, FuzzySearch(s.needle, s.haystack))
 	}
 }
+
+func TestFuzzyFilter(t *testing.T) {
+	type scenario struct {
+		needle   string
+		haystack []string
+		expected []int
+	}
+
+	scenarios := []scenario{
+		{
+			needle:   "",
+			haystack: []string{"a", "b"},
+			expected: []int{0, 1},
+		},
+		{
+			needle:   "mybranch",
+			haystack: []string{"branch", "this is my branch", "mybranch", "my_branch"},
+			expected: []int{1, 2, 3},
+		},
+		{
+			needle:   "zzz",
+			haystack: []string{"a", "b"},
+			expected: []int{},
+		},
+	}
+
+	for _, s := range scenarios {
+		assert.EqualValues(t, s.expected, FuzzyFilter(s.needle, s.haystack))
+	}
+}